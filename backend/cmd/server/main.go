@@ -7,30 +7,91 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
-	"time"
 
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/config"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/logging"
 	"github.com/envoyproxy/ai-gateway/console/backend/internal/router"
 	"github.com/envoyproxy/ai-gateway/console/backend/internal/server"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/tracing"
+	"github.com/go-logr/logr"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 func main() {
-	log.Println("Starting Envoy AI Gateway Console Backend...")
+	cfg, printConfig, err := config.Load(os.Args[1:], os.Getenv)
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(0)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
+		os.Exit(1)
+	}
+	if printConfig {
+		if err := cfg.Print(os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to print configuration: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid configuration: %v\n", err)
+		os.Exit(1)
+	}
+	if printConfig {
+		return
+	}
+
+	logger, err := logging.New(cfg.Logging)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create logger: %v\n", err)
+		os.Exit(1)
+	}
+	ctrllog.SetLogger(logger.WithName("controller-runtime"))
+
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing, logger.WithName("tracing"))
+	if err != nil {
+		logger.Error(err, "Failed to configure tracing")
+		os.Exit(1)
+	}
 
-	// Get port from environment variable, default to 8081
-	port := os.Getenv("SERVER_PORT")
-	if port == "" {
-		port = "8080"
+	logger.Info("Starting Envoy AI Gateway Console Backend")
+	if len(cfg.Auth.Modes) > 0 {
+		logger.Info("Authentication modes", "modes", cfg.Auth.Modes)
+	}
+	if cfg.Auth.Impersonate {
+		logger.Info("Impersonating authenticated users for Kubernetes requests")
+	}
+	if !cfg.Authz.Enabled() {
+		logger.Info("No authorization policy configured, every authenticated user is an admin")
+	}
+	if len(cfg.Kubernetes.AllowedNamespaces) > 0 {
+		logger.Info("Restricted to namespaces", "namespaces", cfg.Kubernetes.AllowedNamespaces)
+	}
+	if len(cfg.Audit.Sinks) > 0 {
+		logger.Info("Audit sinks", "sinks", cfg.Audit.Sinks)
+	}
+	if cfg.Tracing.Exporter != tracing.ExporterNone {
+		logger.Info("Exporting traces", "exporter", cfg.Tracing.Exporter)
+	}
+	if cfg.Usage.MetricsURL != "" {
+		logger.Info("Scraping AI Gateway usage", "url", cfg.Usage.MetricsURL, "interval", cfg.Usage.ScrapeInterval.Duration)
 	}
 
 	// Create server
-	srv, err := server.NewServer()
+	serverConfig := cfg.ServerConfig()
+	serverConfig.Logger = logger
+	srv, err := server.NewServer(serverConfig)
 	if err != nil {
-		log.Fatalf("Failed to create server: %v", err)
+		logger.Error(err, "Failed to create server")
+		os.Exit(1)
 	}
 
 	// Create router
@@ -38,24 +99,26 @@ func main() {
 
 	// Setup HTTP server
 	httpServer := &http.Server{
-		Addr:         ":" + port,
+		Addr:         cfg.Server.Address,
 		Handler:      rt,
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
+		ReadTimeout:  cfg.Server.ReadTimeout.Duration,
+		WriteTimeout: cfg.Server.WriteTimeout.Duration,
+		ErrorLog:     log.New(logWriter{logger.WithName("http")}, "", 0),
 	}
 
 	// Start server in a goroutine
 	go func() {
-		log.Printf("Server starting on :%s", port)
-		log.Println("Available endpoints:")
-		log.Println("  GET /api/v1/llm/providers      - List all LLM providers")
-		log.Println("  POST /api/v1/llm/providers     - Create a new LLM provider")
-		log.Println("  GET /api/v1/llm/providers/{name} - Get specific LLM provider")
-		log.Println("  DELETE /api/v1/llm/providers/{name} - Delete an LLM provider")
-		log.Println("  GET /health                     - Health check")
-
-		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("Server failed to start: %v", err)
+		logger.Info("Server starting", "address", cfg.Server.Address, "tls", cfg.Server.TLS.Enabled())
+
+		var err error
+		if cfg.Server.TLS.Enabled() {
+			err = httpServer.ListenAndServeTLS(cfg.Server.TLS.CertFile, cfg.Server.TLS.KeyFile)
+		} else {
+			err = httpServer.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
+			logger.Error(err, "Server failed to start")
+			os.Exit(1)
 		}
 	}()
 
@@ -64,15 +127,30 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
-	log.Println("Shutting down server...")
+	logger.Info("Shutting down server")
 
 	// Gracefully shutdown with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout.Duration)
 	defer cancel()
 
 	if err := httpServer.Shutdown(ctx); err != nil {
-		log.Fatalf("Server forced to shutdown: %v", err)
+		logger.Error(err, "Server forced to shutdown")
+		os.Exit(1)
+	}
+	srv.Close()
+	if err := shutdownTracing(ctx); err != nil {
+		logger.Error(err, "Failed to flush traces")
 	}
 
-	log.Println("Server exited")
+	logger.Info("Server exited")
+}
+
+// logWriter logs the messages of the standard logger used by net/http
+type logWriter struct {
+	logger logr.Logger
+}
+
+func (w logWriter) Write(p []byte) (int, error) {
+	w.logger.Info(strings.TrimSpace(string(p)))
+	return len(p), nil
 }
@@ -0,0 +1,178 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+// Package apierror defines the typed errors returned by the console services
+// and their mapping to HTTP problem details responses.
+package apierror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Code classifies an error independently of the transport
+type Code string
+
+const (
+	CodeNotFound        Code = "NotFound"
+	CodeAlreadyExists   Code = "AlreadyExists"
+	CodeInvalid         Code = "Invalid"
+	CodeUnauthenticated Code = "Unauthenticated"
+	CodeForbidden       Code = "Forbidden"
+	CodeConflict        Code = "Conflict"
+	// CodePreconditionFailed is returned when If-Match does not match the current ETag
+	CodePreconditionFailed Code = "PreconditionFailed"
+	// CodePreconditionRequired is returned when a conditional request lacks If-Match
+	CodePreconditionRequired Code = "PreconditionRequired"
+	CodeUpstream             Code = "Upstream"
+	CodeUnavailable          Code = "Unavailable"
+	CodeInternal             Code = "Internal"
+)
+
+// Error is an error with a code and a message that is safe to return to API clients.
+// The wrapped cause is only meant for logs.
+type Error struct {
+	Code    Code
+	Message string
+	Err     error
+}
+
+// Error implements the error interface
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Err)
+	}
+	return e.Message
+}
+
+// Unwrap returns the underlying cause
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// New creates an error with the given code
+func New(code Code, format string, args ...any) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// Wrap creates an error with the given code that wraps a cause
+func Wrap(code Code, err error, format string, args ...any) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...), Err: err}
+}
+
+// NotFound creates a CodeNotFound error
+func NotFound(format string, args ...any) *Error {
+	return New(CodeNotFound, format, args...)
+}
+
+// Invalid creates a CodeInvalid error
+func Invalid(format string, args ...any) *Error {
+	return New(CodeInvalid, format, args...)
+}
+
+// Internal creates a CodeInternal error
+func Internal(format string, args ...any) *Error {
+	return New(CodeInternal, format, args...)
+}
+
+// FromKubernetes wraps an error returned by the Kubernetes API, deriving the code
+// from its status reason. Validation messages are kept since they describe the
+// client's input, other API server details are only kept in the cause.
+func FromKubernetes(err error, format string, args ...any) *Error {
+	if err == nil {
+		return nil
+	}
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+
+	wrapped := Wrap(kubernetesCode(err), err, format, args...)
+	if wrapped.Code == CodeInvalid {
+		var status k8serrors.APIStatus
+		if errors.As(err, &status) && status.Status().Message != "" {
+			wrapped.Message = fmt.Sprintf("%s: %s", wrapped.Message, status.Status().Message)
+		}
+	}
+	return wrapped
+}
+
+// kubernetesCode maps a Kubernetes status reason to an error code
+func kubernetesCode(err error) Code {
+	switch k8serrors.ReasonForError(err) {
+	case metav1.StatusReasonNotFound:
+		return CodeNotFound
+	case metav1.StatusReasonAlreadyExists:
+		return CodeAlreadyExists
+	case metav1.StatusReasonInvalid, metav1.StatusReasonBadRequest,
+		metav1.StatusReasonExpired, metav1.StatusReasonGone, metav1.StatusReasonRequestEntityTooLarge:
+		return CodeInvalid
+	case metav1.StatusReasonForbidden, metav1.StatusReasonUnauthorized:
+		return CodeForbidden
+	case metav1.StatusReasonConflict:
+		return CodeConflict
+	default:
+		// Server side failures, throttling and transport errors such as timeouts
+		return CodeUpstream
+	}
+}
+
+// CodeOf returns the code of an error, falling back to the Kubernetes status
+// reason and finally CodeInternal
+func CodeOf(err error) Code {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Code
+	}
+	var status k8serrors.APIStatus
+	if errors.As(err, &status) {
+		return kubernetesCode(err)
+	}
+	return CodeInternal
+}
+
+// MessageOf returns the client-safe message of an error
+func MessageOf(err error) string {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Message
+	}
+	return "internal server error"
+}
+
+// Is reports whether the error carries the given code
+func Is(err error, code Code) bool {
+	return err != nil && CodeOf(err) == code
+}
+
+// HTTPStatus returns the HTTP status code for an error code
+func HTTPStatus(code Code) int {
+	switch code {
+	case CodeNotFound:
+		return http.StatusNotFound
+	case CodeAlreadyExists, CodeConflict:
+		return http.StatusConflict
+	case CodePreconditionFailed:
+		return http.StatusPreconditionFailed
+	case CodePreconditionRequired:
+		return http.StatusPreconditionRequired
+	case CodeInvalid:
+		return http.StatusBadRequest
+	case CodeUnauthenticated:
+		return http.StatusUnauthorized
+	case CodeForbidden:
+		return http.StatusForbidden
+	case CodeUpstream:
+		return http.StatusBadGateway
+	case CodeUnavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package apierror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestFromKubernetes(t *testing.T) {
+	gr := schema.GroupResource{Group: "aigateway.envoyproxy.io", Resource: "aiservicebackends"}
+
+	testCases := []struct {
+		name   string
+		err    error
+		code   Code
+		status int
+	}{
+		{name: "NotFound", err: k8serrors.NewNotFound(gr, "openai"), code: CodeNotFound, status: http.StatusNotFound},
+		{name: "AlreadyExists", err: k8serrors.NewAlreadyExists(gr, "openai"), code: CodeAlreadyExists, status: http.StatusConflict},
+		{name: "Invalid", err: k8serrors.NewInvalid(schema.GroupKind{Kind: "Backend"}, "openai", nil), code: CodeInvalid, status: http.StatusBadRequest},
+		{name: "Forbidden", err: k8serrors.NewForbidden(gr, "openai", errors.New("denied")), code: CodeForbidden, status: http.StatusForbidden},
+		{name: "Conflict", err: k8serrors.NewConflict(gr, "openai", errors.New("modified")), code: CodeConflict, status: http.StatusConflict},
+		{name: "ServiceUnavailable", err: k8serrors.NewServiceUnavailable("etcd down"), code: CodeUpstream, status: http.StatusBadGateway},
+		{name: "Transport", err: errors.New("dial tcp: connection refused"), code: CodeUpstream, status: http.StatusBadGateway},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Errors are usually wrapped by the typed clients before reaching the services
+			wrapped := FromKubernetes(fmt.Errorf("failed to get AIServiceBackend: %w", tc.err), "failed to get provider")
+			assert.Equal(t, tc.code, wrapped.Code)
+			assert.Equal(t, tc.status, HTTPStatus(CodeOf(wrapped)))
+			assert.ErrorIs(t, wrapped, tc.err)
+		})
+	}
+}
+
+func TestNewProblemHidesCause(t *testing.T) {
+	err := Wrap(CodeUpstream, errors.New("secret internal detail"), "failed to list providers")
+	problem := NewProblem(fmt.Errorf("handler: %w", err), "/api/v1/llm/providers", "req-1")
+
+	assert.Equal(t, http.StatusBadGateway, problem.Status)
+	assert.Equal(t, CodeUpstream, problem.Code)
+	assert.Equal(t, "failed to list providers", problem.Detail)
+	assert.Equal(t, "req-1", problem.RequestID)
+
+	// Untyped errors never leak their message
+	problem = NewProblem(errors.New("boom"), "/health", "")
+	assert.Equal(t, http.StatusInternalServerError, problem.Status)
+	assert.Equal(t, "internal server error", problem.Detail)
+}
@@ -0,0 +1,47 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package apierror
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// ContentTypeProblem is the media type of problem details responses (RFC 9457)
+const ContentTypeProblem = "application/problem+json"
+
+// Problem is the JSON problem details body returned for every API error
+type Problem struct {
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Status    int    `json:"status"`
+	Detail    string `json:"detail,omitempty"`
+	Instance  string `json:"instance,omitempty"`
+	Code      Code   `json:"code"`
+	RequestID string `json:"requestId,omitempty"`
+}
+
+// NewProblem converts an error into problem details for the given request path
+func NewProblem(err error, instance, requestID string) Problem {
+	code := CodeOf(err)
+	status := HTTPStatus(code)
+	return Problem{
+		Type:      "about:blank",
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    MessageOf(err),
+		Instance:  instance,
+		Code:      code,
+		RequestID: requestID,
+	}
+}
+
+// Write writes the problem details response
+func (p Problem) Write(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", ContentTypeProblem)
+	w.WriteHeader(p.Status)
+	_ = json.NewEncoder(w).Encode(p)
+}
@@ -0,0 +1,187 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package audit
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/client"
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+)
+
+// Action is the kind of mutation recorded by an audit event
+type Action string
+
+const (
+	ActionCreate   Action = "create"
+	ActionUpdate   Action = "update"
+	ActionDelete   Action = "delete"
+	ActionRotate   Action = "rotate"
+	ActionRollback Action = "rollback"
+)
+
+// Kind is the kind of resource changed by an audited call
+type Kind string
+
+const (
+	KindLLMProvider     Kind = "LLMProvider"
+	KindRateLimitPolicy Kind = "RateLimitPolicy"
+)
+
+// Outcome tells whether the audited call succeeded
+type Outcome string
+
+const (
+	OutcomeSuccess Outcome = "success"
+	OutcomeFailure Outcome = "failure"
+)
+
+// Event records one console mutation of an LLM provider or rate limit policy
+type Event struct {
+	ID        string    `json:"id"`
+	Time      time.Time `json:"time"`
+	RequestID string    `json:"requestId,omitempty"`
+	Actor     string    `json:"actor"`
+	Groups    []string  `json:"groups,omitempty"`
+	Action    Action    `json:"action"`
+	// Kind of the changed resource, empty in events recorded before rate limit
+	// policies were audited, which are all about LLM providers
+	Kind      Kind   `json:"kind,omitempty"`
+	Namespace string `json:"namespace"`
+	// Provider is the name of the changed resource, an LLM provider unless Kind says otherwise
+	Provider string `json:"provider"`
+	// Changes is the diff of the masked resource before and after the call, empty
+	// when the call failed
+	Changes []Change `json:"changes,omitempty"`
+	Outcome Outcome  `json:"outcome"`
+	Error   string   `json:"error,omitempty"`
+}
+
+// ResourceKind returns the kind of the changed resource
+func (e Event) ResourceKind() Kind {
+	if e.Kind == "" {
+		return KindLLMProvider
+	}
+	return e.Kind
+}
+
+// Sink receives audit events
+type Sink interface {
+	Write(ctx context.Context, event Event) error
+}
+
+// Store is a sink whose events can be queried back
+type Store interface {
+	Sink
+	Query(ctx context.Context, query Query) ([]Event, error)
+}
+
+// DefaultQueryLimit is the number of events returned when the query sets no limit
+const DefaultQueryLimit = 100
+
+// Query selects audit events. Empty fields match every event.
+type Query struct {
+	// Namespaces of the provider, client.AllNamespaces matches every namespace
+	Namespaces []string
+	Kind       Kind
+	Provider   string
+	Actor      string
+	Action     Action
+	Outcome    Outcome
+	Since      time.Time
+	Until      time.Time
+	// Limit caps the number of returned events, newest first
+	Limit int
+}
+
+// Matches reports whether the event is selected by the query
+func (q Query) Matches(event Event) bool {
+	if len(q.Namespaces) > 0 && !slices.Contains(q.Namespaces, client.AllNamespaces) && !slices.Contains(q.Namespaces, event.Namespace) {
+		return false
+	}
+	if q.Kind != "" && !strings.EqualFold(string(q.Kind), string(event.ResourceKind())) {
+		return false
+	}
+	if q.Provider != "" && q.Provider != event.Provider {
+		return false
+	}
+	if q.Actor != "" && q.Actor != event.Actor {
+		return false
+	}
+	if q.Action != "" && !strings.EqualFold(string(q.Action), string(event.Action)) {
+		return false
+	}
+	if q.Outcome != "" && !strings.EqualFold(string(q.Outcome), string(event.Outcome)) {
+		return false
+	}
+	if !q.Since.IsZero() && event.Time.Before(q.Since) {
+		return false
+	}
+	if !q.Until.IsZero() && !event.Time.Before(q.Until) {
+		return false
+	}
+	return true
+}
+
+// limit returns the effective query limit
+func (q Query) limit() int {
+	if q.Limit <= 0 {
+		return DefaultQueryLimit
+	}
+	return q.Limit
+}
+
+// Recorder stamps audit events and fans them out to the store and sinks.
+// Failing sinks are logged and never fail the audited call.
+type Recorder struct {
+	store Store
+	sinks []Sink
+	now   func() time.Time
+}
+
+// NewRecorder creates a recorder answering queries from the store
+func NewRecorder(store Store, sinks ...Sink) *Recorder {
+	return &Recorder{store: store, sinks: sinks, now: time.Now}
+}
+
+// Record writes the event to every sink
+func (r *Recorder) Record(ctx context.Context, event Event) {
+	if event.ID == "" {
+		event.ID = uuid.NewString()
+	}
+	if event.Time.IsZero() {
+		event.Time = r.now().UTC()
+	}
+
+	for _, sink := range append([]Sink{r.store}, r.sinks...) {
+		if err := sink.Write(ctx, event); err != nil {
+			logr.FromContextOrDiscard(ctx).Error(err, "Failed to write audit event", "event", event.ID, "action", event.Action)
+		}
+	}
+}
+
+// Close closes the store and sinks holding resources, e.g. the audit file. Events
+// recorded before Close are persisted.
+func (r *Recorder) Close() error {
+	var errs []error
+	for _, sink := range append([]Sink{r.store}, r.sinks...) {
+		if closer, ok := sink.(io.Closer); ok {
+			errs = append(errs, closer.Close())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Query returns the recorded events matching the query, newest first
+func (r *Recorder) Query(ctx context.Context, query Query) ([]Event, error) {
+	return r.store.Query(ctx, query)
+}
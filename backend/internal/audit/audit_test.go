@@ -0,0 +1,172 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package audit
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/llm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestDiff(t *testing.T) {
+	before := &llm.LLMProvider{
+		Name: "openai", Namespace: "default", Schema: "OpenAI",
+		Auth:    llm.AuthConfig{Type: "apiKey", APIKey: "old"},
+		Backend: llm.Backend{Host: "api.openai.com", Port: 443},
+	}
+	after := *before
+	after.Backend.Host = "proxy.example.com"
+	after.Auth.APIKey = "new"
+
+	changes := Diff(before.MaskSecret(), after.MaskSecret())
+	assert.Equal(t, []Change{{Path: "backend.host", Before: "api.openai.com", After: "proxy.example.com"}}, changes,
+		"masked secrets never show up in the diff")
+
+	created := Diff(nil, before.MaskSecret())
+	assert.Contains(t, created, Change{Path: "auth.apiKey", After: llm.MaskedSecretValue})
+	for _, change := range created {
+		assert.Nil(t, change.Before)
+	}
+
+	var missing *llm.LLMProvider
+	assert.Len(t, Diff(before.MaskSecret(), missing), len(created))
+}
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(3)
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, provider := range []string{"a", "b", "c", "d"} {
+		require.NoError(t, store.Write(ctx, Event{Provider: provider, Namespace: "default", Time: start.Add(time.Duration(i) * time.Minute)}))
+	}
+
+	events, err := store.Query(ctx, Query{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"d", "c", "b"}, providers(events), "oldest event is evicted, newest first")
+
+	events, err = store.Query(ctx, Query{Since: start.Add(2 * time.Minute), Limit: 1})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"d"}, providers(events))
+
+	events, err = store.Query(ctx, Query{Namespaces: []string{"other"}})
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
+
+func TestQueryKind(t *testing.T) {
+	legacy := Event{Provider: "openai"}
+	provider := Event{Kind: KindLLMProvider, Provider: "openai"}
+	policy := Event{Kind: KindRateLimitPolicy, Provider: "budget"}
+
+	query := Query{Kind: KindLLMProvider}
+	assert.True(t, query.Matches(legacy), "events without a kind are about providers")
+	assert.True(t, query.Matches(provider))
+	assert.False(t, query.Matches(policy))
+
+	query = Query{Kind: "ratelimitpolicy"}
+	assert.False(t, query.Matches(legacy))
+	assert.True(t, query.Matches(policy))
+}
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	store, err := NewFileStore(path)
+	require.NoError(t, err)
+	defer store.Close()
+
+	recorder := NewRecorder(store)
+	for _, action := range []Action{ActionCreate, ActionRotate, ActionDelete} {
+		recorder.Record(ctx, Event{Action: action, Actor: "alice", Namespace: "team-a", Provider: "openai", Outcome: OutcomeSuccess})
+	}
+
+	events, err := recorder.Query(ctx, Query{Namespaces: []string{"*"}, Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, ActionDelete, events[0].Action)
+	assert.Equal(t, ActionRotate, events[1].Action)
+	assert.NotEmpty(t, events[0].ID)
+	assert.False(t, events[0].Time.IsZero())
+
+	events, err = recorder.Query(ctx, Query{Action: ActionCreate})
+	require.NoError(t, err)
+	assert.Len(t, events, 1)
+}
+
+func TestRecorderClose(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	recorder, err := New(Config{Sinks: []string{SinkFile}, File: path}, nil)
+	require.NoError(t, err)
+
+	recorder.Record(ctx, Event{Action: ActionCreate, Actor: "alice", Namespace: "team-a", Provider: "openai", Outcome: OutcomeSuccess})
+	require.NoError(t, recorder.Close())
+	require.NoError(t, recorder.Close(), "closing twice is a no-op")
+	assert.ErrorContains(t, recorder.store.Write(ctx, Event{Action: ActionDelete}), "closed")
+
+	// The event recorded before Close is read back by a new store
+	store, err := NewFileStore(path)
+	require.NoError(t, err)
+	defer store.Close()
+	events, err := store.Query(ctx, Query{})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, ActionCreate, events[0].Action)
+}
+
+func TestKubernetesEventSink(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	ctx := context.Background()
+
+	sink := NewKubernetesEventSink(k8sClient)
+	require.NoError(t, sink.Write(ctx, Event{
+		Time: time.Now(), Actor: "alice", Action: ActionDelete, Namespace: "team-a", Provider: "openai",
+		Outcome: OutcomeFailure, Error: "forbidden",
+	}))
+
+	var events corev1.EventList
+	require.NoError(t, k8sClient.List(ctx, &events))
+	require.Len(t, events.Items, 1)
+	event := events.Items[0]
+	assert.Equal(t, "AIServiceBackend", event.InvolvedObject.Kind)
+	assert.Equal(t, "openai", event.InvolvedObject.Name)
+	assert.Equal(t, "ProviderDeleted", event.Reason)
+	assert.Equal(t, corev1.EventTypeWarning, event.Type)
+	assert.Equal(t, "delete of LLM provider by alice failed: forbidden", event.Message)
+
+	require.NoError(t, sink.Write(ctx, Event{
+		Time: time.Now(), Actor: "bob", Action: ActionUpdate, Kind: KindRateLimitPolicy, Namespace: "team-a", Provider: "budget",
+		Outcome: OutcomeSuccess, Changes: []Change{{Path: "rules", Before: 10, After: 20}},
+	}))
+	require.NoError(t, k8sClient.List(ctx, &events))
+	require.Len(t, events.Items, 2)
+	index := slices.IndexFunc(events.Items, func(event corev1.Event) bool { return event.InvolvedObject.Name == "budget" })
+	require.NotEqual(t, -1, index)
+	event = events.Items[index]
+	assert.Equal(t, "BackendTrafficPolicy", event.InvolvedObject.Kind)
+	assert.Equal(t, "RateLimitPolicyUpdated", event.Reason)
+	assert.Equal(t, corev1.EventTypeNormal, event.Type)
+	assert.Equal(t, "update of rate limit policy by bob succeeded; changed rules", event.Message)
+}
+
+func providers(events []Event) []string {
+	var names []string
+	for _, event := range events {
+		names = append(names, event.Provider)
+	}
+	return names
+}
@@ -0,0 +1,57 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package audit
+
+import (
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	SinkStdout = "stdout"
+	SinkFile   = "file"
+	SinkEvents = "events"
+)
+
+// Config selects the audit sinks. Recent events are always kept in memory for
+// queries; with the file sink, queries are answered from the file instead.
+type Config struct {
+	// Sinks lists the enabled sinks: stdout, file and events
+	Sinks []string `json:"sinks,omitempty"`
+	// File is the JSON lines file used by the file sink
+	File string `json:"file,omitempty"`
+}
+
+// New builds the recorder for the configuration. The Kubernetes client creates
+// Events with the console's own identity.
+func New(cfg Config, k8sClient client.Client) (*Recorder, error) {
+	var store Store = NewMemoryStore(DefaultMemoryCapacity)
+	var sinks []Sink
+
+	for _, sink := range cfg.Sinks {
+		switch sink {
+		case SinkStdout:
+			sinks = append(sinks, NewWriterSink(os.Stdout))
+		case SinkFile:
+			if cfg.File == "" {
+				return nil, fmt.Errorf("the file audit sink requires a file")
+			}
+			fileStore, err := NewFileStore(cfg.File)
+			if err != nil {
+				return nil, err
+			}
+			store = fileStore
+		case SinkEvents:
+			sinks = append(sinks, NewKubernetesEventSink(k8sClient))
+		default:
+			return nil, fmt.Errorf("unknown audit sink %q", sink)
+		}
+	}
+
+	return NewRecorder(store, sinks...), nil
+}
@@ -0,0 +1,83 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package audit
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+)
+
+// Change is a field that differs between two versions of a provider
+type Change struct {
+	// Path is the dotted JSON path of the field
+	Path   string `json:"path"`
+	Before any    `json:"before,omitempty"`
+	After  any    `json:"after,omitempty"`
+}
+
+// Diff returns the changed fields between two JSON-serializable values, sorted by path.
+// Either side may be nil, e.g. for creations and deletions. Callers pass masked
+// providers so that no secret ends up in the audit log.
+func Diff(before, after any) []Change {
+	beforeFields := map[string]any{}
+	afterFields := map[string]any{}
+	flatten("", toJSONValue(before), beforeFields)
+	flatten("", toJSONValue(after), afterFields)
+
+	var changes []Change
+	for path, value := range beforeFields {
+		if other, ok := afterFields[path]; !ok || !reflect.DeepEqual(value, other) {
+			changes = append(changes, Change{Path: path, Before: value, After: afterFields[path]})
+		}
+	}
+	for path, value := range afterFields {
+		if _, ok := beforeFields[path]; !ok {
+			changes = append(changes, Change{Path: path, After: value})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
+
+// toJSONValue converts a value to its generic JSON representation
+func toJSONValue(value any) any {
+	if value == nil || (reflect.ValueOf(value).Kind() == reflect.Pointer && reflect.ValueOf(value).IsNil()) {
+		return nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	var generic any
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil
+	}
+	return generic
+}
+
+// flatten collects the leaf values of a JSON document keyed by their dotted path
+func flatten(prefix string, value any, fields map[string]any) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			path := key
+			if prefix != "" {
+				path = prefix + "." + key
+			}
+			flatten(path, child, fields)
+		}
+	case nil:
+	case string:
+		if v != "" {
+			fields[prefix] = v
+		}
+	default:
+		// Numbers, booleans and arrays are compared as a whole
+		fields[prefix] = v
+	}
+}
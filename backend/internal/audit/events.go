@@ -0,0 +1,111 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package audit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// EventSource is the reporting component of the Kubernetes Events
+const EventSource = "envoy-ai-gateway-console"
+
+// KubernetesEventSink records audit events as Kubernetes Events on the provider's AIServiceBackend
+// or on the BackendTrafficPolicy of a rate limit policy
+type KubernetesEventSink struct {
+	client client.Client
+}
+
+// NewKubernetesEventSink creates a sink creating Events with the client
+func NewKubernetesEventSink(k8sClient client.Client) *KubernetesEventSink {
+	return &KubernetesEventSink{client: k8sClient}
+}
+
+// Write implements Sink
+func (s *KubernetesEventSink) Write(ctx context.Context, event Event) error {
+	eventType, verb := corev1.EventTypeNormal, "succeeded"
+	if event.Outcome == OutcomeFailure {
+		eventType, verb = corev1.EventTypeWarning, "failed"
+	}
+
+	involved := corev1.ObjectReference{
+		APIVersion: "aigateway.envoyproxy.io/v1alpha1",
+		Kind:       "AIServiceBackend",
+		Namespace:  event.Namespace,
+		Name:       event.Provider,
+	}
+	subject, reason := "LLM provider", "Provider"
+	if event.ResourceKind() == KindRateLimitPolicy {
+		involved.APIVersion, involved.Kind = "gateway.envoyproxy.io/v1alpha1", "BackendTrafficPolicy"
+		subject, reason = "rate limit policy", "RateLimitPolicy"
+	}
+
+	message := fmt.Sprintf("%s of %s by %s %s", event.Action, subject, event.Actor, verb)
+	if len(event.Changes) > 0 {
+		paths := make([]string, 0, len(event.Changes))
+		for _, change := range event.Changes {
+			paths = append(paths, change.Path)
+		}
+		message += "; changed " + strings.Join(paths, ", ")
+	}
+	if event.Error != "" {
+		message += ": " + event.Error
+	}
+
+	eventTime := metav1.NewTime(event.Time)
+	k8sEvent := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s.%x", event.Provider, event.Time.UnixNano()),
+			Namespace: event.Namespace,
+		},
+		InvolvedObject: involved,
+		Reason:         reason + reasonSuffix(event.Action),
+		Message:        truncate(message, 1024),
+		Type:           eventType,
+		Source:         corev1.EventSource{Component: EventSource},
+		FirstTimestamp: eventTime,
+		LastTimestamp:  eventTime,
+		Count:          1,
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := s.client.Create(ctx, k8sEvent); err != nil {
+		return fmt.Errorf("failed to create Event for %s/%s: %w", event.Namespace, event.Provider, err)
+	}
+	return nil
+}
+
+// reasonSuffix turns an action into the CamelCase suffix of an Event reason
+func reasonSuffix(action Action) string {
+	switch action {
+	case ActionCreate:
+		return "Created"
+	case ActionUpdate:
+		return "Updated"
+	case ActionDelete:
+		return "Deleted"
+	case ActionRotate:
+		return "CredentialsRotated"
+	case ActionRollback:
+		return "RolledBack"
+	default:
+		return "Changed"
+	}
+}
+
+func truncate(value string, length int) string {
+	if len(value) <= length {
+		return value
+	}
+	return value[:length-3] + "..."
+}
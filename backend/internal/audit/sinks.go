@@ -0,0 +1,175 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// DefaultMemoryCapacity is the number of events kept by the in-memory store
+const DefaultMemoryCapacity = 1000
+
+// MemoryStore keeps the most recent events in a ring buffer
+type MemoryStore struct {
+	mu       sync.Mutex
+	events   []Event
+	next     int
+	capacity int
+}
+
+// NewMemoryStore creates a store keeping the last capacity events
+func NewMemoryStore(capacity int) *MemoryStore {
+	if capacity <= 0 {
+		capacity = DefaultMemoryCapacity
+	}
+	return &MemoryStore{capacity: capacity}
+}
+
+// Write implements Sink
+func (s *MemoryStore) Write(_ context.Context, event Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.events) < s.capacity {
+		s.events = append(s.events, event)
+		return nil
+	}
+	s.events[s.next] = event
+	s.next = (s.next + 1) % s.capacity
+	return nil
+}
+
+// Query implements Store
+func (s *MemoryStore) Query(_ context.Context, query Query) ([]Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	events := []Event{}
+	// Walk from the newest event backwards
+	for i := 0; i < len(s.events) && len(events) < query.limit(); i++ {
+		index := (s.next - 1 - i + 2*len(s.events)) % len(s.events)
+		if query.Matches(s.events[index]) {
+			events = append(events, s.events[index])
+		}
+	}
+	return events, nil
+}
+
+// WriterSink writes events as JSON lines, e.g. to stdout
+type WriterSink struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+// NewWriterSink creates a sink writing JSON lines to w
+func NewWriterSink(w io.Writer) *WriterSink {
+	return &WriterSink{encoder: json.NewEncoder(w)}
+}
+
+// Write implements Sink
+func (s *WriterSink) Write(_ context.Context, event Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.encoder.Encode(event)
+}
+
+// FileStore appends events as JSON lines to a file and answers queries by scanning it
+type FileStore struct {
+	mu   sync.Mutex
+	path string
+	file *os.File
+}
+
+// NewFileStore opens or creates the audit file at path
+func NewFileStore(path string) (*FileStore, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log %s: %w", path, err)
+	}
+	return &FileStore{path: path, file: file}, nil
+}
+
+// Write implements Sink
+func (s *FileStore) Write(_ context.Context, event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit event: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return fmt.Errorf("audit log %s is closed", s.path)
+	}
+	if _, err := s.file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit log %s: %w", s.path, err)
+	}
+	return nil
+}
+
+// Query implements Store
+func (s *FileStore) Query(_ context.Context, query Query) ([]Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := os.Open(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log %s: %w", s.path, err)
+	}
+	defer file.Close()
+
+	// Events are appended in order; keep the newest matches while scanning
+	var matches []Event
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			continue
+		}
+		if query.Matches(event) {
+			matches = append(matches, event)
+			if len(matches) > query.limit() {
+				matches = matches[1:]
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log %s: %w", s.path, err)
+	}
+
+	events := make([]Event, 0, len(matches))
+	for i := len(matches) - 1; i >= 0; i-- {
+		events = append(events, matches[i])
+	}
+	return events, nil
+}
+
+// Close flushes the written events to disk and closes the audit file. Later writes fail.
+func (s *FileStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return nil
+	}
+
+	file := s.file
+	s.file = nil
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to flush audit log %s: %w", s.path, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to close audit log %s: %w", s.path, err)
+	}
+	return nil
+}
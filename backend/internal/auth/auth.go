@@ -0,0 +1,85 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+// Package auth authenticates console API callers from bearer tokens.
+package auth
+
+import (
+	"context"
+	"errors"
+
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/apierror"
+)
+
+// Authentication methods, also used as configuration mode names
+const (
+	MethodOIDC       = "oidc"
+	MethodKubernetes = "kubernetes"
+	MethodStatic     = "static"
+)
+
+// User is the authenticated identity of an API caller
+type User struct {
+	Name   string   `json:"name"`
+	UID    string   `json:"uid,omitempty"`
+	Groups []string `json:"groups,omitempty"`
+	// Method is the authenticator that accepted the credentials
+	Method string `json:"method"`
+}
+
+// Authenticator validates bearer tokens
+type Authenticator interface {
+	// AuthenticateToken returns the user for the token. ok is false without an error
+	// when the token is not valid for this authenticator.
+	AuthenticateToken(ctx context.Context, token string) (user *User, ok bool, err error)
+}
+
+// Chain tries each authenticator in order and returns the first user accepted
+type Chain []Authenticator
+
+// AuthenticateToken implements Authenticator
+func (c Chain) AuthenticateToken(ctx context.Context, token string) (*User, bool, error) {
+	var errs []error
+	for _, authenticator := range c {
+		user, ok, err := authenticator.AuthenticateToken(ctx, token)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if ok {
+			return user, true, nil
+		}
+	}
+	return nil, false, errors.Join(errs...)
+}
+
+// Authenticate validates the token and returns a CodeUnauthenticated error when no
+// authenticator accepts it
+func Authenticate(ctx context.Context, authenticator Authenticator, token string) (*User, error) {
+	if token == "" {
+		return nil, apierror.New(apierror.CodeUnauthenticated, "a bearer token is required")
+	}
+	user, ok, err := authenticator.AuthenticateToken(ctx, token)
+	if ok {
+		return user, nil
+	}
+	if err != nil && apierror.Is(err, apierror.CodeUpstream) {
+		return nil, apierror.Wrap(apierror.CodeUpstream, err, "failed to validate bearer token")
+	}
+	return nil, apierror.Wrap(apierror.CodeUnauthenticated, err, "invalid bearer token")
+}
+
+type contextKey struct{}
+
+// WithUser returns a copy of the context carrying the authenticated user
+func WithUser(ctx context.Context, user *User) context.Context {
+	return context.WithValue(ctx, contextKey{}, user)
+}
+
+// UserFromContext returns the authenticated user stored in the context
+func UserFromContext(ctx context.Context) (*User, bool) {
+	user, ok := ctx.Value(contextKey{}).(*User)
+	return user, ok && user != nil
+}
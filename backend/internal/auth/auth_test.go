@@ -0,0 +1,98 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/apierror"
+	"github.com/go-jose/go-jose/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStaticTokens(t *testing.T) {
+	tokens, err := ParseStaticTokens(strings.NewReader(`# token,user,uid,groups
+dev-token,developer,1000,"editors,viewers"
+ci-token,ci,1001
+`))
+	require.NoError(t, err)
+	assert.Equal(t, User{Name: "developer", UID: "1000", Groups: []string{"editors", "viewers"}}, tokens["dev-token"])
+	assert.Equal(t, User{Name: "ci", UID: "1001"}, tokens["ci-token"])
+
+	_, err = ParseStaticTokens(strings.NewReader("dev-token,developer,1\ndev-token,other,2\n"))
+	assert.ErrorContains(t, err, "duplicate token")
+}
+
+func TestAuthenticate(t *testing.T) {
+	ctx := context.Background()
+	authenticator := Chain{NewStaticTokenAuthenticator(map[string]User{
+		"dev-token": {Name: "developer"},
+	})}
+
+	user, err := Authenticate(ctx, authenticator, "dev-token")
+	require.NoError(t, err)
+	assert.Equal(t, "developer", user.Name)
+	assert.Equal(t, MethodStatic, user.Method)
+
+	_, err = Authenticate(ctx, authenticator, "wrong-token")
+	assert.True(t, apierror.Is(err, apierror.CodeUnauthenticated))
+
+	_, err = Authenticate(ctx, authenticator, "")
+	assert.True(t, apierror.Is(err, apierror.CodeUnauthenticated))
+}
+
+func TestOIDCAuthenticator(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: key}, nil)
+	require.NoError(t, err)
+
+	sign := func(claims map[string]any) string {
+		payload, err := json.Marshal(claims)
+		require.NoError(t, err)
+		jws, err := signer.Sign(payload)
+		require.NoError(t, err)
+		token, err := jws.CompactSerialize()
+		require.NoError(t, err)
+		return token
+	}
+
+	authenticator := newOIDCAuthenticator(OIDCConfig{
+		IssuerURL:     "https://issuer.example.com",
+		Audience:      "console",
+		UsernameClaim: "email",
+		GroupsPrefix:  "oidc:",
+	}, &oidc.StaticKeySet{PublicKeys: []crypto.PublicKey{&key.PublicKey}})
+
+	claims := map[string]any{
+		"iss":    "https://issuer.example.com",
+		"aud":    "console",
+		"sub":    "1234",
+		"email":  "jane@example.com",
+		"groups": []string{"platform"},
+		"exp":    time.Now().Add(time.Hour).Unix(),
+	}
+
+	user, ok, err := authenticator.AuthenticateToken(context.Background(), sign(claims))
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, &User{Name: "jane@example.com", UID: "1234", Groups: []string{"oidc:platform"}, Method: MethodOIDC}, user)
+
+	// Tokens for another audience are rejected
+	claims["aud"] = "other"
+	_, ok, err = authenticator.AuthenticateToken(context.Background(), sign(claims))
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -0,0 +1,63 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Config selects and configures the authenticators of the console API
+type Config struct {
+	// Modes lists the enabled authenticators in the order they are tried:
+	// oidc, kubernetes and static. Defaults to kubernetes.
+	Modes []string   `json:"modes,omitempty"`
+	OIDC  OIDCConfig `json:"oidc"`
+	// TokenReviewAudiences restricts the audiences of Kubernetes tokens
+	TokenReviewAudiences []string `json:"tokenReviewAudiences,omitempty"`
+	// StaticTokensFile is the token file used by the static mode
+	StaticTokensFile string `json:"staticTokensFile,omitempty"`
+	// Impersonate makes Kubernetes calls as the authenticated user instead of the
+	// console's service account, so the cluster's RBAC applies per user
+	Impersonate bool `json:"impersonate"`
+}
+
+// New builds the authenticator chain for the configuration. The Kubernetes client is
+// used by the kubernetes mode to create TokenReviews.
+func New(ctx context.Context, cfg Config, k8sClient client.Client) (Authenticator, error) {
+	modes := cfg.Modes
+	if len(modes) == 0 {
+		modes = []string{MethodKubernetes}
+	}
+
+	var chain Chain
+	for _, mode := range modes {
+		switch mode {
+		case MethodOIDC:
+			authenticator, err := NewOIDCAuthenticator(ctx, cfg.OIDC)
+			if err != nil {
+				return nil, err
+			}
+			chain = append(chain, authenticator)
+		case MethodKubernetes:
+			chain = append(chain, NewTokenReviewAuthenticator(k8sClient, cfg.TokenReviewAudiences))
+		case MethodStatic:
+			if cfg.StaticTokensFile == "" {
+				return nil, fmt.Errorf("static authentication requires a token file")
+			}
+			tokens, err := LoadStaticTokens(cfg.StaticTokensFile)
+			if err != nil {
+				return nil, err
+			}
+			chain = append(chain, NewStaticTokenAuthenticator(tokens))
+		default:
+			return nil, fmt.Errorf("unknown authentication mode %q", mode)
+		}
+	}
+	return chain, nil
+}
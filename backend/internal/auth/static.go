@@ -0,0 +1,97 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// StaticTokenAuthenticator accepts a fixed set of tokens. It is meant for local
+// development and tests only.
+type StaticTokenAuthenticator struct {
+	tokens map[string]User
+}
+
+// NewStaticTokenAuthenticator creates an authenticator for the token to user mapping
+func NewStaticTokenAuthenticator(tokens map[string]User) *StaticTokenAuthenticator {
+	return &StaticTokenAuthenticator{tokens: tokens}
+}
+
+// AuthenticateToken implements Authenticator
+func (a *StaticTokenAuthenticator) AuthenticateToken(_ context.Context, token string) (*User, bool, error) {
+	for candidate, user := range a.tokens {
+		if subtle.ConstantTimeCompare([]byte(candidate), []byte(token)) == 1 {
+			user.Method = MethodStatic
+			return &user, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+// LoadStaticTokens reads a token file in the Kubernetes static token file format:
+// token,user,uid[,"group1,group2"]
+func LoadStaticTokens(path string) (map[string]User, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open static token file: %w", err)
+	}
+	defer f.Close()
+
+	tokens, err := ParseStaticTokens(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse static token file %s: %w", path, err)
+	}
+	return tokens, nil
+}
+
+// ParseStaticTokens parses static token CSV records
+func ParseStaticTokens(r io.Reader) (map[string]User, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.Comment = '#'
+	reader.TrimLeadingSpace = true
+
+	tokens := map[string]User{}
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) < 3 {
+			return nil, fmt.Errorf("line %d: expected at least token,user,uid", line)
+		}
+		if record[0] == "" || record[1] == "" {
+			return nil, fmt.Errorf("line %d: token and user must not be empty", line)
+		}
+		if _, exists := tokens[record[0]]; exists {
+			return nil, fmt.Errorf("line %d: duplicate token", line)
+		}
+
+		user := User{Name: record[1], UID: record[2]}
+		if len(record) > 3 && record[3] != "" {
+			for _, group := range strings.Split(record[3], ",") {
+				if group = strings.TrimSpace(group); group != "" {
+					user.Groups = append(user.Groups, group)
+				}
+			}
+		}
+		tokens[record[0]] = user
+	}
+
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("no tokens defined")
+	}
+	return tokens, nil
+}
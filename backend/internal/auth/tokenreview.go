@@ -0,0 +1,88 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"sync"
+	"time"
+
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/apierror"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// tokenReviewCacheTTL bounds how long a token review result is reused
+const tokenReviewCacheTTL = time.Minute
+
+// TokenReviewAuthenticator validates Kubernetes bearer tokens with the TokenReview API
+type TokenReviewAuthenticator struct {
+	client    client.Client
+	audiences []string
+
+	mu    sync.Mutex
+	cache map[[sha256.Size]byte]tokenReviewResult
+}
+
+type tokenReviewResult struct {
+	user    *User
+	expires time.Time
+}
+
+// NewTokenReviewAuthenticator creates an authenticator that asks the API server to review
+// tokens. Audiences optionally restricts the accepted token audiences.
+func NewTokenReviewAuthenticator(c client.Client, audiences []string) *TokenReviewAuthenticator {
+	return &TokenReviewAuthenticator{
+		client:    c,
+		audiences: audiences,
+		cache:     map[[sha256.Size]byte]tokenReviewResult{},
+	}
+}
+
+// AuthenticateToken implements Authenticator
+func (a *TokenReviewAuthenticator) AuthenticateToken(ctx context.Context, token string) (*User, bool, error) {
+	key := sha256.Sum256([]byte(token))
+	now := time.Now()
+
+	a.mu.Lock()
+	cached, found := a.cache[key]
+	a.mu.Unlock()
+	if found && now.Before(cached.expires) {
+		return cached.user, cached.user != nil, nil
+	}
+
+	review := &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{
+			Token:     token,
+			Audiences: a.audiences,
+		},
+	}
+	if err := a.client.Create(ctx, review); err != nil {
+		return nil, false, apierror.FromKubernetes(err, "token review failed")
+	}
+
+	var user *User
+	if review.Status.Authenticated {
+		user = &User{
+			Name:   review.Status.User.Username,
+			UID:    review.Status.User.UID,
+			Groups: review.Status.User.Groups,
+			Method: MethodKubernetes,
+		}
+	}
+
+	a.mu.Lock()
+	for k, v := range a.cache {
+		if now.After(v.expires) {
+			delete(a.cache, k)
+		}
+	}
+	a.cache[key] = tokenReviewResult{user: user, expires: now.Add(tokenReviewCacheTTL)}
+	a.mu.Unlock()
+
+	return user, user != nil, nil
+}
@@ -0,0 +1,179 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package authz
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/apierror"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/auth"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/client"
+)
+
+// Role is a console role. Every role includes the permissions of the roles below it.
+type Role string
+
+const (
+	// RoleViewer can list and read masked providers
+	RoleViewer Role = "viewer"
+	// RoleEditor can additionally create, update and delete providers
+	RoleEditor Role = "editor"
+	// RoleAdmin can additionally rotate credentials and view manifests with secrets
+	RoleAdmin Role = "admin"
+)
+
+// rank orders the roles from least to most privileged
+func (r Role) rank() int {
+	switch r {
+	case RoleViewer:
+		return 1
+	case RoleEditor:
+		return 2
+	case RoleAdmin:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// Includes reports whether the role grants the permissions of the other role
+func (r Role) Includes(other Role) bool {
+	return r.rank() > 0 && r.rank() >= other.rank()
+}
+
+// Validate returns an error for unknown roles
+func (r Role) Validate() error {
+	if r.rank() == 0 {
+		return fmt.Errorf("unknown role %q: must be viewer, editor or admin", r)
+	}
+	return nil
+}
+
+// Authorizer decides which console roles a user holds in a namespace
+type Authorizer interface {
+	// Authorize returns a CodeForbidden error unless the user holds the role in the namespace
+	Authorize(ctx context.Context, user *auth.User, namespace string, role Role) error
+	// Namespaces returns the namespaces where the user holds the role.
+	// client.AllNamespaces is returned when the role is granted cluster-wide.
+	Namespaces(ctx context.Context, user *auth.User, role Role) ([]string, error)
+}
+
+// AllowAll makes every authenticated user an admin in every namespace. It is only used
+// when explicitly enabled with Config.AllowAll.
+type AllowAll struct{}
+
+// Authorize implements Authorizer
+func (AllowAll) Authorize(context.Context, *auth.User, string, Role) error { return nil }
+
+// Namespaces implements Authorizer
+func (AllowAll) Namespaces(context.Context, *auth.User, Role) ([]string, error) {
+	return []string{client.AllNamespaces}, nil
+}
+
+// DenyAll is the authorizer used when no policy is configured: no user holds any role
+type DenyAll struct{}
+
+// Authorize implements Authorizer
+func (DenyAll) Authorize(context.Context, *auth.User, string, Role) error {
+	return apierror.New(apierror.CodeForbidden, "no authorization policy is configured")
+}
+
+// Namespaces implements Authorizer
+func (DenyAll) Namespaces(context.Context, *auth.User, Role) ([]string, error) {
+	return nil, nil
+}
+
+// Source provides the current role policy
+type Source interface {
+	Policy(ctx context.Context) (*Policy, error)
+}
+
+// PolicyAuthorizer authorizes users against the policy of a Source
+type PolicyAuthorizer struct {
+	source Source
+}
+
+// NewPolicyAuthorizer creates an authorizer backed by the policy source
+func NewPolicyAuthorizer(source Source) *PolicyAuthorizer {
+	return &PolicyAuthorizer{source: source}
+}
+
+// Authorize implements Authorizer
+func (a *PolicyAuthorizer) Authorize(ctx context.Context, user *auth.User, namespace string, role Role) error {
+	policy, err := a.source.Policy(ctx)
+	if err != nil {
+		return apierror.Wrap(apierror.CodeUnavailable, err, "authorization policy is unavailable")
+	}
+
+	if user == nil || !policy.Allows(user, namespace, role) {
+		name := ""
+		if user != nil {
+			name = user.Name
+		}
+		if client.IsAllNamespaces(namespace) {
+			return apierror.New(apierror.CodeForbidden, "user %q does not have the %s role in all namespaces", name, role)
+		}
+		return apierror.New(apierror.CodeForbidden, "user %q does not have the %s role in namespace %s", name, role, namespace)
+	}
+	return nil
+}
+
+// Namespaces implements Authorizer
+func (a *PolicyAuthorizer) Namespaces(ctx context.Context, user *auth.User, role Role) ([]string, error) {
+	policy, err := a.source.Policy(ctx)
+	if err != nil {
+		return nil, apierror.Wrap(apierror.CodeUnavailable, err, "authorization policy is unavailable")
+	}
+	if user == nil {
+		return nil, nil
+	}
+	return policy.Namespaces(user, role), nil
+}
+
+// restricted limits an authorizer to a fixed set of namespaces
+type restricted struct {
+	Authorizer
+	allowed []string
+}
+
+// RestrictNamespaces returns an authorizer that denies every namespace outside allowed,
+// whatever roles the wrapped authorizer grants
+func RestrictNamespaces(authorizer Authorizer, allowed []string) Authorizer {
+	return &restricted{Authorizer: authorizer, allowed: slices.Clone(allowed)}
+}
+
+// Authorize implements Authorizer
+func (r *restricted) Authorize(ctx context.Context, user *auth.User, namespace string, role Role) error {
+	if client.IsAllNamespaces(namespace) {
+		return apierror.New(apierror.CodeForbidden, "the console is restricted to namespaces %s", strings.Join(r.allowed, ", "))
+	}
+	if !slices.Contains(r.allowed, namespace) {
+		return apierror.New(apierror.CodeForbidden, "namespace %s is not managed by the console", namespace)
+	}
+	return r.Authorizer.Authorize(ctx, user, namespace, role)
+}
+
+// Namespaces implements Authorizer
+func (r *restricted) Namespaces(ctx context.Context, user *auth.User, role Role) ([]string, error) {
+	granted, err := r.Authorizer.Namespaces(ctx, user, role)
+	if err != nil {
+		return nil, err
+	}
+	if slices.ContainsFunc(granted, client.IsAllNamespaces) {
+		return slices.Clone(r.allowed), nil
+	}
+
+	var namespaces []string
+	for _, namespace := range granted {
+		if slices.Contains(r.allowed, namespace) {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	return namespaces, nil
+}
@@ -0,0 +1,222 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package authz
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/apierror"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+const testPolicy = `
+bindings:
+  - role: admin
+    groups: [platform]
+    namespaces: ["*"]
+  - role: editor
+    groups: [team-a]
+    namespaces: [team-a]
+  - role: viewer
+    users: [auditor]
+    namespaces: [team-a, team-b]
+`
+
+func TestPolicy(t *testing.T) {
+	policy, err := ParsePolicy([]byte(testPolicy))
+	require.NoError(t, err)
+
+	admin := &auth.User{Name: "root", Groups: []string{"platform"}}
+	editor := &auth.User{Name: "alice", Groups: []string{"team-a"}}
+	auditor := &auth.User{Name: "auditor"}
+
+	assert.True(t, policy.Allows(admin, "anything", RoleAdmin))
+	assert.True(t, policy.Allows(admin, "*", RoleViewer))
+
+	assert.True(t, policy.Allows(editor, "team-a", RoleViewer))
+	assert.True(t, policy.Allows(editor, "team-a", RoleEditor))
+	assert.False(t, policy.Allows(editor, "team-a", RoleAdmin))
+	assert.False(t, policy.Allows(editor, "team-b", RoleViewer))
+	assert.False(t, policy.Allows(editor, "*", RoleViewer))
+
+	assert.True(t, policy.Allows(auditor, "team-b", RoleViewer))
+	assert.False(t, policy.Allows(auditor, "team-b", RoleEditor))
+
+	assert.Equal(t, []string{"*"}, policy.Namespaces(admin, RoleEditor))
+	assert.Equal(t, []string{"team-a", "team-b"}, policy.Namespaces(auditor, RoleViewer))
+	assert.Empty(t, policy.Namespaces(auditor, RoleEditor))
+}
+
+func TestParsePolicyErrors(t *testing.T) {
+	for name, doc := range map[string]string{
+		"unknown role":  "bindings: [{role: owner, users: [a], namespaces: [x]}]",
+		"no subject":    "bindings: [{role: viewer, namespaces: [x]}]",
+		"no namespaces": "bindings: [{role: viewer, users: [a]}]",
+		"unknown field": "bindings: [{role: viewer, users: [a], namespaces: [x], extra: 1}]",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParsePolicy([]byte(doc))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestPolicyAuthorizer(t *testing.T) {
+	policy, err := ParsePolicy([]byte(testPolicy))
+	require.NoError(t, err)
+	authorizer := NewPolicyAuthorizer(NewStaticSource(policy))
+	ctx := context.Background()
+
+	editor := &auth.User{Name: "alice", Groups: []string{"team-a"}}
+	assert.NoError(t, authorizer.Authorize(ctx, editor, "team-a", RoleEditor))
+
+	err = authorizer.Authorize(ctx, editor, "team-a", RoleAdmin)
+	assert.True(t, apierror.Is(err, apierror.CodeForbidden))
+
+	err = authorizer.Authorize(ctx, nil, "team-a", RoleViewer)
+	assert.True(t, apierror.Is(err, apierror.CodeForbidden))
+}
+
+func TestRestrictNamespaces(t *testing.T) {
+	policy, err := ParsePolicy([]byte(testPolicy))
+	require.NoError(t, err)
+	ctx := context.Background()
+	admin := &auth.User{Name: "bob", Groups: []string{"platform"}}
+	auditor := &auth.User{Name: "auditor"}
+
+	authorizer := RestrictNamespaces(NewPolicyAuthorizer(NewStaticSource(policy)), []string{"team-a", "team-c"})
+	assert.NoError(t, authorizer.Authorize(ctx, admin, "team-a", RoleAdmin))
+	err = authorizer.Authorize(ctx, admin, "team-b", RoleViewer)
+	assert.True(t, apierror.Is(err, apierror.CodeForbidden))
+	err = authorizer.Authorize(ctx, admin, "*", RoleViewer)
+	assert.True(t, apierror.Is(err, apierror.CodeForbidden))
+
+	namespaces, err := authorizer.Namespaces(ctx, admin, RoleViewer)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"team-a", "team-c"}, namespaces)
+
+	namespaces, err = authorizer.Namespaces(ctx, auditor, RoleViewer)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"team-a"}, namespaces)
+}
+
+func TestConfigMapSource(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "console", Name: "roles"},
+		Data:       map[string]string{PolicyKey: testPolicy},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(configMap).Build()
+	ctx := context.Background()
+
+	now := time.Now()
+	source := NewConfigMapSource(k8sClient, "console", "roles", time.Minute)
+	source.now = func() time.Time { return now }
+
+	policy, err := source.Policy(ctx)
+	require.NoError(t, err)
+	assert.Len(t, policy.Bindings, 3)
+
+	// A broken update keeps the last good policy
+	configMap.Data[PolicyKey] = "bindings: [{role: owner}]"
+	require.NoError(t, k8sClient.Update(ctx, configMap))
+	now = now.Add(2 * time.Minute)
+
+	policy, err = source.Policy(ctx)
+	require.NoError(t, err)
+	assert.Len(t, policy.Bindings, 3)
+
+	_, err = NewConfigMapSource(k8sClient, "console", "missing", time.Minute).Policy(ctx)
+	assert.Error(t, err)
+}
+
+func TestConfigMapSourceRetriesFailedReads(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	reads, fail := 0, true
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "console", Name: "roles"},
+			Data:       map[string]string{PolicyKey: testPolicy},
+		}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				reads++
+				if fail {
+					return errors.New("connection refused")
+				}
+				return c.Get(ctx, key, obj, opts...)
+			},
+		}).Build()
+	ctx := context.Background()
+
+	now := time.Now()
+	source := NewConfigMapSource(k8sClient, "console", "roles", time.Minute)
+	source.now = func() time.Time { return now }
+
+	// A failed read is reused until the retry interval passes
+	_, err := source.Policy(ctx)
+	assert.ErrorContains(t, err, "connection refused")
+	_, err = source.Policy(ctx)
+	assert.ErrorContains(t, err, "connection refused")
+	assert.Equal(t, 1, reads)
+
+	fail = false
+	now = now.Add(retryInterval)
+	policy, err := source.Policy(ctx)
+	require.NoError(t, err)
+	assert.Len(t, policy.Bindings, 3)
+	assert.Equal(t, 2, reads)
+
+	// Once the refresh interval passes a failed read keeps the last good policy, and is
+	// retried after the retry interval rather than on every request
+	fail = true
+	now = now.Add(time.Minute)
+	for range 3 {
+		policy, err = source.Policy(ctx)
+		require.NoError(t, err)
+		assert.Len(t, policy.Bindings, 3)
+	}
+	assert.Equal(t, 3, reads)
+	now = now.Add(retryInterval)
+	_, err = source.Policy(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 4, reads)
+}
+
+func TestNewFailsClosed(t *testing.T) {
+	ctx := context.Background()
+	user := &auth.User{Name: "alice"}
+
+	authorizer, err := New(Config{}, nil)
+	require.NoError(t, err)
+	err = authorizer.Authorize(ctx, user, "team-a", RoleViewer)
+	assert.True(t, apierror.Is(err, apierror.CodeForbidden), "no policy denies, got %v", err)
+	namespaces, err := authorizer.Namespaces(ctx, user, RoleViewer)
+	require.NoError(t, err)
+	assert.Empty(t, namespaces)
+
+	authorizer, err = New(Config{AllowAll: true}, nil)
+	require.NoError(t, err)
+	assert.NoError(t, authorizer.Authorize(ctx, user, "team-a", RoleAdmin), "allowAll is an explicit opt-in")
+
+	_, err = New(Config{AllowAll: true, PolicyFile: "roles.yaml"}, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,57 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package authz
+
+import (
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Config selects where the role policy is read from. Without a source every request
+// is denied, unless AllowAll is set.
+type Config struct {
+	// PolicyConfigMap is the namespace/name of a ConfigMap holding the policy
+	PolicyConfigMap string `json:"policyConfigMap,omitempty"`
+	// PolicyFile is a local policy file, mostly for development
+	PolicyFile string `json:"policyFile,omitempty"`
+	// AllowAll disables authorization when no source is set, making every
+	// authenticated user an admin in every namespace
+	AllowAll bool `json:"allowAll,omitempty"`
+}
+
+// Enabled reports whether a policy source is configured
+func (c Config) Enabled() bool {
+	return c.PolicyConfigMap != "" || c.PolicyFile != ""
+}
+
+// New builds the authorizer for the configuration. The Kubernetes client reads the
+// policy ConfigMap with the console's own identity.
+func New(cfg Config, k8sClient client.Client) (Authorizer, error) {
+	switch {
+	case cfg.PolicyConfigMap != "" && cfg.PolicyFile != "":
+		return nil, fmt.Errorf("only one of the policy ConfigMap and policy file can be set")
+	case cfg.AllowAll && cfg.Enabled():
+		return nil, fmt.Errorf("allowAll cannot be combined with a policy ConfigMap or file")
+	case cfg.PolicyConfigMap != "":
+		namespace, name, ok := strings.Cut(cfg.PolicyConfigMap, "/")
+		if !ok || namespace == "" || name == "" {
+			return nil, fmt.Errorf("invalid policy ConfigMap %q: must be namespace/name", cfg.PolicyConfigMap)
+		}
+		return NewPolicyAuthorizer(NewConfigMapSource(k8sClient, namespace, name, DefaultRefreshInterval)), nil
+	case cfg.PolicyFile != "":
+		policy, err := LoadPolicy(cfg.PolicyFile)
+		if err != nil {
+			return nil, err
+		}
+		return NewPolicyAuthorizer(NewStaticSource(policy)), nil
+	case cfg.AllowAll:
+		return AllowAll{}, nil
+	default:
+		return DenyAll{}, nil
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package authz
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// PolicyKey is the ConfigMap key holding the policy document
+	PolicyKey = "policy.yaml"
+	// DefaultRefreshInterval is how long a policy read from a ConfigMap is reused
+	DefaultRefreshInterval = 30 * time.Second
+	// retryInterval is how long a failed read is reused before the ConfigMap is read again,
+	// at most the refresh interval
+	retryInterval = 5 * time.Second
+)
+
+// ConfigMapSource reads the policy from the PolicyKey of a ConfigMap. The policy is
+// re-read after the refresh interval; the last good policy is kept when a read fails.
+// A failed read is retried after a short interval instead of on every request.
+type ConfigMapSource struct {
+	client          client.Client
+	key             types.NamespacedName
+	refreshInterval time.Duration
+	now             func() time.Time
+
+	mu     sync.Mutex
+	policy *Policy
+	// err is the error of the last read, returned until nextRead when there is no good policy
+	err      error
+	nextRead time.Time
+}
+
+// NewConfigMapSource creates a source reading the policy from the ConfigMap
+func NewConfigMapSource(k8sClient client.Client, namespace, name string, refreshInterval time.Duration) *ConfigMapSource {
+	if refreshInterval <= 0 {
+		refreshInterval = DefaultRefreshInterval
+	}
+	return &ConfigMapSource{
+		client:          k8sClient,
+		key:             types.NamespacedName{Namespace: namespace, Name: name},
+		refreshInterval: refreshInterval,
+		now:             time.Now,
+	}
+}
+
+// Policy implements Source
+func (s *ConfigMapSource) Policy(ctx context.Context) (*Policy, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if now.Before(s.nextRead) {
+		return s.cached()
+	}
+
+	policy, err := s.load(ctx)
+	if err != nil {
+		s.err, s.nextRead = err, now.Add(min(retryInterval, s.refreshInterval))
+		return s.cached()
+	}
+
+	s.policy, s.err, s.nextRead = policy, nil, now.Add(s.refreshInterval)
+	return policy, nil
+}
+
+// cached returns the last good policy, or the error of the last read when there is none
+func (s *ConfigMapSource) cached() (*Policy, error) {
+	if s.policy != nil {
+		// Keep serving the last good policy rather than locking everybody out
+		return s.policy, nil
+	}
+	return nil, s.err
+}
+
+func (s *ConfigMapSource) load(ctx context.Context) (*Policy, error) {
+	var configMap corev1.ConfigMap
+	if err := s.client.Get(ctx, s.key, &configMap); err != nil {
+		return nil, fmt.Errorf("failed to get authorization policy ConfigMap %s: %w", s.key, err)
+	}
+
+	data, ok := configMap.Data[PolicyKey]
+	if !ok {
+		return nil, fmt.Errorf("authorization policy ConfigMap %s has no %s key", s.key, PolicyKey)
+	}
+	return ParsePolicy([]byte(data))
+}
@@ -0,0 +1,142 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package authz
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/auth"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// Policy maps users and groups to console roles per namespace
+//
+//	bindings:
+//	  - role: admin
+//	    groups: [platform-admins]
+//	    namespaces: ["*"]
+//	  - role: editor
+//	    groups: [team-a]
+//	    users: [alice@example.com]
+//	    namespaces: [team-a]
+type Policy struct {
+	Bindings []Binding `json:"bindings"`
+}
+
+// Binding grants a role to users and groups in a set of namespaces
+type Binding struct {
+	Role   Role     `json:"role"`
+	Users  []string `json:"users,omitempty"`
+	Groups []string `json:"groups,omitempty"`
+	// Namespaces the role applies to, "*" for all namespaces
+	Namespaces []string `json:"namespaces"`
+}
+
+// ParsePolicy parses a YAML or JSON policy document
+func ParsePolicy(data []byte) (*Policy, error) {
+	var policy Policy
+	if err := yaml.UnmarshalStrict(data, &policy); err != nil {
+		return nil, fmt.Errorf("failed to parse authorization policy: %w", err)
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// LoadPolicy reads a policy from a file
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read authorization policy %s: %w", path, err)
+	}
+	return ParsePolicy(data)
+}
+
+// Validate checks that every binding has a known role, a subject and a namespace
+func (p *Policy) Validate() error {
+	for i, binding := range p.Bindings {
+		if err := binding.Role.Validate(); err != nil {
+			return fmt.Errorf("binding %d: %w", i, err)
+		}
+		if len(binding.Users) == 0 && len(binding.Groups) == 0 {
+			return fmt.Errorf("binding %d: at least one user or group is required", i)
+		}
+		if len(binding.Namespaces) == 0 {
+			return fmt.Errorf("binding %d: at least one namespace is required", i)
+		}
+	}
+	return nil
+}
+
+// Allows reports whether the user holds the role in the namespace.
+// Checking client.AllNamespaces requires a cluster-wide binding.
+func (p *Policy) Allows(user *auth.User, namespace string, role Role) bool {
+	for _, binding := range p.Bindings {
+		if !binding.Role.Includes(role) || !binding.subjectMatches(user) {
+			continue
+		}
+		for _, ns := range binding.Namespaces {
+			if client.IsAllNamespaces(ns) || ns == namespace {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Namespaces returns the namespaces where the user holds the role, or
+// client.AllNamespaces if a binding grants it cluster-wide
+func (p *Policy) Namespaces(user *auth.User, role Role) []string {
+	var namespaces []string
+	for _, binding := range p.Bindings {
+		if !binding.Role.Includes(role) || !binding.subjectMatches(user) {
+			continue
+		}
+		for _, namespace := range binding.Namespaces {
+			if client.IsAllNamespaces(namespace) {
+				return []string{client.AllNamespaces}
+			}
+			if !slices.Contains(namespaces, namespace) {
+				namespaces = append(namespaces, namespace)
+			}
+		}
+	}
+	slices.Sort(namespaces)
+	return namespaces
+}
+
+// subjectMatches reports whether the binding names the user or one of its groups
+func (b Binding) subjectMatches(user *auth.User) bool {
+	if slices.Contains(b.Users, user.Name) {
+		return true
+	}
+	for _, group := range user.Groups {
+		if slices.Contains(b.Groups, group) {
+			return true
+		}
+	}
+	return false
+}
+
+// StaticSource serves a policy that never changes
+type StaticSource struct {
+	policy *Policy
+}
+
+// NewStaticSource creates a source for a fixed policy
+func NewStaticSource(policy *Policy) *StaticSource {
+	return &StaticSource{policy: policy}
+}
+
+// Policy implements Source
+func (s *StaticSource) Policy(_ context.Context) (*Policy, error) {
+	return s.policy, nil
+}
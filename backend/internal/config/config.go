@@ -0,0 +1,242 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+// Package config loads the console configuration from a YAML file, environment
+// variables and command-line flags
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/audit"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/auth"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/authz"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/logging"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/server"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/tracing"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/usage"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/client"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// Config is the complete configuration of the console backend
+type Config struct {
+	Server     Server          `json:"server"`
+	Kubernetes Kubernetes      `json:"kubernetes"`
+	Auth       auth.Config     `json:"auth"`
+	Authz      authz.Config    `json:"authz"`
+	Audit      audit.Config    `json:"audit"`
+	Usage      Usage           `json:"usage"`
+	Features   server.Features `json:"features"`
+	Logging    logging.Config  `json:"logging"`
+	Tracing    tracing.Config  `json:"tracing"`
+}
+
+// Server configures the HTTP server
+type Server struct {
+	// Address is the host:port the server listens on
+	Address         string          `json:"address"`
+	ReadTimeout     metav1.Duration `json:"readTimeout"`
+	WriteTimeout    metav1.Duration `json:"writeTimeout"`
+	ShutdownTimeout metav1.Duration `json:"shutdownTimeout"`
+	// CORSOrigins are the origins browsers may call the API from, e.g. https://console.example.com.
+	// There is no wildcard; none allows only same-origin calls.
+	CORSOrigins []string `json:"corsOrigins,omitempty"`
+	TLS         TLS      `json:"tls"`
+	// SwaggerUIURL is the base URL of the Swagger UI assets of the API docs
+	SwaggerUIURL string `json:"swaggerUIURL"`
+}
+
+// TLS serves HTTPS when both files are set
+type TLS struct {
+	CertFile string `json:"certFile,omitempty"`
+	KeyFile  string `json:"keyFile,omitempty"`
+}
+
+// Enabled reports whether HTTPS is served
+func (t TLS) Enabled() bool {
+	return t.CertFile != "" && t.KeyFile != ""
+}
+
+// Kubernetes configures the connection to the cluster and the namespaces the console manages
+type Kubernetes struct {
+	// Kubeconfig path, if empty $KUBECONFIG, the in-cluster config and ~/.kube/config are tried
+	Kubeconfig string `json:"kubeconfig,omitempty"`
+	// Context of the kubeconfig, if empty the current context
+	Context string `json:"context,omitempty"`
+	// InCluster forces the config of the pod service account
+	InCluster bool `json:"inCluster"`
+	// DefaultNamespace is used by requests that name no namespace
+	DefaultNamespace string `json:"defaultNamespace"`
+	// AllowedNamespaces restricts the console to these namespaces, all when empty
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+	// Timeout of requests to the API server
+	Timeout metav1.Duration `json:"timeout"`
+}
+
+// Usage configures the scraping of AI Gateway token metrics, see usage.Config
+type Usage struct {
+	MetricsURL     string          `json:"metricsURL,omitempty"`
+	ScrapeInterval metav1.Duration `json:"scrapeInterval"`
+	Retention      metav1.Duration `json:"retention"`
+	Metric         string          `json:"metric"`
+	ModelLabel     string          `json:"modelLabel"`
+	BackendLabel   string          `json:"backendLabel"`
+	TokenTypeLabel string          `json:"tokenTypeLabel"`
+	NamespaceLabel string          `json:"namespaceLabel,omitempty"`
+}
+
+// Default returns the configuration used when nothing is set
+func Default() *Config {
+	return &Config{
+		Server: Server{
+			Address:         ":8080",
+			ReadTimeout:     metav1.Duration{Duration: 15 * time.Second},
+			WriteTimeout:    metav1.Duration{Duration: 15 * time.Second},
+			ShutdownTimeout: metav1.Duration{Duration: 30 * time.Second},
+			SwaggerUIURL:    server.DefaultSwaggerUIURL,
+		},
+		Kubernetes: Kubernetes{
+			DefaultNamespace: "default",
+			Timeout:          metav1.Duration{Duration: 30 * time.Second},
+		},
+		Usage: Usage{
+			ScrapeInterval: metav1.Duration{Duration: usage.DefaultScrapeInterval},
+			Retention:      metav1.Duration{Duration: usage.DefaultRetention},
+			Metric:         usage.DefaultMetric,
+			ModelLabel:     usage.DefaultModelLabel,
+			BackendLabel:   usage.DefaultBackendLabel,
+			TokenTypeLabel: usage.DefaultTokenTypeLabel,
+		},
+		Features: server.Features{APIDocs: true, Revisions: true, Rollouts: true},
+		Logging:  logging.Config{Format: logging.FormatJSON, Level: "info"},
+		Tracing:  tracing.Config{Exporter: tracing.ExporterNone},
+	}
+}
+
+// Validate checks the configuration and returns every problem found
+func (c *Config) Validate() error {
+	var errs []error
+	invalid := func(format string, args ...any) { errs = append(errs, fmt.Errorf(format, args...)) }
+
+	if _, port, err := net.SplitHostPort(c.Server.Address); err != nil || port == "" {
+		invalid("server.address %q must be host:port", c.Server.Address)
+	}
+	if u, err := url.Parse(c.Server.SwaggerUIURL); c.Server.SwaggerUIURL != "" && (err != nil || (u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "")) {
+		invalid("server.swaggerUIURL %q must be an http(s) URL or a path", c.Server.SwaggerUIURL)
+	}
+	for _, origin := range c.Server.CORSOrigins {
+		if u, err := url.Parse(origin); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || (u.Path != "" && u.Path != "/") {
+			invalid("server.corsOrigins %q must be an http(s) origin such as https://console.example.com, there is no wildcard", origin)
+		}
+	}
+	if (c.Server.TLS.CertFile == "") != (c.Server.TLS.KeyFile == "") {
+		invalid("server.tls.certFile and server.tls.keyFile must be set together")
+	}
+	for name, d := range map[string]metav1.Duration{
+		"server.readTimeout":     c.Server.ReadTimeout,
+		"server.writeTimeout":    c.Server.WriteTimeout,
+		"server.shutdownTimeout": c.Server.ShutdownTimeout,
+		"kubernetes.timeout":     c.Kubernetes.Timeout,
+	} {
+		if d.Duration < 0 {
+			invalid("%s must not be negative", name)
+		}
+	}
+
+	k := c.Kubernetes
+	if k.InCluster && (k.Kubeconfig != "" || k.Context != "") {
+		invalid("kubernetes.inCluster cannot be combined with a kubeconfig or context")
+	}
+	for _, namespace := range append([]string{k.DefaultNamespace}, k.AllowedNamespaces...) {
+		if msgs := validation.IsDNS1123Label(namespace); len(msgs) > 0 {
+			invalid("invalid namespace %q: %s", namespace, strings.Join(msgs, ", "))
+		}
+	}
+	if len(k.AllowedNamespaces) > 0 && !slices.Contains(k.AllowedNamespaces, k.DefaultNamespace) {
+		invalid("kubernetes.defaultNamespace %q must be one of the allowed namespaces", k.DefaultNamespace)
+	}
+
+	for _, mode := range c.Auth.Modes {
+		if !slices.Contains([]string{auth.MethodOIDC, auth.MethodKubernetes, auth.MethodStatic}, mode) {
+			invalid("unknown authentication mode %q", mode)
+		}
+		if mode == auth.MethodStatic && c.Auth.StaticTokensFile == "" {
+			invalid("the static authentication mode requires auth.staticTokensFile")
+		}
+		if mode == auth.MethodOIDC && (c.Auth.OIDC.IssuerURL == "" || c.Auth.OIDC.Audience == "") {
+			invalid("the oidc authentication mode requires auth.oidc.issuerURL and auth.oidc.audience")
+		}
+	}
+	if c.Authz.PolicyConfigMap != "" && c.Authz.PolicyFile != "" {
+		invalid("only one of authz.policyConfigMap and authz.policyFile can be set")
+	}
+	if c.Authz.AllowAll && c.Authz.Enabled() {
+		invalid("authz.allowAll cannot be combined with a role policy")
+	}
+	for _, sink := range c.Audit.Sinks {
+		if !slices.Contains([]string{audit.SinkStdout, audit.SinkFile, audit.SinkEvents}, sink) {
+			invalid("unknown audit sink %q", sink)
+		}
+		if sink == audit.SinkFile && c.Audit.File == "" {
+			invalid("the file audit sink requires audit.file")
+		}
+	}
+
+	if c.Usage.MetricsURL != "" {
+		if c.Usage.ScrapeInterval.Duration <= 0 {
+			invalid("usage.scrapeInterval must be positive")
+		}
+		if c.Usage.Retention.Duration <= 0 {
+			invalid("usage.retention must be positive")
+		}
+	}
+
+	if err := c.Logging.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := c.Tracing.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// ServerConfig returns the configuration of the API server
+func (c *Config) ServerConfig() server.Config {
+	return server.Config{
+		Auth:  c.Auth,
+		Authz: c.Authz,
+		Audit: c.Audit,
+		Usage: usage.Config{
+			MetricsURL:     c.Usage.MetricsURL,
+			ScrapeInterval: c.Usage.ScrapeInterval.Duration,
+			Retention:      c.Usage.Retention.Duration,
+			Metric:         c.Usage.Metric,
+			ModelLabel:     c.Usage.ModelLabel,
+			BackendLabel:   c.Usage.BackendLabel,
+			TokenTypeLabel: c.Usage.TokenTypeLabel,
+			NamespaceLabel: c.Usage.NamespaceLabel,
+		},
+		Client: client.Config{
+			Kubeconfig: c.Kubernetes.Kubeconfig,
+			Context:    c.Kubernetes.Context,
+			InCluster:  c.Kubernetes.InCluster,
+			Timeout:    c.Kubernetes.Timeout.Duration,
+		},
+		DefaultNamespace:  c.Kubernetes.DefaultNamespace,
+		AllowedNamespaces: c.Kubernetes.AllowedNamespaces,
+		CORSOrigins:       c.Server.CORSOrigins,
+		Features:          c.Features,
+		SwaggerUIURL:      c.Server.SwaggerUIURL,
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func env(values map[string]string) func(string) string {
+	return func(name string) string { return values[name] }
+}
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "console.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadDefaults(t *testing.T) {
+	cfg, printConfig, err := Load(nil, env(nil))
+	require.NoError(t, err)
+	assert.False(t, printConfig)
+	assert.Equal(t, Default(), cfg)
+	require.NoError(t, cfg.Validate())
+
+	serverConfig := cfg.ServerConfig()
+	assert.Equal(t, "default", serverConfig.DefaultNamespace)
+	assert.Empty(t, serverConfig.CORSOrigins)
+	assert.Equal(t, 30*time.Second, serverConfig.Client.Timeout)
+	assert.True(t, serverConfig.Features.Revisions)
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	path := writeFile(t, `
+server:
+  address: ":9000"
+  readTimeout: 5s
+kubernetes:
+  defaultNamespace: from-file
+  allowedNamespaces: [from-file, from-env, from-flag]
+auth:
+  modes: [kubernetes]
+features:
+  rollouts: false
+`)
+
+	cfg, _, err := Load(
+		[]string{"--config", path, "--namespace", "from-flag", "--in-cluster"},
+		env(map[string]string{
+			"K8S_NAMESPACE":       "from-env",
+			"SERVER_PORT":         "9090",
+			"SERVER_READ_TIMEOUT": "7s",
+			"AUTH_MODES":          "oidc, kubernetes",
+		}),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, ":9090", cfg.Server.Address)
+	assert.Equal(t, 7*time.Second, cfg.Server.ReadTimeout.Duration)
+	assert.Equal(t, 15*time.Second, cfg.Server.WriteTimeout.Duration)
+	assert.Equal(t, "from-flag", cfg.Kubernetes.DefaultNamespace)
+	assert.Equal(t, []string{"from-file", "from-env", "from-flag"}, cfg.Kubernetes.AllowedNamespaces)
+	assert.True(t, cfg.Kubernetes.InCluster)
+	assert.Equal(t, []string{"oidc", "kubernetes"}, cfg.Auth.Modes)
+	assert.False(t, cfg.Features.Rollouts)
+	assert.True(t, cfg.Features.APIDocs)
+}
+
+func TestLoadConfigFileFromEnv(t *testing.T) {
+	path := writeFile(t, "server:\n  corsOrigins: [https://console.example.com]\n")
+	cfg, _, err := Load(nil, env(map[string]string{EnvConfigFile: path}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://console.example.com"}, cfg.Server.CORSOrigins)
+}
+
+func TestLoadErrors(t *testing.T) {
+	for name, test := range map[string]struct {
+		args []string
+		env  map[string]string
+		file string
+	}{
+		"unknown flag":        {args: []string{"--unknown"}},
+		"extra argument":      {args: []string{"serve"}},
+		"invalid duration":    {env: map[string]string{"K8S_TIMEOUT": "soon"}},
+		"invalid bool":        {args: []string{"--impersonate=maybe"}},
+		"invalid port":        {env: map[string]string{"SERVER_PORT": "http"}},
+		"unknown file field":  {file: "server:\n  listen: \":80\"\n"},
+		"missing config file": {args: []string{"--config", "/does/not/exist.yaml"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			args := test.args
+			if test.file != "" {
+				args = append(args, "--config", writeFile(t, test.file))
+			}
+			_, _, err := Load(args, env(test.env))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for name, mutate := range map[string]func(*Config){
+		"address without port":         func(c *Config) { c.Server.Address = "localhost" },
+		"cert without key":             func(c *Config) { c.Server.TLS.CertFile = "tls.crt" },
+		"negative timeout":             func(c *Config) { c.Server.WriteTimeout.Duration = -time.Second },
+		"in-cluster with kubeconfig":   func(c *Config) { c.Kubernetes.InCluster, c.Kubernetes.Kubeconfig = true, "/kubeconfig" },
+		"invalid namespace":            func(c *Config) { c.Kubernetes.DefaultNamespace = "Team_A" },
+		"default outside allowed":      func(c *Config) { c.Kubernetes.AllowedNamespaces = []string{"team-a"} },
+		"unknown auth mode":            func(c *Config) { c.Auth.Modes = []string{"ldap"} },
+		"static mode without file":     func(c *Config) { c.Auth.Modes = []string{"static"} },
+		"two policy sources":           func(c *Config) { c.Authz.PolicyFile, c.Authz.PolicyConfigMap = "policy.yaml", "ns/policy" },
+		"allow all with a policy":      func(c *Config) { c.Authz.AllowAll, c.Authz.PolicyFile = true, "policy.yaml" },
+		"unknown audit sink":           func(c *Config) { c.Audit.Sinks = []string{"syslog"} },
+		"usage without interval":       func(c *Config) { c.Usage.MetricsURL, c.Usage.ScrapeInterval.Duration = "http://gw:9190/metrics", 0 },
+		"file audit sink without file": func(c *Config) { c.Audit.Sinks = []string{"file"} },
+		"unknown log format":           func(c *Config) { c.Logging.Format = "text" },
+		"swagger UI over ftp":          func(c *Config) { c.Server.SwaggerUIURL = "ftp://mirror/swagger-ui" },
+		"wildcard CORS origin":         func(c *Config) { c.Server.CORSOrigins = []string{"*"} },
+		"CORS origin with a path":      func(c *Config) { c.Server.CORSOrigins = []string{"https://console.example.com/app"} },
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg := Default()
+			mutate(cfg)
+			assert.Error(t, cfg.Validate())
+		})
+	}
+}
+
+func TestPrintRoundTrip(t *testing.T) {
+	cfg, printConfig, err := Load([]string{"--print-config", "--allowed-namespaces", "default,team-a", "--address", "127.0.0.1"}, env(nil))
+	require.NoError(t, err)
+	assert.True(t, printConfig)
+	assert.Equal(t, "127.0.0.1:8080", cfg.Server.Address)
+
+	var out bytes.Buffer
+	require.NoError(t, cfg.Print(&out))
+	assert.Contains(t, out.String(), "readTimeout: 15s")
+
+	loaded, _, err := Load([]string{"--config", writeFile(t, out.String())}, env(nil))
+	require.NoError(t, err)
+	assert.Equal(t, cfg, loaded)
+}
@@ -0,0 +1,227 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package config
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// EnvConfigFile names the configuration file when --config is not given
+const EnvConfigFile = "CONSOLE_CONFIG"
+
+// setting is a configuration value that can be set by an environment variable and a flag
+type setting struct {
+	flag, env, usage string
+	boolean          bool
+	set              func(c *Config, value string) error
+}
+
+var settings = []setting{
+	{flag: "address", env: "SERVER_ADDRESS", usage: "listen address as host:port, or a host keeping the port", set: setAddress},
+	{flag: "port", env: "SERVER_PORT", usage: "listen port", set: setPort},
+	{flag: "read-timeout", env: "SERVER_READ_TIMEOUT", usage: "HTTP read timeout", set: durationOf(func(c *Config) *metav1.Duration { return &c.Server.ReadTimeout })},
+	{flag: "write-timeout", env: "SERVER_WRITE_TIMEOUT", usage: "HTTP write timeout", set: durationOf(func(c *Config) *metav1.Duration { return &c.Server.WriteTimeout })},
+	{flag: "shutdown-timeout", env: "SERVER_SHUTDOWN_TIMEOUT", usage: "graceful shutdown timeout", set: durationOf(func(c *Config) *metav1.Duration { return &c.Server.ShutdownTimeout })},
+	{flag: "cors-origins", env: "SERVER_CORS_ORIGINS", usage: "comma separated origins allowed by CORS, e.g. https://console.example.com", set: listOf(func(c *Config) *[]string { return &c.Server.CORSOrigins })},
+	{flag: "swagger-ui-url", env: "SERVER_SWAGGER_UI_URL", usage: "base URL of the Swagger UI assets of the API docs", set: stringOf(func(c *Config) *string { return &c.Server.SwaggerUIURL })},
+	{flag: "tls-cert-file", env: "SERVER_TLS_CERT_FILE", usage: "TLS certificate file, serves HTTPS with --tls-key-file", set: stringOf(func(c *Config) *string { return &c.Server.TLS.CertFile })},
+	{flag: "tls-key-file", env: "SERVER_TLS_KEY_FILE", usage: "TLS private key file", set: stringOf(func(c *Config) *string { return &c.Server.TLS.KeyFile })},
+
+	{flag: "kubeconfig", env: "K8S_CONFIG_PATH", usage: "kubeconfig file", set: stringOf(func(c *Config) *string { return &c.Kubernetes.Kubeconfig })},
+	{flag: "context", env: "K8S_CONTEXT", usage: "kubeconfig context", set: stringOf(func(c *Config) *string { return &c.Kubernetes.Context })},
+	{flag: "in-cluster", env: "K8S_IN_CLUSTER", usage: "use the in-cluster Kubernetes config", boolean: true, set: boolOf(func(c *Config) *bool { return &c.Kubernetes.InCluster })},
+	{flag: "namespace", env: "K8S_NAMESPACE", usage: "namespace of requests that name none", set: stringOf(func(c *Config) *string { return &c.Kubernetes.DefaultNamespace })},
+	{flag: "allowed-namespaces", env: "K8S_ALLOWED_NAMESPACES", usage: "comma separated namespaces the console is restricted to", set: listOf(func(c *Config) *[]string { return &c.Kubernetes.AllowedNamespaces })},
+	{flag: "kube-timeout", env: "K8S_TIMEOUT", usage: "timeout of Kubernetes API requests", set: durationOf(func(c *Config) *metav1.Duration { return &c.Kubernetes.Timeout })},
+
+	{flag: "auth-modes", env: "AUTH_MODES", usage: "comma separated authentication modes: oidc, kubernetes, static", set: listOf(func(c *Config) *[]string { return &c.Auth.Modes })},
+	{flag: "oidc-issuer-url", env: "AUTH_OIDC_ISSUER_URL", usage: "OIDC issuer URL", set: stringOf(func(c *Config) *string { return &c.Auth.OIDC.IssuerURL })},
+	{flag: "oidc-jwks-url", env: "AUTH_OIDC_JWKS_URL", usage: "OIDC key set URL, skips issuer discovery", set: stringOf(func(c *Config) *string { return &c.Auth.OIDC.JWKSURL })},
+	{flag: "oidc-audience", env: "AUTH_OIDC_AUDIENCE", usage: "OIDC audience", set: stringOf(func(c *Config) *string { return &c.Auth.OIDC.Audience })},
+	{flag: "oidc-username-claim", env: "AUTH_OIDC_USERNAME_CLAIM", usage: "OIDC claim of the user name", set: stringOf(func(c *Config) *string { return &c.Auth.OIDC.UsernameClaim })},
+	{flag: "oidc-groups-claim", env: "AUTH_OIDC_GROUPS_CLAIM", usage: "OIDC claim of the groups", set: stringOf(func(c *Config) *string { return &c.Auth.OIDC.GroupsClaim })},
+	{flag: "oidc-username-prefix", env: "AUTH_OIDC_USERNAME_PREFIX", usage: "prefix of OIDC user names", set: stringOf(func(c *Config) *string { return &c.Auth.OIDC.UsernamePrefix })},
+	{flag: "oidc-groups-prefix", env: "AUTH_OIDC_GROUPS_PREFIX", usage: "prefix of OIDC groups", set: stringOf(func(c *Config) *string { return &c.Auth.OIDC.GroupsPrefix })},
+	{flag: "token-review-audiences", env: "AUTH_TOKEN_REVIEW_AUDIENCES", usage: "comma separated audiences of Kubernetes tokens", set: listOf(func(c *Config) *[]string { return &c.Auth.TokenReviewAudiences })},
+	{flag: "static-tokens-file", env: "AUTH_STATIC_TOKENS_FILE", usage: "token file of the static mode", set: stringOf(func(c *Config) *string { return &c.Auth.StaticTokensFile })},
+	{flag: "impersonate", env: "AUTH_IMPERSONATE", usage: "make Kubernetes calls as the authenticated user", boolean: true, set: boolOf(func(c *Config) *bool { return &c.Auth.Impersonate })},
+
+	{flag: "authz-policy-configmap", env: "AUTHZ_POLICY_CONFIGMAP", usage: "namespace/name of the role policy ConfigMap", set: stringOf(func(c *Config) *string { return &c.Authz.PolicyConfigMap })},
+	{flag: "authz-policy-file", env: "AUTHZ_POLICY_FILE", usage: "role policy file", set: stringOf(func(c *Config) *string { return &c.Authz.PolicyFile })},
+	{flag: "authz-allow-all", env: "AUTHZ_ALLOW_ALL", usage: "without a role policy, make every authenticated user an admin", boolean: true, set: boolOf(func(c *Config) *bool { return &c.Authz.AllowAll })},
+
+	{flag: "audit-sinks", env: "AUDIT_SINKS", usage: "comma separated audit sinks: stdout, file, events", set: listOf(func(c *Config) *[]string { return &c.Audit.Sinks })},
+	{flag: "audit-file", env: "AUDIT_FILE", usage: "JSON lines file of the file audit sink", set: stringOf(func(c *Config) *string { return &c.Audit.File })},
+
+	{flag: "usage-metrics-url", env: "USAGE_METRICS_URL", usage: "Prometheus endpoint of the AI Gateway", set: stringOf(func(c *Config) *string { return &c.Usage.MetricsURL })},
+	{flag: "usage-scrape-interval", env: "USAGE_SCRAPE_INTERVAL", usage: "time between usage scrapes", set: durationOf(func(c *Config) *metav1.Duration { return &c.Usage.ScrapeInterval })},
+	{flag: "usage-retention", env: "USAGE_RETENTION", usage: "how long usage is kept", set: durationOf(func(c *Config) *metav1.Duration { return &c.Usage.Retention })},
+	{flag: "usage-metric", env: "USAGE_METRIC", usage: "token counter metric", set: stringOf(func(c *Config) *string { return &c.Usage.Metric })},
+	{flag: "usage-model-label", env: "USAGE_MODEL_LABEL", usage: "label of the model", set: stringOf(func(c *Config) *string { return &c.Usage.ModelLabel })},
+	{flag: "usage-backend-label", env: "USAGE_BACKEND_LABEL", usage: "label of the backend", set: stringOf(func(c *Config) *string { return &c.Usage.BackendLabel })},
+	{flag: "usage-token-type-label", env: "USAGE_TOKEN_TYPE_LABEL", usage: "label of the token type", set: stringOf(func(c *Config) *string { return &c.Usage.TokenTypeLabel })},
+	{flag: "usage-namespace-label", env: "USAGE_NAMESPACE_LABEL", usage: "label of the backend namespace", set: stringOf(func(c *Config) *string { return &c.Usage.NamespaceLabel })},
+
+	{flag: "feature-api-docs", env: "FEATURE_API_DOCS", usage: "serve the OpenAPI document and Swagger UI", boolean: true, set: boolOf(func(c *Config) *bool { return &c.Features.APIDocs })},
+	{flag: "feature-revisions", env: "FEATURE_REVISIONS", usage: "record the revision history of providers", boolean: true, set: boolOf(func(c *Config) *bool { return &c.Features.Revisions })},
+	{flag: "feature-rollouts", env: "FEATURE_ROLLOUTS", usage: "allow staged traffic split rollouts", boolean: true, set: boolOf(func(c *Config) *bool { return &c.Features.Rollouts })},
+
+	{flag: "log-format", env: "LOG_FORMAT", usage: "log format: json or console", set: stringOf(func(c *Config) *string { return &c.Logging.Format })},
+	{flag: "log-level", env: "LOG_LEVEL", usage: "log level: debug, info, warn, error or a verbosity", set: stringOf(func(c *Config) *string { return &c.Logging.Level })},
+
+	{flag: "tracing-exporter", env: "TRACING_EXPORTER", usage: "span exporter: none, otlp or stdout", set: stringOf(func(c *Config) *string { return &c.Tracing.Exporter })},
+	{flag: "tracing-endpoint", env: "TRACING_ENDPOINT", usage: "URL of the OTLP/HTTP collector, e.g. http://localhost:4318", set: stringOf(func(c *Config) *string { return &c.Tracing.Endpoint })},
+}
+
+// Load builds the configuration from, in increasing precedence, the defaults, the YAML
+// file named by --config or CONSOLE_CONFIG, environment variables and flags.
+// printConfig reports whether --print-config was given. The configuration is not validated.
+func Load(args []string, getenv func(string) string) (cfg *Config, printConfig bool, err error) {
+	fs := flag.NewFlagSet("console", flag.ContinueOnError)
+	configFile := fs.String("config", getenv(EnvConfigFile), "YAML configuration file ("+EnvConfigFile+")")
+	fs.BoolVar(&printConfig, "print-config", false, "print the configuration as YAML and exit")
+
+	// Flags are applied after the file and the environment, in the order given
+	var fromFlags []func(*Config) error
+	for _, s := range settings {
+		parse := func(value string) error {
+			fromFlags = append(fromFlags, func(c *Config) error {
+				if err := s.set(c, value); err != nil {
+					return fmt.Errorf("invalid value %q for flag --%s: %w", value, s.flag, err)
+				}
+				return nil
+			})
+			return nil
+		}
+		usage := fmt.Sprintf("%s (%s)", s.usage, s.env)
+		if s.boolean {
+			fs.BoolFunc(s.flag, usage, parse)
+		} else {
+			fs.Func(s.flag, usage, parse)
+		}
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, false, err
+	}
+	if fs.NArg() > 0 {
+		return nil, false, fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+
+	cfg = Default()
+	if *configFile != "" {
+		if err := cfg.loadFile(*configFile); err != nil {
+			return nil, false, err
+		}
+	}
+	for _, s := range settings {
+		if value := getenv(s.env); value != "" {
+			if err := s.set(cfg, value); err != nil {
+				return nil, false, fmt.Errorf("invalid %s %q: %w", s.env, value, err)
+			}
+		}
+	}
+	for _, apply := range fromFlags {
+		if err := apply(cfg); err != nil {
+			return nil, false, err
+		}
+	}
+	return cfg, printConfig, nil
+}
+
+// loadFile merges a YAML file into the configuration; unknown fields are rejected
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	if err := yaml.UnmarshalStrict(data, c); err != nil {
+		return fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// Print writes the configuration as YAML, in the format of the config file
+func (c *Config) Print(w io.Writer) error {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+func stringOf(field func(*Config) *string) func(*Config, string) error {
+	return func(c *Config, value string) error {
+		*field(c) = value
+		return nil
+	}
+}
+
+func listOf(field func(*Config) *[]string) func(*Config, string) error {
+	return func(c *Config, value string) error {
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		*field(c) = items
+		return nil
+	}
+}
+
+func boolOf(field func(*Config) *bool) func(*Config, string) error {
+	return func(c *Config, value string) error {
+		enabled, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("must be true or false")
+		}
+		*field(c) = enabled
+		return nil
+	}
+}
+
+func durationOf(field func(*Config) *metav1.Duration) func(*Config, string) error {
+	return func(c *Config, value string) error {
+		d, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("must be a duration such as 30s")
+		}
+		*field(c) = metav1.Duration{Duration: d}
+		return nil
+	}
+}
+
+// setAddress sets the listen address; a bare host keeps the current port
+func setAddress(c *Config, value string) error {
+	if _, _, err := net.SplitHostPort(value); err == nil {
+		c.Server.Address = value
+		return nil
+	}
+	_, port, _ := net.SplitHostPort(c.Server.Address)
+	c.Server.Address = net.JoinHostPort(strings.Trim(value, "[]"), port)
+	return nil
+}
+
+// setPort sets the port of the listen address
+func setPort(c *Config, value string) error {
+	if port, err := strconv.Atoi(value); err != nil || port < 0 || port > 65535 {
+		return fmt.Errorf("must be a port number")
+	}
+	host, _, _ := net.SplitHostPort(c.Server.Address)
+	c.Server.Address = net.JoinHostPort(host, value)
+	return nil
+}
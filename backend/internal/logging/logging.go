@@ -0,0 +1,81 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+// Package logging builds the logr logger of the console, backed by zap
+package logging
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/zapr"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	FormatJSON    = "json"
+	FormatConsole = "console"
+)
+
+// Config selects the format and level of the logs
+type Config struct {
+	// Format is json or console
+	Format string `json:"format"`
+	// Level is debug, info, warn or error, or a logr verbosity such as 2
+	Level string `json:"level"`
+}
+
+// Validate checks the format and level
+func (c Config) Validate() error {
+	if c.Format != FormatJSON && c.Format != FormatConsole {
+		return fmt.Errorf("unknown log format %q: must be %s or %s", c.Format, FormatJSON, FormatConsole)
+	}
+	_, err := parseLevel(c.Level)
+	return err
+}
+
+// New returns a logger writing to stderr in the configured format, dropping
+// messages below the configured level
+func New(cfg Config) (logr.Logger, error) {
+	level, err := parseLevel(cfg.Level)
+	if err != nil {
+		return logr.Discard(), err
+	}
+
+	encoderConfig := zap.NewProductionEncoderConfig()
+	encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	var encoder zapcore.Encoder
+	switch cfg.Format {
+	case FormatJSON:
+		encoder = zapcore.NewJSONEncoder(encoderConfig)
+	case FormatConsole:
+		encoderConfig.EncodeLevel = zapcore.CapitalLevelEncoder
+		encoder = zapcore.NewConsoleEncoder(encoderConfig)
+	default:
+		return logr.Discard(), fmt.Errorf("unknown log format %q: must be %s or %s", cfg.Format, FormatJSON, FormatConsole)
+	}
+
+	core := zapcore.NewCore(encoder, zapcore.Lock(os.Stderr), level)
+	return zapr.NewLogger(zap.New(core, zap.AddCaller(), zap.AddStacktrace(zapcore.PanicLevel))), nil
+}
+
+// parseLevel maps a level name or a logr verbosity to a zap level.
+// logr's V(n) is logged at zap level -n, so debug is the same as 1.
+func parseLevel(level string) (zapcore.Level, error) {
+	if v, err := strconv.Atoi(level); err == nil {
+		if v < 0 {
+			return 0, fmt.Errorf("invalid log level %q: verbosity must not be negative", level)
+		}
+		return zapcore.Level(-v), nil
+	}
+	parsed, err := zapcore.ParseLevel(level)
+	if err != nil {
+		return 0, fmt.Errorf("invalid log level %q: must be debug, info, warn, error or a verbosity", level)
+	}
+	return parsed, nil
+}
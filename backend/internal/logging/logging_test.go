@@ -0,0 +1,52 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package logging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestParseLevel(t *testing.T) {
+	for level, expected := range map[string]zapcore.Level{
+		"debug": zapcore.DebugLevel,
+		"info":  zapcore.InfoLevel,
+		"warn":  zapcore.WarnLevel,
+		"error": zapcore.ErrorLevel,
+		"0":     zapcore.InfoLevel,
+		"1":     zapcore.DebugLevel,
+		"3":     zapcore.Level(-3),
+	} {
+		parsed, err := parseLevel(level)
+		require.NoError(t, err, level)
+		assert.Equal(t, expected, parsed, level)
+	}
+
+	for _, level := range []string{"verbose", "-1"} {
+		_, err := parseLevel(level)
+		assert.Error(t, err, level)
+	}
+}
+
+func TestNew(t *testing.T) {
+	logger, err := New(Config{Format: FormatJSON, Level: "info"})
+	require.NoError(t, err)
+	assert.True(t, logger.Enabled())
+	assert.False(t, logger.V(1).Enabled())
+
+	logger, err = New(Config{Format: FormatConsole, Level: "2"})
+	require.NoError(t, err)
+	assert.True(t, logger.V(2).Enabled())
+	assert.False(t, logger.V(3).Enabled())
+
+	_, err = New(Config{Format: "text", Level: "info"})
+	assert.Error(t, err)
+	assert.Error(t, Config{Format: "text", Level: "info"}.Validate())
+	assert.NoError(t, Config{Format: FormatConsole, Level: "debug"}.Validate())
+}
@@ -0,0 +1,59 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+// Package metrics defines the Prometheus metrics of the console and serves them on /metrics
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/client"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	// HTTPRequests counts the handled API requests by method, route template and status code
+	HTTPRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "console_http_requests_total",
+		Help: "HTTP requests handled by the console by method, route and status code.",
+	}, []string{"method", "route", "status"})
+
+	// HTTPRequestDuration observes the latency of the API requests by method and route template
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "console_http_request_duration_seconds",
+		Help:    "Latency of HTTP requests handled by the console by method and route.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	// TranslationFailures counts the API requests that failed to translate the Kubernetes resources
+	// of a provider. Broken providers are counted by console_llm_providers{status="Broken"}.
+	TranslationFailures = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "console_provider_translation_failures_total",
+		Help: "API requests that failed to translate Kubernetes resources to an LLM provider.",
+	})
+)
+
+// NewRegistry returns a registry with the console, Kubernetes client, Go runtime and
+// process metrics and any additional collectors
+func NewRegistry(additional ...prometheus.Collector) *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequests,
+		HTTPRequestDuration,
+		TranslationFailures,
+	)
+	registry.MustRegister(client.MetricsCollectors()...)
+	registry.MustRegister(additional...)
+	return registry
+}
+
+// Handler serves the metrics of the registry in the Prometheus exposition format
+func Handler(registry *prometheus.Registry) http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{Registry: registry})
+}
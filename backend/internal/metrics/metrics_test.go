@@ -0,0 +1,54 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/llm"
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderCollector(t *testing.T) {
+	calls := 0
+	var err error
+	providers := []llm.LLMProvider{
+		{Schema: "OpenAI", Auth: llm.AuthConfig{Type: "APIKey"}, Status: "Accepted"},
+		{Schema: "OpenAI", Auth: llm.AuthConfig{Type: "APIKey"}, Status: "Accepted"},
+		{Schema: "AWSBedrock", Auth: llm.AuthConfig{Type: "AWS"}, Status: llm.StatusBroken},
+		{Schema: "OpenAI", Auth: llm.AuthConfig{Type: "APIKey"}},
+	}
+	collector := NewProviderCollector(func(context.Context) ([]llm.LLMProvider, error) {
+		calls++
+		return providers, err
+	}, time.Hour, logr.Discard())
+
+	expected := `
+# HELP console_llm_providers LLM providers managed by the console by API schema, authentication type and status.
+# TYPE console_llm_providers gauge
+console_llm_providers{auth_type="APIKey",schema="OpenAI",status="Accepted"} 2
+console_llm_providers{auth_type="APIKey",schema="OpenAI",status="Unknown"} 1
+console_llm_providers{auth_type="AWS",schema="AWSBedrock",status="Broken"} 1
+`
+	require.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected)))
+
+	// Counts are cached until they expire
+	require.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected)))
+	assert.Equal(t, 1, calls)
+
+	// Failures keep the last counts
+	collector.ttl = 0
+	err = errors.New("unreachable")
+	require.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected)))
+	assert.Equal(t, 2, calls)
+}
@@ -0,0 +1,95 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package metrics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/llm"
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DefaultProviderCacheTTL is how long a provider count is reused across scrapes
+const DefaultProviderCacheTTL = time.Minute
+
+// ProviderLister lists every provider the console manages
+type ProviderLister func(ctx context.Context) ([]llm.LLMProvider, error)
+
+var providersDesc = prometheus.NewDesc(
+	"console_llm_providers",
+	"LLM providers managed by the console by API schema, authentication type and status.",
+	[]string{"schema", "auth_type", "status"}, nil,
+)
+
+type providerKey struct {
+	schema, authType, status string
+}
+
+// ProviderCollector counts the LLM providers by schema, authentication type and status.
+// Listing providers reads several resources per provider, so the counts are cached
+// and the last counts are kept when listing fails.
+type ProviderCollector struct {
+	list    ProviderLister
+	ttl     time.Duration
+	timeout time.Duration
+	logger  logr.Logger
+
+	mu        sync.Mutex
+	counts    map[providerKey]int
+	refreshed time.Time
+}
+
+// NewProviderCollector returns a collector counting the providers returned by list
+func NewProviderCollector(list ProviderLister, ttl time.Duration, logger logr.Logger) *ProviderCollector {
+	if ttl <= 0 {
+		ttl = DefaultProviderCacheTTL
+	}
+	return &ProviderCollector{list: list, ttl: ttl, timeout: 10 * time.Second, logger: logger}
+}
+
+// Describe implements prometheus.Collector
+func (p *ProviderCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- providersDesc
+}
+
+// Collect implements prometheus.Collector
+func (p *ProviderCollector) Collect(ch chan<- prometheus.Metric) {
+	for key, count := range p.refresh() {
+		ch <- prometheus.MustNewConstMetric(providersDesc, prometheus.GaugeValue, float64(count), key.schema, key.authType, key.status)
+	}
+}
+
+// refresh returns the cached counts, listing the providers again once they expire
+func (p *ProviderCollector) refresh() map[providerKey]int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.counts != nil && time.Since(p.refreshed) < p.ttl {
+		return p.counts
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+	providers, err := p.list(ctx)
+	if err != nil {
+		p.logger.Error(err, "Failed to count LLM providers, reporting the last counts")
+		return p.counts
+	}
+
+	counts := map[providerKey]int{}
+	for _, provider := range providers {
+		status := provider.Status
+		if status == "" {
+			status = "Unknown"
+		}
+		counts[providerKey{schema: provider.Schema, authType: provider.Auth.Type, status: status}]++
+	}
+	p.counts, p.refreshed = counts, time.Now()
+	return counts
+}
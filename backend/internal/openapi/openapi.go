@@ -0,0 +1,300 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+// Package openapi builds an OpenAPI 3 document from endpoint descriptions,
+// deriving request and response schemas from the Go types served by the API.
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Version of the OpenAPI specification produced by this package
+const Version = "3.0.3"
+
+// Document is an OpenAPI 3 document
+type Document struct {
+	OpenAPI    string                `json:"openapi"`
+	Info       Info                  `json:"info"`
+	Security   []SecurityRequirement `json:"security,omitempty"`
+	Paths      map[string]*PathItem  `json:"paths"`
+	Components Components            `json:"components"`
+}
+
+// SecurityRequirement maps security scheme names to required scopes
+type SecurityRequirement map[string][]string
+
+// Info describes the API
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// PathItem holds the operations available on a path
+type PathItem struct {
+	Get    *Operation `json:"get,omitempty"`
+	Put    *Operation `json:"put,omitempty"`
+	Post   *Operation `json:"post,omitempty"`
+	Delete *Operation `json:"delete,omitempty"`
+	Patch  *Operation `json:"patch,omitempty"`
+}
+
+// Operation describes a single API operation on a path
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Parameters  []Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+	// Security overrides the document security, an empty list makes the operation public
+	Security *[]SecurityRequirement `json:"security,omitempty"`
+	// Deprecated operations are kept for existing clients only
+	Deprecated bool `json:"deprecated,omitempty"`
+}
+
+// Parameter is a path, query or header parameter
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody describes the body of a request
+type RequestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response describes a response of an operation
+type Response struct {
+	Description string               `json:"description"`
+	Headers     map[string]*Header   `json:"headers,omitempty"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// Header describes a response header
+type Header struct {
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// MediaType holds the schema of a body for a content type
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Components holds the reusable schemas referenced from operations
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme describes how clients authenticate
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+	Description  string `json:"description,omitempty"`
+}
+
+// Schema is the subset of the OpenAPI schema object used by the console
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+// Endpoint describes an HTTP route of the console API
+type Endpoint struct {
+	// Method and Path as registered on the Gin router, e.g. "/api/v1/llm/providers/:name"
+	Method string
+	Path   string
+
+	OperationID string
+	Summary     string
+	Tag         string
+
+	// Query lists the accepted query parameters, path parameters are derived from Path
+	Query []Parameter
+	// Headers lists the accepted request headers
+	Headers []Parameter
+	// ResponseHeaders lists the headers of the success response
+	ResponseHeaders []Parameter
+	// Request is a value of the Go type of the request body, nil if there is none
+	Request any
+	// Response is a value of the Go type of the success body, nil if there is none
+	Response any
+	// Status is the success status code, defaults to 200
+	Status int
+	// ContentType of the success body, defaults to application/json
+	ContentType string
+	// Public operations do not require the document's security schemes
+	Public bool
+	// Deprecated operations are kept for existing clients only
+	Deprecated bool
+}
+
+var pathParam = regexp.MustCompile(`:([A-Za-z0-9_]+)`)
+
+// OpenAPIPath converts a Gin route path to an OpenAPI path template
+func OpenAPIPath(ginPath string) string {
+	return pathParam.ReplaceAllString(ginPath, "{$1}")
+}
+
+// Build creates the document for the endpoints. Every operation also documents
+// errorBody, the Go type of error responses, as its default response.
+func Build(info Info, endpoints []Endpoint, errorBody any, errorContentType string) (*Document, error) {
+	doc := &Document{
+		OpenAPI:    Version,
+		Info:       info,
+		Paths:      map[string]*PathItem{},
+		Components: Components{Schemas: map[string]*Schema{}},
+	}
+	gen := newGenerator(doc.Components.Schemas)
+	errorSchema := gen.schemaFor(errorBody)
+
+	for _, ep := range endpoints {
+		path := OpenAPIPath(ep.Path)
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = &PathItem{}
+			doc.Paths[path] = item
+		}
+
+		op := &Operation{
+			OperationID: ep.OperationID,
+			Summary:     ep.Summary,
+			Responses:   map[string]*Response{},
+			Deprecated:  ep.Deprecated,
+		}
+		if ep.Tag != "" {
+			op.Tags = []string{ep.Tag}
+		}
+		if ep.Public {
+			op.Security = &[]SecurityRequirement{}
+		}
+
+		for _, match := range pathParam.FindAllStringSubmatch(ep.Path, -1) {
+			op.Parameters = append(op.Parameters, Parameter{
+				Name:     match[1],
+				In:       "path",
+				Required: true,
+				Schema:   &Schema{Type: "string"},
+			})
+		}
+		for _, param := range ep.Query {
+			param.In = "query"
+			if param.Schema == nil {
+				param.Schema = &Schema{Type: "string"}
+			}
+			op.Parameters = append(op.Parameters, param)
+		}
+		for _, param := range ep.Headers {
+			param.In = "header"
+			if param.Schema == nil {
+				param.Schema = &Schema{Type: "string"}
+			}
+			op.Parameters = append(op.Parameters, param)
+		}
+
+		if ep.Request != nil {
+			op.RequestBody = &RequestBody{
+				Required: true,
+				Content:  map[string]MediaType{"application/json": {Schema: gen.schemaFor(ep.Request)}},
+			}
+		}
+
+		status := ep.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		success := &Response{Description: http.StatusText(status)}
+		for _, header := range ep.ResponseHeaders {
+			if success.Headers == nil {
+				success.Headers = map[string]*Header{}
+			}
+			schema := header.Schema
+			if schema == nil {
+				schema = &Schema{Type: "string"}
+			}
+			success.Headers[header.Name] = &Header{Description: header.Description, Schema: schema}
+		}
+		if ep.Response != nil {
+			contentType := ep.ContentType
+			if contentType == "" {
+				contentType = "application/json"
+			}
+			success.Content = map[string]MediaType{contentType: {Schema: gen.schemaFor(ep.Response)}}
+		}
+		op.Responses[strconv.Itoa(status)] = success
+		op.Responses["default"] = &Response{
+			Description: "Error",
+			Content:     map[string]MediaType{errorContentType: {Schema: errorSchema}},
+		}
+
+		if err := item.set(ep.Method, op); err != nil {
+			return nil, fmt.Errorf("endpoint %s %s: %w", ep.Method, ep.Path, err)
+		}
+	}
+
+	return doc, nil
+}
+
+// set registers the operation for the HTTP method
+func (p *PathItem) set(method string, op *Operation) error {
+	var slot **Operation
+	switch strings.ToUpper(method) {
+	case http.MethodGet:
+		slot = &p.Get
+	case http.MethodPut:
+		slot = &p.Put
+	case http.MethodPost:
+		slot = &p.Post
+	case http.MethodDelete:
+		slot = &p.Delete
+	case http.MethodPatch:
+		slot = &p.Patch
+	default:
+		return fmt.Errorf("unsupported method %q", method)
+	}
+	if *slot != nil {
+		return fmt.Errorf("duplicate operation")
+	}
+	*slot = op
+	return nil
+}
+
+// Operations returns the "METHOD path" keys of every operation in the document
+func (d *Document) Operations() []string {
+	var keys []string
+	for path, item := range d.Paths {
+		for method, op := range map[string]*Operation{
+			http.MethodGet:    item.Get,
+			http.MethodPut:    item.Put,
+			http.MethodPost:   item.Post,
+			http.MethodDelete: item.Delete,
+			http.MethodPatch:  item.Patch,
+		} {
+			if op != nil {
+				keys = append(keys, method+" "+path)
+			}
+		}
+	}
+	return keys
+}
@@ -0,0 +1,126 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package router
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/apierror"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/auth"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/authz"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/server"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/client"
+	"github.com/gin-gonic/gin"
+	"github.com/go-logr/logr"
+)
+
+// authorize requires the authenticated user to hold the role in every namespace the request
+// targets, which is defaultNamespace when the request names none
+func authorize(authorizer authz.Authorizer, defaultNamespace string, role authz.Role) gin.HandlerFunc {
+	return authorizeWith(authorizer, defaultNamespace, func(*gin.Context) (authz.Role, error) { return role, nil })
+}
+
+// authorizeManifests requires the viewer role to read manifests and the admin role to reveal secrets
+func authorizeManifests(authorizer authz.Authorizer, defaultNamespace string) gin.HandlerFunc {
+	return authorizeWith(authorizer, defaultNamespace, func(c *gin.Context) (authz.Role, error) {
+		include, err := server.ParseIncludeSecrets(c.Query(server.IncludeSecretsParam))
+		if err != nil {
+			return "", err
+		}
+		if include {
+			return authz.RoleAdmin, nil
+		}
+		return authz.RoleViewer, nil
+	})
+}
+
+func authorizeWith(authorizer authz.Authorizer, defaultNamespace string, roleFor func(*gin.Context) (authz.Role, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if authorizer == nil {
+			server.AbortWithError(c, apierror.Internal("authorization is not configured"))
+			return
+		}
+
+		role, err := roleFor(c)
+		if err != nil {
+			server.AbortWithError(c, err)
+			return
+		}
+
+		ctx := c.Request.Context()
+		user, _ := auth.UserFromContext(ctx)
+
+		namespaces, err := requestNamespaces(c, defaultNamespace)
+		if err != nil {
+			server.AbortWithError(c, err)
+			return
+		}
+		ctx = logr.NewContext(ctx, logr.FromContextOrDiscard(ctx).WithValues("namespace", strings.Join(namespaces, ",")))
+		c.Request = c.Request.WithContext(ctx)
+
+		// Listing all namespaces is narrowed to the namespaces the user may read
+		if len(namespaces) == 1 && client.IsAllNamespaces(namespaces[0]) {
+			allowed, err := authorizer.Namespaces(ctx, user, role)
+			if err != nil {
+				server.AbortWithError(c, err)
+				return
+			}
+			if len(allowed) == 0 {
+				server.AbortWithError(c, apierror.New(apierror.CodeForbidden, "the %s role is not granted in any namespace", role))
+				return
+			}
+			query := c.Request.URL.Query()
+			query.Set("namespace", strings.Join(allowed, ","))
+			c.Request.URL.RawQuery = query.Encode()
+			c.Next()
+			return
+		}
+
+		for _, namespace := range namespaces {
+			if err := authorizer.Authorize(ctx, user, namespace, role); err != nil {
+				server.AbortWithError(c, err)
+				return
+			}
+		}
+		c.Next()
+	}
+}
+
+// requestNamespaces returns the namespaces targeted by the request: those of the namespace
+// query parameter or, for POST and PUT, the single namespace the handler writes to, resolved
+// from the query and the namespace of the JSON body as the handlers do.
+func requestNamespaces(c *gin.Context, defaultNamespace string) ([]string, error) {
+	if (c.Request.Method == http.MethodPost || c.Request.Method == http.MethodPut) && c.Request.Body != nil {
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			return nil, apierror.Wrap(apierror.CodeInvalid, err, "failed to read request body")
+		}
+		// Restore the body for the handler
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		var target struct {
+			Namespace string `json:"namespace"`
+		}
+		if len(body) > 0 {
+			_ = json.Unmarshal(body, &target)
+		}
+		namespace, err := server.ResolveNamespace(c.QueryArray("namespace"), target.Namespace, defaultNamespace)
+		if err != nil {
+			return nil, err
+		}
+		return []string{namespace}, nil
+	}
+
+	namespaces := client.ParseNamespaces(c.QueryArray("namespace")...)
+	if len(namespaces) == 0 {
+		namespaces = []string{defaultNamespace}
+	}
+	return namespaces, nil
+}
@@ -6,8 +6,24 @@
 package router
 
 import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/apierror"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/auth"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/authz"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/metrics"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/requestid"
 	"github.com/envoyproxy/ai-gateway/console/backend/internal/server"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/tracing"
 	"github.com/gin-gonic/gin"
+	"github.com/go-logr/logr"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // NewRouter creates a new Gin router with all routes configured
@@ -19,34 +35,103 @@ func NewRouter(srv *server.Server) *gin.Engine {
 	router := gin.New()
 
 	// Add middleware
-	router.Use(gin.Recovery())   // Recovery middleware
-	router.Use(corsMiddleware()) // CORS middleware
+	router.Use(tracingMiddleware())                 // Spans continuing the trace context of the caller
+	router.Use(requestIDMiddleware())               // Request ID middleware
+	router.Use(loggerMiddleware(srv.Logger()))      // Request-scoped logger
+	router.Use(metricsMiddleware())                 // Request metrics
+	router.Use(gin.CustomRecovery(recoveryHandler)) // Recovery middleware
+	router.Use(corsMiddleware(srv.CORSOrigins()))   // CORS middleware
+
+	// Unknown routes return problem details like every other API error
+	router.HandleMethodNotAllowed = true
+	router.NoRoute(func(c *gin.Context) {
+		server.AbortWithError(c, apierror.NotFound("no route for %s %s", c.Request.Method, c.Request.URL.Path))
+	})
+	router.NoMethod(func(c *gin.Context) {
+		server.AbortWithError(c, apierror.New(apierror.CodeInvalid, "method %s is not allowed for %s", c.Request.Method, c.Request.URL.Path))
+	})
 
-	// Health check endpoint
-	router.GET("/health", gin.WrapF(srv.HealthCheck))
+	// Liveness and readiness probes
+	router.GET("/livez", srv.Livez)
+	router.GET("/readyz", srv.Readyz)
+	router.GET("/health", srv.Health)
+
+	// Prometheus metrics
+	router.GET("/metrics", gin.WrapH(srv.Metrics()))
 
 	// API v1 routes
 	apiV1 := router.Group("/api/v1")
 	{
-		// LLM provider routes
-		llm := apiV1.Group("/llm")
+		// API documentation is public
+		apiV1.GET("/openapi.json", srv.GetOpenAPISpec)
+		apiV1.GET("/docs", srv.GetAPIDocs)
+
+		// Every other route requires an authenticated user
+		authenticated := apiV1.Group("", authMiddleware(srv.Authenticator()))
+
+		// LLM provider routes, each requiring a console role in the targeted namespace
+		authorizer, defaultNamespace := srv.Authorizer(), srv.DefaultNamespace()
+		viewer := authorize(authorizer, defaultNamespace, authz.RoleViewer)
+		editor := authorize(authorizer, defaultNamespace, authz.RoleEditor)
+		admin := authorize(authorizer, defaultNamespace, authz.RoleAdmin)
+
+		llm := authenticated.Group("/llm")
 		{
-			llm.GET("/providers", gin.WrapF(srv.GetLLMProviders))
-			llm.POST("/providers", srv.CreateLLMProvider)
-			llm.GET("/providers/:name", srv.GetLLMProviderByName)
-			llm.DELETE("/providers/:name", srv.DeleteLLMProvider)
+			llm.GET("/providers", viewer, gin.WrapF(srv.GetLLMProviders))
+			llm.POST("/providers", editor, srv.CreateLLMProvider)
+			llm.GET("/providers/:name", viewer, srv.GetLLMProviderByName)
+			llm.PUT("/providers/:name", editor, srv.UpdateLLMProvider)
+			llm.DELETE("/providers/:name", editor, srv.DeleteLLMProvider)
+			llm.GET("/providers/:name/revisions", viewer, srv.ListLLMProviderRevisions)
+			llm.POST("/providers/:name/revisions/:rev", editor, srv.RollbackLLMProvider)
+			llm.GET("/providers/:name/manifests", authorizeManifests(authorizer, defaultNamespace), srv.GetLLMProviderManifests)
+			llm.PUT("/providers/:name/credentials", admin, srv.RotateLLMProviderCredentials)
+
+			llm.GET("/models", viewer, srv.ListModels)
+
+			llm.GET("/ratelimits", viewer, srv.ListRateLimitPolicies)
+			llm.POST("/ratelimits", editor, srv.CreateRateLimitPolicy)
+			llm.GET("/ratelimits/:name", viewer, srv.GetRateLimitPolicy)
+			llm.PUT("/ratelimits/:name", editor, srv.UpdateRateLimitPolicy)
+			llm.DELETE("/ratelimits/:name", editor, srv.DeleteRateLimitPolicy)
+
+			llm.GET("/routes/:name/rules/:rule/split", viewer, srv.GetTrafficSplit)
+			llm.PUT("/routes/:name/rules/:rule/split", editor, srv.UpdateTrafficSplit)
+			llm.GET("/routes/:name/rules/:rule/rollout", viewer, srv.GetRollout)
+			llm.POST("/routes/:name/rules/:rule/rollout", editor, srv.StartRollout)
+			llm.POST("/routes/:name/rules/:rule/rollout/pause", editor, srv.PauseRollout)
+			llm.POST("/routes/:name/rules/:rule/rollout/resume", editor, srv.ResumeRollout)
+			llm.POST("/routes/:name/rules/:rule/rollout/abort", editor, srv.AbortRollout)
 		}
+
+		// Token usage and cost of the namespaces the user may view
+		authenticated.GET("/usage", viewer, srv.GetUsage)
+
+		// The audit log reveals who changed what and is restricted to admins
+		authenticated.GET("/audit", admin, srv.GetAuditEvents)
+
+		// Diagnostics reveal the cluster setup and RBAC of the console
+		authenticated.GET("/diagnostics", admin, srv.GetDiagnostics)
 	}
 
 	return router
 }
 
-// corsMiddleware returns a Gin middleware for CORS
-func corsMiddleware() gin.HandlerFunc {
+// corsMiddleware returns a Gin middleware for CORS. Only the listed origins get CORS
+// headers, there is no wildcard: the API carries credentials and provider secrets, so
+// every origin allowed to call it from a browser is named. No origins disable CORS.
+func corsMiddleware(origins []string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		c.Header("Access-Control-Allow-Origin", "*")
+		origin := c.GetHeader("Origin")
+		if origin == "" || origin == "*" || !slices.Contains(origins, origin) {
+			c.Next()
+			return
+		}
+		c.Header("Access-Control-Allow-Origin", origin)
+		c.Header("Vary", "Origin")
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization, "+server.HeaderIfMatch+", "+requestid.Header)
+		c.Header("Access-Control-Expose-Headers", server.HeaderETag+", "+requestid.Header)
 
 		// Handle preflight requests
 		if c.Request.Method == "OPTIONS" {
@@ -57,3 +142,95 @@ func corsMiddleware() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// authMiddleware authenticates the bearer token of the request and stores the user in its context
+func authMiddleware(authenticator auth.Authenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if authenticator == nil {
+			server.AbortWithError(c, apierror.New(apierror.CodeUnauthenticated, "authentication is not configured"))
+			return
+		}
+
+		token := ""
+		if header := c.GetHeader("Authorization"); len(header) > len("Bearer ") && strings.EqualFold(header[:len("Bearer ")], "Bearer ") {
+			token = strings.TrimSpace(header[len("Bearer "):])
+		}
+
+		user, err := auth.Authenticate(c.Request.Context(), authenticator, token)
+		if err != nil {
+			if apierror.Is(err, apierror.CodeUnauthenticated) {
+				c.Header("WWW-Authenticate", `Bearer realm="envoy-ai-gateway-console"`)
+			}
+			server.AbortWithError(c, err)
+			return
+		}
+
+		ctx := auth.WithUser(c.Request.Context(), user)
+		ctx = logr.NewContext(ctx, logr.FromContextOrDiscard(ctx).WithValues("user", user.Name))
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// requestIDMiddleware assigns every request an ID, reusing the one sent by the client if present,
+// and echoes it in the response headers
+func requestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(requestid.Header)
+		if id == "" {
+			id = requestid.New()
+		}
+
+		c.Request = c.Request.WithContext(requestid.NewContext(c.Request.Context(), id))
+		c.Header(requestid.Header, id)
+
+		c.Next()
+	}
+}
+
+// tracingMiddleware starts a span per request named after its route, continuing the
+// W3C trace context of the caller. Probes and metric scrapes are not traced.
+func tracingMiddleware() gin.HandlerFunc {
+	return otelgin.Middleware(tracing.ServiceName, otelgin.WithFilter(func(r *http.Request) bool {
+		return r.URL.Path != "/livez" && r.URL.Path != "/readyz" && r.URL.Path != "/health" && r.URL.Path != "/metrics"
+	}))
+}
+
+// loggerMiddleware stores a logger with the request ID, method and path in the request
+// context, and logs every handled request at debug level. Requests that are part of
+// a trace also log its trace ID.
+func loggerMiddleware(logger logr.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		requestLogger := logger.WithValues("requestID", requestid.FromContext(ctx), "method", c.Request.Method, "path", c.Request.URL.Path)
+		if spanContext := trace.SpanContextFromContext(ctx); spanContext.HasTraceID() {
+			requestLogger = requestLogger.WithValues("traceID", spanContext.TraceID().String())
+		}
+		c.Request = c.Request.WithContext(logr.NewContext(ctx, requestLogger))
+
+		start := time.Now()
+		c.Next()
+		requestLogger.V(1).Info("Handled request", "status", c.Writer.Status(), "duration", time.Since(start))
+	}
+}
+
+// metricsMiddleware counts and times every request by its route template, so paths
+// with names do not create a series per resource
+func metricsMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		metrics.HTTPRequests.WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).Inc()
+		metrics.HTTPRequestDuration.WithLabelValues(c.Request.Method, route).Observe(time.Since(start).Seconds())
+	}
+}
+
+// recoveryHandler turns a panic into an internal error response
+func recoveryHandler(c *gin.Context, recovered any) {
+	server.AbortWithError(c, apierror.Wrap(apierror.CodeInternal, fmt.Errorf("panic: %v", recovered), "internal server error"))
+}
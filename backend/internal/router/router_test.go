@@ -0,0 +1,497 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	aigatewayv1alpha1 "github.com/envoyproxy/ai-gateway/api/v1alpha1"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/audit"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/auth"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/authz"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/openapi"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/requestid"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/server"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/tracing"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/client"
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/gin-gonic/gin"
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	k8stesting "k8s.io/client-go/testing"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// TestRoutesMatchOpenAPISpec fails when a route is added to the router without
+// documenting it in server.Endpoints, or the other way around.
+func TestRoutesMatchOpenAPISpec(t *testing.T) {
+	rt := NewRouter(&server.Server{})
+
+	var routes []string
+	for _, route := range rt.Routes() {
+		routes = append(routes, route.Method+" "+openapi.OpenAPIPath(route.Path))
+	}
+
+	doc, err := server.OpenAPISpec()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, routes, doc.Operations())
+}
+
+func TestOpenAPISpecEndpoint(t *testing.T) {
+	rt := NewRouter(&server.Server{})
+
+	rec := httptest.NewRecorder()
+	rt.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/openapi.json", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var doc openapi.Document
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+	assert.Equal(t, openapi.Version, doc.OpenAPI)
+
+	// Schemas are derived from the Go model so every JSON field is documented
+	provider := doc.Components.Schemas["LLMProvider"]
+	require.NotNil(t, provider)
+	for _, field := range []string{"name", "namespace", "schema", "auth", "backend", "tls"} {
+		assert.Contains(t, provider.Properties, field)
+		assert.Contains(t, provider.Required, field)
+	}
+	assert.Equal(t, "#/components/schemas/AuthConfig", provider.Properties["auth"].Ref)
+	assert.NotContains(t, provider.Required, "version")
+}
+
+func TestAPIDocsSwaggerUIAssets(t *testing.T) {
+	docs := func(srv *server.Server) string {
+		rec := httptest.NewRecorder()
+		NewRouter(srv).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/docs", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		return rec.Body.String()
+	}
+
+	page := docs(&server.Server{})
+	assert.Contains(t, page, `src="`+server.DefaultSwaggerUIURL+`/swagger-ui-bundle.js"`)
+	assert.NotContains(t, page, "swagger-ui-dist@5/", "the release is pinned")
+
+	srv, err := server.NewServerWithManager(server.Config{
+		Features:     server.Features{APIDocs: true},
+		SwaggerUIURL: "/static/swagger-ui/",
+	}, newTestManager(t))
+	require.NoError(t, err)
+	page = docs(srv)
+	assert.Contains(t, page, `href="/static/swagger-ui/swagger-ui.css"`)
+	assert.NotContains(t, page, "unpkg.com")
+}
+
+func TestCORSMiddleware(t *testing.T) {
+	cors := func(origins []string, origin string) http.Header {
+		rt := gin.New()
+		rt.Use(corsMiddleware(origins))
+		rt.GET("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Origin", origin)
+		rec := httptest.NewRecorder()
+		rt.ServeHTTP(rec, req)
+		return rec.Header()
+	}
+
+	assert.Empty(t, cors([]string{"*"}, "https://any.example.com").Get("Access-Control-Allow-Origin"), "there is no wildcard")
+
+	allowed := cors([]string{"https://console.example.com"}, "https://console.example.com")
+	assert.Equal(t, "https://console.example.com", allowed.Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "Origin", allowed.Get("Vary"))
+
+	assert.Empty(t, cors([]string{"https://console.example.com"}, "https://evil.example.com").Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, cors(nil, "https://console.example.com").Get("Access-Control-Allow-Origin"))
+}
+
+func TestRequestLogger(t *testing.T) {
+	var lines []string
+	logger := funcr.New(func(prefix, args string) { lines = append(lines, args) }, funcr.Options{Verbosity: 1})
+
+	rt := gin.New()
+	rt.Use(requestIDMiddleware(), loggerMiddleware(logger))
+	rt.GET("/", func(c *gin.Context) {
+		logr.FromContextOrDiscard(c.Request.Context()).Info("handling")
+		c.Status(http.StatusNoContent)
+	})
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(requestid.Header, "req-1")
+	rt.ServeHTTP(httptest.NewRecorder(), req)
+
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], `"requestID"="req-1"`)
+	assert.Contains(t, lines[0], `"msg"="handling"`)
+	assert.Contains(t, lines[1], `"status"=204`)
+}
+
+func TestMetricsEndpoint(t *testing.T) {
+	rt := NewRouter(&server.Server{})
+
+	rt.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/llm/providers/openai", nil))
+	rt.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/no/such/route", nil))
+
+	rec := httptest.NewRecorder()
+	rt.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	body := rec.Body.String()
+	// Requests are labeled with the route template rather than the path
+	assert.Contains(t, body, `console_http_requests_total{method="GET",route="/api/v1/llm/providers/:name",status="401"}`)
+	assert.Contains(t, body, `console_http_requests_total{method="GET",route="unmatched",status="404"}`)
+	assert.Contains(t, body, "console_http_request_duration_seconds_bucket")
+	assert.Contains(t, body, "console_provider_translation_failures_total")
+	assert.Contains(t, body, "go_goroutines")
+}
+
+func TestTraceContextPropagation(t *testing.T) {
+	_, err := tracing.Setup(context.Background(), tracing.Config{Exporter: tracing.ExporterNone}, logr.Discard())
+	require.NoError(t, err)
+
+	var lines []string
+	logger := funcr.New(func(prefix, args string) { lines = append(lines, args) }, funcr.Options{})
+
+	rt := gin.New()
+	rt.Use(tracingMiddleware(), requestIDMiddleware(), loggerMiddleware(logger))
+	rt.GET("/", func(c *gin.Context) {
+		logr.FromContextOrDiscard(c.Request.Context()).Info("handling")
+		c.Status(http.StatusNoContent)
+	})
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	rt.ServeHTTP(httptest.NewRecorder(), req)
+
+	require.Len(t, lines, 1)
+	assert.Contains(t, lines[0], `"traceID"="4bf92f3577b34da6a3ce929d0e0e4736"`)
+}
+
+// newTestManager returns a client manager backed by an empty fake client
+func newTestManager(t *testing.T) *client.Manager {
+	t.Helper()
+	scheme, err := client.NewScheme()
+	require.NoError(t, err)
+	return client.NewManagerWithClient(fake.NewClientBuilder().WithScheme(scheme).Build(), logr.Discard())
+}
+
+// servedKinds serves every kind the console requires, BackendTLSPolicy in the Gateway API version
+func servedKinds(backendTLSPolicyVersion string) *fakediscovery.FakeDiscovery {
+	var resources []*metav1.APIResourceList
+	for groupVersion, kinds := range map[string][]string{
+		"aigateway.envoyproxy.io/v1alpha1":                     {"AIServiceBackend", "BackendSecurityPolicy", "AIGatewayRoute"},
+		"gateway.envoyproxy.io/v1alpha1":                       {"Backend", "BackendTrafficPolicy"},
+		"gateway.networking.k8s.io/" + backendTLSPolicyVersion: {"BackendTLSPolicy"},
+	} {
+		list := &metav1.APIResourceList{GroupVersion: groupVersion}
+		for _, kind := range kinds {
+			list.APIResources = append(list.APIResources, metav1.APIResource{Name: strings.ToLower(kind) + "s", Kind: kind})
+		}
+		resources = append(resources, list)
+	}
+	return &fakediscovery.FakeDiscovery{Fake: &k8stesting.Fake{Resources: resources}}
+}
+
+// newTestServer returns a server that is closed when the test ends
+func newTestServer(t *testing.T, cfg server.Config, manager client.ManagerInterface) *server.Server {
+	t.Helper()
+	srv, err := server.NewServerWithManager(cfg, manager)
+	require.NoError(t, err)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestProbes(t *testing.T) {
+	manager := newTestManager(t)
+
+	// Without discovery the console starts degraded
+	rt := NewRouter(newTestServer(t, server.Config{}, manager))
+
+	rec := httptest.NewRecorder()
+	rt.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/livez", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	rt.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	var health server.HealthResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &health))
+	assert.Equal(t, "unavailable", health.Status)
+	require.NotEmpty(t, health.Checks)
+	assert.Equal(t, server.CheckKubernetes, health.Checks[0].Name)
+	assert.False(t, health.Checks[0].OK)
+
+	// Readiness is checked in the background once every required CRD is served
+	manager.SetDiscovery(servedKinds("v1alpha3"))
+	rt = NewRouter(newTestServer(t, server.Config{}, manager))
+	assert.Eventually(t, func() bool {
+		rec := httptest.NewRecorder()
+		rt.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		return rec.Code == http.StatusOK
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The probe of earlier releases answers as /readyz and points to it
+	rec = httptest.NewRecorder()
+	rt.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "true", rec.Header().Get("Deprecation"))
+	assert.Equal(t, `</readyz>; rel="successor-version"`, rec.Header().Get("Link"))
+}
+
+func TestServerSelectsAPIVersionsBeforeServing(t *testing.T) {
+	manager := newTestManager(t)
+	manager.SetDiscovery(servedKinds("v1"))
+	newTestServer(t, server.Config{}, manager)
+
+	// Requests right after startup write the versions the cluster serves
+	assert.Equal(t, "v1", manager.APIVersions().BackendTLSPolicy)
+}
+
+func TestGetDiagnostics(t *testing.T) {
+	scheme, err := client.NewScheme()
+	require.NoError(t, err)
+	controller := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: client.DefaultControllers[0].Namespace, Name: client.DefaultControllers[0].Deployment},
+		Status:     appsv1.DeploymentStatus{ReadyReplicas: 1},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(controller).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c ctrlclient.WithWatch, obj ctrlclient.Object, opts ...ctrlclient.CreateOption) error {
+			review := obj.(*authorizationv1.SelfSubjectAccessReview)
+			attributes := review.Spec.ResourceAttributes
+			review.Status.Allowed = attributes.Resource != "secrets" || attributes.Verb != "delete"
+			return nil
+		},
+	}).Build()
+	manager := client.NewManagerWithClient(k8sClient, logr.Discard())
+	discovery := servedKinds("v1alpha3")
+	manager.SetDiscovery(discovery)
+	srv := newTestServer(t, server.Config{AllowedNamespaces: []string{"team-a"}}, manager)
+
+	diagnostics := func() server.Diagnostics {
+		rec := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(rec)
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/diagnostics", nil)
+		srv.GetDiagnostics(c)
+		require.Equal(t, http.StatusOK, rec.Code)
+		var report server.Diagnostics
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+		return report
+	}
+
+	// The checks are those of the background loop, which waits before checking again once ready
+	assert.Eventually(t, func() bool { return diagnostics().Ready }, 5*time.Second, 10*time.Millisecond)
+	calls := len(discovery.Actions())
+	report := diagnostics()
+	assert.Len(t, discovery.Actions(), calls, "diagnostics do not repeat the discovery of the checks")
+	require.NotNil(t, report.CheckedAt)
+	assert.WithinDuration(t, time.Now(), *report.CheckedAt, 5*time.Second)
+	assert.Empty(t, report.Errors)
+	for _, crd := range report.CRDs {
+		assert.True(t, crd.Required, crd.Kind)
+		assert.NotEmpty(t, crd.Versions, crd.Kind)
+	}
+	assert.Equal(t, []client.AccessCheck{
+		{Resource: "secrets", Verb: "delete", Namespace: "team-a"},
+	}, report.MissingPermissions)
+	require.Len(t, report.Controllers, len(client.DefaultControllers))
+	assert.True(t, report.Controllers[0].Present)
+	assert.Equal(t, int32(1), report.Controllers[0].ReadyReplicas)
+	assert.False(t, report.Controllers[1].Present)
+}
+
+// newPolicyRouter returns a router authenticating the static tokens and authorizing the role
+// bindings of the policy
+func newPolicyRouter(t *testing.T, manager client.ManagerInterface, features server.Features, tokens, policy string) *gin.Engine {
+	t.Helper()
+	dir := t.TempDir()
+	tokensFile, policyFile := filepath.Join(dir, "tokens.csv"), filepath.Join(dir, "policy.yaml")
+	require.NoError(t, os.WriteFile(tokensFile, []byte(tokens), 0o600))
+	require.NoError(t, os.WriteFile(policyFile, []byte(policy), 0o600))
+	return NewRouter(newTestServer(t, server.Config{
+		Auth:     auth.Config{Modes: []string{auth.MethodStatic}, StaticTokensFile: tokensFile},
+		Authz:    authz.Config{PolicyFile: policyFile},
+		Features: features,
+	}, manager))
+}
+
+// serve sends the request with the bearer token
+func serve(rt http.Handler, method, target, token, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	rec := httptest.NewRecorder()
+	rt.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestSingleResourceRoutesRejectNamespaceLists(t *testing.T) {
+	rt := newPolicyRouter(t, newTestManager(t), server.Features{}, "admin-token,admin,admin\n",
+		"bindings:\n  - role: admin\n    users: [admin]\n    namespaces: [\"*\"]\n")
+
+	for _, target := range []string{
+		"/api/v1/llm/providers/openai?namespace=team-a,team-b",
+		"/api/v1/llm/providers/openai?namespace=team-a&namespace=team-b",
+		"/api/v1/llm/providers/openai?namespace=*",
+		"/api/v1/llm/providers/openai/manifests?namespace=team-a,team-b",
+		"/api/v1/llm/ratelimits/limits?namespace=team-a,team-b",
+		"/api/v1/llm/routes/chat/rules/0/split?namespace=team-a,team-b",
+	} {
+		rec := serve(rt, http.MethodGet, target, "admin-token", "")
+		assert.Equal(t, http.StatusBadRequest, rec.Code, "GET %s: %s", target, rec.Body.String())
+	}
+	rec := serve(rt, http.MethodDelete, "/api/v1/llm/providers/openai?namespace=team-a,team-b", "admin-token", "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+
+	// A single namespace reaches Kubernetes
+	rec = serve(rt, http.MethodGet, "/api/v1/llm/providers/openai?namespace=team-a", "admin-token", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code, rec.Body.String())
+}
+
+func TestCreateWritesToTheAuthorizedNamespace(t *testing.T) {
+	scheme, err := client.NewScheme()
+	require.NoError(t, err)
+	builder := fake.NewClientBuilder().WithScheme(scheme)
+	for _, namespace := range []string{"default", "team-a"} {
+		builder.WithObjects(&aigatewayv1alpha1.AIGatewayRoute{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "chat"}})
+	}
+	k8sClient := builder.Build()
+	rt := newPolicyRouter(t, client.NewManagerWithClient(k8sClient, logr.Discard()), server.Features{}, "editor-token,editor,editor\n",
+		"bindings:\n  - role: editor\n    users: [editor]\n    namespaces: [team-a]\n")
+
+	for _, tc := range []struct {
+		name, path, body string
+		list             ctrlclient.ObjectList
+	}{
+		{
+			name: "provider", path: "/api/v1/llm/providers",
+			body: `{"name":"openai","schema":"OpenAI","auth":{"type":"APIKey","apiKey":"sk-test"},` +
+				`"backend":{"host":"api.openai.com","port":443},"tls":{"hostname":"api.openai.com","wellKnownCACertificates":"System"}}`,
+			list: &aigatewayv1alpha1.AIServiceBackendList{},
+		},
+		{
+			name: "rate limit policy", path: "/api/v1/llm/ratelimits",
+			body: `{"name":"budget","route":"chat","rules":[{"tokenType":"total","limit":1000,"unit":"Minute"}]}`,
+			list: &egv1a1.BackendTrafficPolicyList{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			count := func(namespace string) int {
+				require.NoError(t, k8sClient.List(context.Background(), tc.list, ctrlclient.InNamespace(namespace)))
+				return apimeta.LenList(tc.list)
+			}
+
+			// The body names no namespace, the default namespace is not the one authorized
+			rec := serve(rt, http.MethodPost, tc.path+"?namespace=team-a", "editor-token", tc.body)
+			require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+			assert.Equal(t, 1, count("team-a"))
+			assert.Zero(t, count("default"))
+
+			aimed := strings.Replace(tc.body, "{", `{"namespace":"default",`, 1)
+			rec = serve(rt, http.MethodPost, tc.path+"?namespace=team-a", "editor-token", aimed)
+			assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+			rec = serve(rt, http.MethodPost, tc.path, "editor-token", tc.body)
+			assert.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+			assert.Zero(t, count("default"))
+		})
+	}
+}
+
+func TestCredentialRotationAndRollbackRequireIfMatch(t *testing.T) {
+	scheme, err := client.NewScheme()
+	require.NoError(t, err)
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(&aigatewayv1alpha1.AIGatewayRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "chat"}}).Build()
+	rt := newPolicyRouter(t, client.NewManagerWithClient(k8sClient, logr.Discard()), server.Features{Revisions: true},
+		"admin-token,admin,admin\n", "bindings:\n  - role: admin\n    users: [admin]\n    namespaces: [\"*\"]\n")
+
+	rec := serve(rt, http.MethodPost, "/api/v1/llm/providers", "admin-token",
+		`{"name":"openai","schema":"OpenAI","auth":{"type":"APIKey","apiKey":"sk-test"},`+
+			`"backend":{"host":"api.openai.com","port":443},"tls":{"hostname":"api.openai.com","wellKnownCACertificates":"System"}}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	rec = serve(rt, http.MethodGet, "/api/v1/llm/providers/openai", "admin-token", "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	etag := rec.Header().Get(server.HeaderETag)
+	require.NotEmpty(t, etag)
+
+	// send is serve with an If-Match header, none when ifMatch is empty
+	send := func(method, target, ifMatch, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer admin-token")
+		req.Header.Set("Content-Type", "application/json")
+		if ifMatch != "" {
+			req.Header.Set(server.HeaderIfMatch, ifMatch)
+		}
+		rec := httptest.NewRecorder()
+		rt.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for _, tc := range []struct {
+		name, method, target, body string
+	}{
+		{name: "rotate credentials", method: http.MethodPut, target: "/api/v1/llm/providers/openai/credentials", body: `{"type":"APIKey","apiKey":"sk-new"}`},
+		{name: "rollback", method: http.MethodPost, target: "/api/v1/llm/providers/openai/revisions/1:rollback", body: `{}`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := send(tc.method, tc.target, "", tc.body)
+			assert.Equal(t, http.StatusPreconditionRequired, rec.Code, rec.Body.String())
+			rec = send(tc.method, tc.target, `"stale"`, tc.body)
+			assert.Equal(t, http.StatusPreconditionFailed, rec.Code, rec.Body.String())
+
+			rec = send(tc.method, tc.target, etag, tc.body)
+			require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+			assert.NotEqual(t, etag, rec.Header().Get(server.HeaderETag))
+			etag = rec.Header().Get(server.HeaderETag)
+		})
+	}
+}
+
+func TestCloseFlushesTheAuditLog(t *testing.T) {
+	scheme, err := client.NewScheme()
+	require.NoError(t, err)
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(&aigatewayv1alpha1.AIGatewayRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "chat"}}).Build()
+	dir := t.TempDir()
+	tokensFile, auditFile := filepath.Join(dir, "tokens.csv"), filepath.Join(dir, "audit.jsonl")
+	require.NoError(t, os.WriteFile(tokensFile, []byte("admin-token,admin,admin\n"), 0o600))
+	srv := newTestServer(t, server.Config{
+		Auth:  auth.Config{Modes: []string{auth.MethodStatic}, StaticTokensFile: tokensFile},
+		Authz: authz.Config{AllowAll: true},
+		Audit: audit.Config{Sinks: []string{audit.SinkFile}, File: auditFile},
+	}, client.NewManagerWithClient(k8sClient, logr.Discard()))
+	rt := NewRouter(srv)
+
+	// The provider is created right before shutdown
+	rec := serve(rt, http.MethodPost, "/api/v1/llm/providers", "admin-token",
+		`{"name":"openai","schema":"OpenAI","auth":{"type":"APIKey","apiKey":"sk-test"},`+
+			`"backend":{"host":"api.openai.com","port":443},"tls":{"hostname":"api.openai.com","wellKnownCACertificates":"System"}}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	srv.Close()
+
+	store, err := audit.NewFileStore(auditFile)
+	require.NoError(t, err)
+	defer store.Close()
+	events, err := store.Query(context.Background(), audit.Query{})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, audit.ActionCreate, events[0].Action)
+	assert.Equal(t, "openai", events[0].Provider)
+}
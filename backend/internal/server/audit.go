@@ -0,0 +1,80 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package server
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/apierror"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/audit"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/client"
+	"github.com/gin-gonic/gin"
+)
+
+// AuditEventList is the body of GET /api/v1/audit
+type AuditEventList struct {
+	Items []audit.Event `json:"items"`
+}
+
+// GetAuditEvents handles GET /api/v1/audit with Gin
+func (s *Server) GetAuditEvents(c *gin.Context) {
+	if s.auditor == nil {
+		AbortWithError(c, apierror.New(apierror.CodeUnavailable, "audit log is not configured"))
+		return
+	}
+
+	query, err := parseAuditQuery(c.Request.URL.Query(), s.DefaultNamespace())
+	if err != nil {
+		AbortWithError(c, err)
+		return
+	}
+
+	events, err := s.auditor.Query(c.Request.Context(), query)
+	if err != nil {
+		AbortWithError(c, apierror.Wrap(apierror.CodeInternal, err, "failed to query audit log"))
+		return
+	}
+
+	c.JSON(http.StatusOK, AuditEventList{Items: events})
+}
+
+// parseAuditQuery builds the audit query from the request query
+func parseAuditQuery(values url.Values, defaultNamespace string) (audit.Query, error) {
+	query := audit.Query{
+		Namespaces: client.ParseNamespaces(values["namespace"]...),
+		Kind:       audit.Kind(values.Get("kind")),
+		Provider:   values.Get("provider"),
+		Actor:      values.Get("actor"),
+		Action:     audit.Action(values.Get("action")),
+		Outcome:    audit.Outcome(values.Get("outcome")),
+	}
+	if len(query.Namespaces) == 0 {
+		query.Namespaces = []string{defaultNamespace}
+	}
+
+	for name, target := range map[string]*time.Time{"since": &query.Since, "until": &query.Until} {
+		if value := values.Get(name); value != "" {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return query, apierror.Invalid("invalid %s %q: must be an RFC 3339 timestamp", name, value)
+			}
+			*target = parsed
+		}
+	}
+
+	if limit := values.Get("limit"); limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil || parsed < 0 {
+			return query, apierror.Invalid("invalid limit %q: must be a positive number", limit)
+		}
+		query.Limit = parsed
+	}
+
+	return query, nil
+}
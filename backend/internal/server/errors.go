@@ -0,0 +1,35 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package server
+
+import (
+	"net/http"
+
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/apierror"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/requestid"
+	"github.com/gin-gonic/gin"
+	"github.com/go-logr/logr"
+)
+
+// WriteError writes an error as a problem details response.
+// The full error including its cause is logged with the request logger, only the safe
+// message is returned. Server errors are logged as errors, client errors at debug level.
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	problem := apierror.NewProblem(err, r.URL.Path, requestid.FromContext(r.Context()))
+	logger := logr.FromContextOrDiscard(r.Context())
+	if problem.Status >= http.StatusInternalServerError {
+		logger.Error(err, "Request failed", "status", problem.Status)
+	} else {
+		logger.V(1).Info("Request rejected", "status", problem.Status, "reason", err.Error())
+	}
+	problem.Write(w)
+}
+
+// AbortWithError writes an error as a problem details response and aborts the Gin chain
+func AbortWithError(c *gin.Context, err error) {
+	WriteError(c.Writer, c.Request, err)
+	c.Abort()
+}
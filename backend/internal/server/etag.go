@@ -0,0 +1,44 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package server
+
+import (
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/apierror"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// HeaderETag carries the version of a provider in responses
+	HeaderETag = "ETag"
+	// HeaderIfMatch carries the version of a provider a mutation was based on
+	HeaderIfMatch = "If-Match"
+)
+
+// requireIfMatch returns the If-Match header, failing with 428 when it is missing
+// so that clients cannot overwrite changes they have not seen
+func requireIfMatch(c *gin.Context) (string, error) {
+	ifMatch := c.GetHeader(HeaderIfMatch)
+	if ifMatch == "" {
+		return "", apierror.New(apierror.CodePreconditionRequired, "the %s header is required, send the ETag of the resource as last read", HeaderIfMatch)
+	}
+	return ifMatch, nil
+}
+
+// setProviderETag sets the ETag header to the current version of the provider.
+// It is best effort: the mutation already succeeded when this runs.
+func (s *Server) setProviderETag(c *gin.Context, namespace, name string) {
+	if _, etag, err := s.llmProviderService.GetProvider(c.Request.Context(), namespace, name); err == nil {
+		c.Header(HeaderETag, etag)
+	}
+}
+
+// setRateLimitETag sets the ETag header to the current version of the rate limit policy.
+// It is best effort: the mutation already succeeded when this runs.
+func (s *Server) setRateLimitETag(c *gin.Context, namespace, name string) {
+	if _, etag, err := s.llmProviderService.GetRateLimitPolicy(c.Request.Context(), namespace, name); err == nil {
+		c.Header(HeaderETag, etag)
+	}
+}
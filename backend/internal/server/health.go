@@ -0,0 +1,362 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/client"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/llm"
+	"github.com/gin-gonic/gin"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// readinessRetryInterval is the time between checks while the console is not ready
+	readinessRetryInterval = 5 * time.Second
+	// readinessInterval is the time between checks once the console is ready
+	readinessInterval = 30 * time.Second
+	// healthCheckTimeout bounds a round of checks
+	healthCheckTimeout = 5 * time.Second
+)
+
+// Names of the readiness checks
+const (
+	CheckKubernetes = "kubernetes"
+	CheckCRDs       = "crds"
+	CheckDiscovery  = "discovery"
+)
+
+// requiredKinds are the kinds the console reads and writes for every provider
+var requiredKinds = []schema.GroupKind{
+	{Group: client.GroupAIGateway, Kind: "AIServiceBackend"},
+	{Group: client.GroupAIGateway, Kind: "BackendSecurityPolicy"},
+	{Group: client.GroupAIGateway, Kind: "AIGatewayRoute"},
+	{Group: client.GroupEnvoyGateway, Kind: "Backend"},
+	{Group: client.GroupEnvoyGateway, Kind: "BackendTrafficPolicy"},
+	{Group: client.GroupGatewayAPI, Kind: "BackendTLSPolicy"},
+}
+
+// managedResources are the resources the console needs every verb of
+var managedResources = []schema.GroupResource{
+	{Group: client.GroupAIGateway, Resource: "aiservicebackends"},
+	{Group: client.GroupAIGateway, Resource: "backendsecuritypolicies"},
+	{Group: client.GroupAIGateway, Resource: "aigatewayroutes"},
+	{Group: client.GroupEnvoyGateway, Resource: "backends"},
+	{Group: client.GroupEnvoyGateway, Resource: "backendtrafficpolicies"},
+	{Group: client.GroupGatewayAPI, Resource: "backendtlspolicies"},
+	{Resource: "secrets"},
+	{Resource: "configmaps"},
+}
+
+var managedVerbs = []string{"get", "list", "create", "update", "delete"}
+
+// CheckResult is the outcome of a readiness check
+type CheckResult struct {
+	Name    string `json:"name"`
+	OK      bool   `json:"ok"`
+	Message string `json:"message,omitempty"`
+}
+
+// HealthResponse is the body of GET /livez, GET /readyz and the deprecated GET /health
+type HealthResponse struct {
+	Status string        `json:"status"`
+	Checks []CheckResult `json:"checks,omitempty"`
+}
+
+// health checks in the background whether the console can serve requests, so the
+// server starts while Kubernetes is unreachable and becomes ready once it is
+type health struct {
+	clients client.ManagerInterface
+	logger  logr.Logger
+
+	mu     sync.RWMutex
+	checks []CheckResult
+	ready  bool
+	kinds  client.ServedKinds
+	// checkedAt is the time of the last round of checks, zero before the first
+	checkedAt time.Time
+}
+
+func newHealth(clients client.ManagerInterface, logger logr.Logger) *health {
+	return &health{
+		clients: clients,
+		logger:  logger,
+		checks: []CheckResult{
+			{Name: CheckKubernetes, Message: "not checked yet"},
+			{Name: CheckCRDs, Message: "not checked yet"},
+			{Name: CheckDiscovery, Message: "not checked yet"},
+		},
+	}
+}
+
+// run checks until the context is done, retrying sooner while not ready
+func (h *health) run(ctx context.Context) {
+	for {
+		interval := readinessInterval
+		if !h.check(ctx) {
+			interval = readinessRetryInterval
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+// discoverAPIVersions selects the API versions of the kinds served by the cluster, so requests
+// write the served versions from the start. It gives up after timeout to start with the
+// defaults while the cluster is unreachable; the readiness checks refresh the versions.
+func (h *health) discoverAPIVersions(timeout time.Duration) {
+	done := make(chan error, 1)
+	go func() {
+		kinds, err := h.clients.DiscoverKinds(context.Background())
+		if err != nil {
+			done <- err
+			return
+		}
+		versions, err := kinds.APIVersions()
+		if err != nil {
+			done <- err
+			return
+		}
+		done <- h.clients.SetAPIVersions(versions)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			h.logger.Info("Failed to discover the served API versions, using the defaults until the next check", "reason", err.Error())
+		}
+	case <-time.After(timeout):
+		h.logger.Info("Discovery of the served API versions timed out, using the defaults until the next check", "timeout", timeout)
+	}
+}
+
+// check runs every readiness check, records the results and reports whether all passed
+func (h *health) check(ctx context.Context) bool {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	kubernetes := CheckResult{Name: CheckKubernetes, OK: true}
+	if version, err := h.clients.ServerVersion(ctx); err != nil {
+		kubernetes.OK, kubernetes.Message = false, err.Error()
+	} else {
+		kubernetes.Message = "Kubernetes " + version
+	}
+
+	h.mu.RLock()
+	kinds := h.kinds
+	h.mu.RUnlock()
+
+	discovery := CheckResult{Name: CheckDiscovery, OK: true, Message: "served API versions discovered"}
+	if kubernetes.OK {
+		discovered, err := h.clients.DiscoverKinds(ctx)
+		if err != nil {
+			discovery.OK, discovery.Message = kinds != nil, err.Error()
+		} else {
+			kinds = discovered
+		}
+	}
+	if kinds == nil && discovery.OK {
+		discovery.OK, discovery.Message = false, "served API versions not discovered yet"
+	}
+
+	crds := CheckResult{Name: CheckCRDs, OK: kinds != nil}
+	if kinds != nil {
+		var missing []string
+		for _, groupKind := range requiredKinds {
+			if len(kinds.Versions(groupKind)) == 0 {
+				missing = append(missing, groupKind.String())
+			}
+		}
+		if len(missing) > 0 {
+			crds.OK, crds.Message = false, "missing CRDs: "+strings.Join(missing, ", ")
+		} else if versions, err := kinds.APIVersions(); err != nil {
+			crds.OK, crds.Message = false, err.Error()
+		} else if err := h.clients.SetAPIVersions(versions); err != nil {
+			crds.OK, crds.Message = false, err.Error()
+		} else {
+			crds.Message = fmt.Sprintf("BackendTLSPolicy %s, AI Gateway API %s", versions.BackendTLSPolicy, versions.AIGateway)
+		}
+	} else {
+		crds.Message = "served API versions not discovered yet"
+	}
+
+	checks := []CheckResult{kubernetes, crds, discovery}
+	ready := kubernetes.OK && crds.OK && discovery.OK
+
+	h.mu.Lock()
+	wasReady, wasChecked := h.ready, !h.checkedAt.IsZero()
+	h.checks, h.ready, h.kinds, h.checkedAt = checks, ready, kinds, time.Now()
+	h.mu.Unlock()
+
+	if ready {
+		if !wasReady {
+			h.logger.Info("Console is ready")
+		}
+		return true
+	}
+	// Failures are logged when the console becomes unready, then only at debug level
+	logger := h.logger
+	if wasChecked && !wasReady {
+		logger = logger.V(1)
+	}
+	for _, check := range checks {
+		if !check.OK {
+			logger.Info("Console is not ready, retrying", "check", check.Name, "reason", check.Message, "retryIn", readinessRetryInterval)
+		}
+	}
+	return false
+}
+
+// status returns the results of the last checks and when they ran
+func (h *health) status() (ready bool, checks []CheckResult, kinds client.ServedKinds, checkedAt time.Time) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.ready, slices.Clone(h.checks), h.kinds, h.checkedAt
+}
+
+// Livez handles GET /livez, which only reports that the process serves requests
+func (s *Server) Livez(c *gin.Context) {
+	c.JSON(http.StatusOK, HealthResponse{Status: "ok"})
+}
+
+// Readyz handles GET /readyz with the results of the last background checks
+func (s *Server) Readyz(c *gin.Context) {
+	if s.health == nil {
+		c.JSON(http.StatusServiceUnavailable, HealthResponse{Status: "unavailable"})
+		return
+	}
+	ready, checks, _, _ := s.health.status()
+	if !ready {
+		c.JSON(http.StatusServiceUnavailable, HealthResponse{Status: "unavailable", Checks: checks})
+		return
+	}
+	c.JSON(http.StatusOK, HealthResponse{Status: "ok", Checks: checks})
+}
+
+// Health handles GET /health, the probe of earlier releases. It is a deprecated alias of
+// GET /readyz, which the response points to.
+func (s *Server) Health(c *gin.Context) {
+	c.Header("Deprecation", "true")
+	c.Header("Link", `</readyz>; rel="successor-version"`)
+	s.Readyz(c)
+}
+
+// CRDStatus reports the served versions of a kind
+type CRDStatus struct {
+	Group    string   `json:"group"`
+	Kind     string   `json:"kind"`
+	Versions []string `json:"versions"`
+	Required bool     `json:"required"`
+}
+
+// Diagnostics is the body of GET /api/v1/diagnostics
+type Diagnostics struct {
+	Ready  bool          `json:"ready"`
+	Checks []CheckResult `json:"checks"`
+	// CheckedAt is the time of the background checks reported, unset before the first
+	CheckedAt *time.Time `json:"checkedAt,omitempty"`
+	// CRDs lists the AI Gateway, Envoy Gateway and Gateway API kinds, installed or required
+	CRDs []CRDStatus `json:"crds"`
+	// APIVersions are the versions providers are written in, selected among the served versions
+	APIVersions llm.APIVersions `json:"apiVersions"`
+	// MissingPermissions are the verbs the console is not allowed to use
+	MissingPermissions []client.AccessCheck      `json:"missingPermissions"`
+	Controllers        []client.ControllerStatus `json:"controllers"`
+	// Errors reports the parts of the diagnostics that could not be collected
+	Errors []string `json:"errors,omitempty"`
+}
+
+// GetDiagnostics handles GET /api/v1/diagnostics with Gin.
+// Permissions are those of the console's own identity, in the allowed namespaces or cluster-wide.
+func (s *Server) GetDiagnostics(c *gin.Context) {
+	ctx := c.Request.Context()
+	report := Diagnostics{MissingPermissions: []client.AccessCheck{}, Controllers: []client.ControllerStatus{}}
+	if s.health == nil {
+		report.Errors = append(report.Errors, "the console is not connected to Kubernetes")
+		c.JSON(http.StatusOK, report)
+		return
+	}
+
+	// The checks are those of the background loop, so requests do not repeat their
+	// round-trips to the API server
+	var kinds client.ServedKinds
+	var checkedAt time.Time
+	report.Ready, report.Checks, kinds, checkedAt = s.health.status()
+	if !checkedAt.IsZero() {
+		report.CheckedAt = &checkedAt
+	}
+	report.CRDs = crdStatuses(kinds)
+	report.APIVersions = s.clientManager.APIVersions()
+
+	namespaces := s.allowedNamespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{""}
+	}
+	checks := []client.AccessCheck{{Resource: "namespaces", Verb: "list"}}
+	for _, namespace := range namespaces {
+		for _, resource := range managedResources {
+			for _, verb := range managedVerbs {
+				checks = append(checks, client.AccessCheck{Group: resource.Group, Resource: resource.Resource, Verb: verb, Namespace: namespace})
+			}
+		}
+	}
+	if results, err := s.clientManager.CheckAccess(ctx, checks); err != nil {
+		logr.FromContextOrDiscard(ctx).Error(err, "Failed to review permissions")
+		report.Errors = append(report.Errors, fmt.Sprintf("failed to review permissions: %v", err))
+	} else {
+		for _, result := range results {
+			if !result.Allowed {
+				report.MissingPermissions = append(report.MissingPermissions, result)
+			}
+		}
+	}
+
+	for _, controller := range client.DefaultControllers {
+		report.Controllers = append(report.Controllers, s.clientManager.GetControllerStatus(ctx, controller))
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// crdStatuses lists the discovered and required kinds sorted by group and kind
+func crdStatuses(kinds client.ServedKinds) []CRDStatus {
+	statuses := map[schema.GroupKind]*CRDStatus{}
+	for groupKind, versions := range kinds {
+		statuses[groupKind] = &CRDStatus{Group: groupKind.Group, Kind: groupKind.Kind, Versions: versions}
+	}
+	for _, groupKind := range requiredKinds {
+		status, ok := statuses[groupKind]
+		if !ok {
+			status = &CRDStatus{Group: groupKind.Group, Kind: groupKind.Kind, Versions: []string{}}
+			statuses[groupKind] = status
+		}
+		status.Required = true
+	}
+
+	result := make([]CRDStatus, 0, len(statuses))
+	for _, status := range statuses {
+		result = append(result, *status)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Group != result[j].Group {
+			return result[i].Group < result[j].Group
+		}
+		return result[i].Kind < result[j].Kind
+	})
+	return result
+}
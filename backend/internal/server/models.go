@@ -0,0 +1,29 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package server
+
+import (
+	"net/http"
+
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/client"
+	"github.com/gin-gonic/gin"
+)
+
+// ListModels handles GET /api/v1/llm/models with Gin
+func (s *Server) ListModels(c *gin.Context) {
+	namespaces := client.ParseNamespaces(c.QueryArray("namespace")...)
+	if len(namespaces) == 0 {
+		namespaces = []string{s.DefaultNamespace()}
+	}
+
+	models, err := s.llmProviderService.ListModels(c.Request.Context(), namespaces)
+	if err != nil {
+		AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models)
+}
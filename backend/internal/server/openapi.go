@@ -0,0 +1,344 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package server
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/apierror"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/audit"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/openapi"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/service"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/llm"
+	"github.com/gin-gonic/gin"
+)
+
+// MessageResponse is the body of operations that only report an outcome
+type MessageResponse struct {
+	Message string `json:"message"`
+}
+
+// ManifestList is the body of GET /api/v1/llm/providers/:name/manifests
+type ManifestList struct {
+	Items []any `json:"items"`
+}
+
+// Endpoints describes every route served by the console API.
+// The router test fails when the registered routes and this list diverge.
+func Endpoints() []openapi.Endpoint {
+	namespace := openapi.Parameter{Name: "namespace", Description: "Namespace of the provider, defaults to default"}
+	etag := []openapi.Parameter{{Name: HeaderETag, Description: "Version of the provider computed from its Kubernetes resources"}}
+	ifMatch := openapi.Parameter{Name: HeaderIfMatch, Description: "ETag of the provider as last read, or * for any version"}
+	requiredIfMatch := ifMatch
+	requiredIfMatch.Required = true
+	routeNamespace := openapi.Parameter{Name: "namespace", Description: "Namespace of the AIGatewayRoute, defaults to default"}
+	rateLimitNamespace := openapi.Parameter{Name: "namespace", Description: "Namespace of the rate limit policy, defaults to default"}
+	rateLimitETag := []openapi.Parameter{{Name: HeaderETag, Description: "Version of the rate limit policy computed from its BackendTrafficPolicy"}}
+	rateLimitIfMatch := openapi.Parameter{Name: HeaderIfMatch, Description: "ETag of the rate limit policy as last read, or * for any version", Required: true}
+
+	return []openapi.Endpoint{
+		{
+			Method: http.MethodGet, Path: "/livez", Public: true,
+			OperationID: "livez", Summary: "Check that the process serves requests", Tag: "system",
+			Response: HealthResponse{},
+		},
+		{
+			Method: http.MethodGet, Path: "/readyz", Public: true,
+			OperationID: "readyz", Summary: "Check that Kubernetes is reachable and the CRDs are installed, 503 otherwise", Tag: "system",
+			Response: HealthResponse{},
+		},
+		{
+			Method: http.MethodGet, Path: "/health", Public: true, Deprecated: true,
+			OperationID: "health", Summary: "Deprecated alias of /readyz", Tag: "system",
+			Response: HealthResponse{},
+		},
+		{
+			Method: http.MethodGet, Path: "/metrics", Public: true,
+			OperationID: "getMetrics", Summary: "Prometheus metrics of the console", Tag: "system",
+			Response: "", ContentType: "text/plain",
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/openapi.json", Public: true,
+			OperationID: "getOpenAPISpec", Summary: "OpenAPI document of this API", Tag: "system",
+			Response: map[string]any{},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/docs", Public: true,
+			OperationID: "getAPIDocs", Summary: "Swagger UI for this API", Tag: "system",
+			Response: "", ContentType: "text/html",
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/llm/providers",
+			OperationID: "listLLMProviders", Summary: "List LLM providers", Tag: "llm",
+			Query: []openapi.Parameter{
+				{Name: "namespace", Description: `Namespace, comma separated namespaces or "*" for all namespaces; defaults to default`},
+				{Name: "limit", Description: "Maximum number of AIServiceBackends to read; filters apply afterwards, so pages may be short or empty while continue is set", Schema: &openapi.Schema{Type: "integer", Format: "int64"}},
+				{Name: "continue", Description: "Continue token returned by the previous page"},
+				{Name: "sort", Schema: &openapi.Schema{Type: "string", Enum: []string{
+					service.SortByName, service.SortBySchema, service.SortByCreationTimestamp, service.SortByStatus,
+				}}},
+				{Name: "order", Schema: &openapi.Schema{Type: "string", Enum: []string{"asc", "desc"}}},
+				{Name: "schema", Description: "Only providers with this API schema"},
+				{Name: "authType", Description: "Only providers with this authentication type"},
+				{Name: "host", Description: "Only providers whose backend host contains this substring"},
+				{Name: "labelSelector", Description: "Kubernetes label selector applied to AIServiceBackends"},
+			},
+			Response: service.ProviderList{},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/llm/providers",
+			OperationID: "createLLMProvider", Summary: "Create an LLM provider", Tag: "llm",
+			Request: llm.LLMProvider{}, Response: llm.LLMProvider{}, Status: http.StatusCreated,
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/llm/providers/:name",
+			OperationID: "getLLMProvider", Summary: "Get an LLM provider", Tag: "llm",
+			Query:    []openapi.Parameter{namespace},
+			Response: llm.LLMProvider{}, ResponseHeaders: etag,
+		},
+		{
+			Method: http.MethodPut, Path: "/api/v1/llm/providers/:name",
+			OperationID: "updateLLMProvider", Summary: "Update an LLM provider, masked credentials are kept", Tag: "llm",
+			Query: []openapi.Parameter{namespace}, Headers: []openapi.Parameter{requiredIfMatch},
+			Request: llm.LLMProvider{}, Response: llm.LLMProvider{}, ResponseHeaders: etag,
+		},
+		{
+			Method: http.MethodDelete, Path: "/api/v1/llm/providers/:name",
+			OperationID: "deleteLLMProvider", Summary: "Delete an LLM provider and its resources", Tag: "llm",
+			Query: []openapi.Parameter{namespace}, Headers: []openapi.Parameter{requiredIfMatch},
+			Response: MessageResponse{},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/llm/providers/:name/manifests",
+			OperationID: "getLLMProviderManifests", Summary: "Get the Kubernetes resources of an LLM provider", Tag: "llm",
+			Query: []openapi.Parameter{namespace, {
+				Name: IncludeSecretsParam, Description: "Reveal secret values, requires the admin role",
+				Schema: &openapi.Schema{Type: "boolean"},
+			}},
+			Response: ManifestList{},
+		},
+		{
+			Method: http.MethodPut, Path: "/api/v1/llm/providers/:name/credentials",
+			OperationID: "rotateLLMProviderCredentials", Summary: "Rotate the credentials of an LLM provider", Tag: "llm",
+			Query: []openapi.Parameter{namespace}, Headers: []openapi.Parameter{requiredIfMatch},
+			Request: llm.AuthConfig{}, Response: llm.LLMProvider{}, ResponseHeaders: etag,
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/llm/providers/:name/revisions",
+			OperationID: "listLLMProviderRevisions", Summary: "List the revisions of an LLM provider with their diffs, newest first", Tag: "llm",
+			Query:    []openapi.Parameter{namespace},
+			Response: service.RevisionList{},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/llm/providers/:name/revisions/:rev",
+			OperationID: "rollbackLLMProvider", Tag: "llm",
+			Summary: `Roll an LLM provider back to a revision; the rev path segment is "<revision>:rollback"`,
+			Query:   []openapi.Parameter{namespace}, Headers: []openapi.Parameter{requiredIfMatch},
+			Request: RollbackRequest{}, Response: llm.LLMProvider{}, ResponseHeaders: etag,
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/llm/models",
+			OperationID: "listModels", Summary: "List logical model names and the upstream names each provider receives", Tag: "traffic",
+			Query: []openapi.Parameter{
+				{Name: "namespace", Description: `Namespace, comma separated namespaces or "*" for all namespaces; defaults to default`},
+			},
+			Response: service.ModelList{},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/llm/ratelimits",
+			OperationID: "listRateLimitPolicies", Summary: "List token rate limit policies", Tag: "ratelimit",
+			Query: []openapi.Parameter{
+				{Name: "namespace", Description: `Namespace, comma separated namespaces or "*" for all namespaces; defaults to default`},
+			},
+			Response: service.RateLimitPolicyList{},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/llm/ratelimits",
+			OperationID: "createRateLimitPolicy", Summary: "Create a token rate limit policy on an AIGatewayRoute", Tag: "ratelimit",
+			Request: llm.RateLimitPolicy{}, Response: llm.RateLimitPolicy{}, Status: http.StatusCreated, ResponseHeaders: rateLimitETag,
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/llm/ratelimits/:name",
+			OperationID: "getRateLimitPolicy", Summary: "Get a token rate limit policy and its status", Tag: "ratelimit",
+			Query:    []openapi.Parameter{rateLimitNamespace},
+			Response: llm.RateLimitPolicy{}, ResponseHeaders: rateLimitETag,
+		},
+		{
+			Method: http.MethodPut, Path: "/api/v1/llm/ratelimits/:name",
+			OperationID: "updateRateLimitPolicy", Summary: "Update a token rate limit policy", Tag: "ratelimit",
+			Query: []openapi.Parameter{rateLimitNamespace}, Headers: []openapi.Parameter{rateLimitIfMatch},
+			Request: llm.RateLimitPolicy{}, Response: llm.RateLimitPolicy{}, ResponseHeaders: rateLimitETag,
+		},
+		{
+			Method: http.MethodDelete, Path: "/api/v1/llm/ratelimits/:name",
+			OperationID: "deleteRateLimitPolicy", Summary: "Delete a token rate limit policy", Tag: "ratelimit",
+			Query: []openapi.Parameter{rateLimitNamespace}, Headers: []openapi.Parameter{rateLimitIfMatch},
+			Response: MessageResponse{},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/llm/routes/:name/rules/:rule/split",
+			OperationID: "getTrafficSplit", Summary: "Get the weighted providers of an AIGatewayRoute rule", Tag: "traffic",
+			Query:    []openapi.Parameter{routeNamespace},
+			Response: llm.TrafficSplit{},
+		},
+		{
+			Method: http.MethodPut, Path: "/api/v1/llm/routes/:name/rules/:rule/split",
+			OperationID: "updateTrafficSplit", Summary: "Set the weighted providers of an AIGatewayRoute rule", Tag: "traffic",
+			Query:   []openapi.Parameter{routeNamespace},
+			Request: llm.TrafficSplit{}, Response: llm.TrafficSplit{},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/llm/routes/:name/rules/:rule/rollout",
+			OperationID: "getRollout", Summary: "Get the latest staged rollout of an AIGatewayRoute rule", Tag: "traffic",
+			Query:    []openapi.Parameter{routeNamespace},
+			Response: service.Rollout{},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/llm/routes/:name/rules/:rule/rollout",
+			OperationID: "startRollout", Summary: "Shift traffic between two providers of a rule in steps", Tag: "traffic",
+			Query:   []openapi.Parameter{routeNamespace},
+			Request: service.RolloutRequest{}, Response: service.Rollout{}, Status: http.StatusAccepted,
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/llm/routes/:name/rules/:rule/rollout/pause",
+			OperationID: "pauseRollout", Summary: "Pause a staged rollout at its current step", Tag: "traffic",
+			Query:    []openapi.Parameter{routeNamespace},
+			Response: service.Rollout{},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/llm/routes/:name/rules/:rule/rollout/resume",
+			OperationID: "resumeRollout", Summary: "Resume a paused rollout", Tag: "traffic",
+			Query:    []openapi.Parameter{routeNamespace},
+			Response: service.Rollout{},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/llm/routes/:name/rules/:rule/rollout/abort",
+			OperationID: "abortRollout", Summary: "Abort a rollout and restore the weights it started from", Tag: "traffic",
+			Query:    []openapi.Parameter{routeNamespace},
+			Response: service.Rollout{},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/usage",
+			OperationID: "getUsage", Summary: "Token usage and cost by namespace, provider, model and time bucket", Tag: "usage",
+			Query: []openapi.Parameter{
+				{Name: "namespace", Description: `Namespace, comma separated namespaces or "*" for all namespaces; defaults to default`},
+				{Name: "provider", Description: "Only usage of this provider"},
+				{Name: "model", Description: "Only usage of this model"},
+				{Name: "since", Description: "RFC 3339 start of the period, defaults to a day before until"},
+				{Name: "until", Description: "RFC 3339 end of the period, defaults to now"},
+				{Name: "bucket", Description: "Width of the time buckets, a multiple of the scrape interval; defaults to 1h"},
+			},
+			Response: service.UsageReport{},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/audit",
+			OperationID: "listAuditEvents", Summary: "Query the audit log of provider and rate limit policy mutations, newest first", Tag: "audit",
+			Query: []openapi.Parameter{
+				{Name: "namespace", Description: `Namespace, comma separated namespaces or "*" for all namespaces; defaults to default`},
+				{Name: "kind", Schema: &openapi.Schema{Type: "string", Enum: []string{string(audit.KindLLMProvider), string(audit.KindRateLimitPolicy)}}},
+				{Name: "provider", Description: "Only events of the provider or rate limit policy of this name"},
+				{Name: "actor", Description: "Only events by this user"},
+				{Name: "action", Schema: &openapi.Schema{Type: "string", Enum: []string{
+					string(audit.ActionCreate), string(audit.ActionUpdate), string(audit.ActionDelete), string(audit.ActionRotate),
+					string(audit.ActionRollback),
+				}}},
+				{Name: "outcome", Schema: &openapi.Schema{Type: "string", Enum: []string{string(audit.OutcomeSuccess), string(audit.OutcomeFailure)}}},
+				{Name: "since", Description: "Only events at or after this RFC 3339 time", Schema: &openapi.Schema{Type: "string", Format: "date-time"}},
+				{Name: "until", Description: "Only events before this RFC 3339 time", Schema: &openapi.Schema{Type: "string", Format: "date-time"}},
+				{Name: "limit", Description: "Maximum number of events, defaults to 100", Schema: &openapi.Schema{Type: "integer"}},
+			},
+			Response: AuditEventList{},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/diagnostics",
+			OperationID: "getDiagnostics", Summary: "Report installed CRD versions, missing RBAC permissions and controllers of the console", Tag: "system",
+			Response: Diagnostics{},
+		},
+	}
+}
+
+var (
+	specOnce sync.Once
+	spec     *openapi.Document
+	specErr  error
+)
+
+// OpenAPISpec returns the OpenAPI document of the console API
+func OpenAPISpec() (*openapi.Document, error) {
+	specOnce.Do(func() {
+		spec, specErr = openapi.Build(openapi.Info{
+			Title:       "Envoy AI Gateway Console API",
+			Version:     "v1",
+			Description: "Manage Envoy AI Gateway LLM providers in Kubernetes.",
+		}, Endpoints(), apierror.Problem{}, apierror.ContentTypeProblem)
+		if specErr != nil {
+			return
+		}
+		spec.Components.SecuritySchemes = map[string]*openapi.SecurityScheme{
+			"bearerAuth": {
+				Type:        "http",
+				Scheme:      "bearer",
+				Description: "OIDC ID token, Kubernetes token or static development token",
+			},
+		}
+		spec.Security = []openapi.SecurityRequirement{{"bearerAuth": {}}}
+	})
+	return spec, specErr
+}
+
+// GetOpenAPISpec handles GET /api/v1/openapi.json
+func (s *Server) GetOpenAPISpec(c *gin.Context) {
+	if s.apiDocsDisabled {
+		AbortWithError(c, apierror.NotFound("API documentation is disabled"))
+		return
+	}
+	doc, err := OpenAPISpec()
+	if err != nil {
+		AbortWithError(c, apierror.Wrap(apierror.CodeInternal, err, "failed to build OpenAPI document"))
+		return
+	}
+	c.JSON(http.StatusOK, doc)
+}
+
+// DefaultSwaggerUIURL serves the assets of a pinned Swagger UI release
+const DefaultSwaggerUIURL = "https://unpkg.com/swagger-ui-dist@5.17.14"
+
+// GetAPIDocs handles GET /api/v1/docs by serving Swagger UI for the OpenAPI document.
+// The assets are loaded from the configured URL, e.g. a copy served inside an air-gapped cluster.
+func (s *Server) GetAPIDocs(c *gin.Context) {
+	if s.apiDocsDisabled {
+		AbortWithError(c, apierror.NotFound("API documentation is disabled"))
+		return
+	}
+	assets := s.swaggerUIURL
+	if assets == "" {
+		assets = DefaultSwaggerUIURL
+	}
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(fmt.Sprintf(swaggerUIPage, html.EscapeString(strings.TrimSuffix(assets, "/")))))
+}
+
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8" />
+  <title>Envoy AI Gateway Console API</title>
+  <link rel="stylesheet" href="%[1]s/swagger-ui.css" crossorigin="anonymous" referrerpolicy="no-referrer" />
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="%[1]s/swagger-ui-bundle.js" crossorigin="anonymous" referrerpolicy="no-referrer"></script>
+  <script>
+    window.onload = () => {
+      window.ui = SwaggerUIBundle({ url: "openapi.json", dom_id: "#swagger-ui" });
+    };
+  </script>
+</body>
+</html>
+`
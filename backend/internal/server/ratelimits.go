@@ -0,0 +1,137 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/apierror"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/client"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/llm"
+	"github.com/gin-gonic/gin"
+)
+
+// ListRateLimitPolicies handles GET /api/v1/llm/ratelimits with Gin
+func (s *Server) ListRateLimitPolicies(c *gin.Context) {
+	namespaces := client.ParseNamespaces(c.QueryArray("namespace")...)
+	if len(namespaces) == 0 {
+		namespaces = []string{s.DefaultNamespace()}
+	}
+
+	policies, err := s.llmProviderService.ListRateLimitPolicies(c.Request.Context(), namespaces)
+	if err != nil {
+		AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, policies)
+}
+
+// GetRateLimitPolicy handles GET /api/v1/llm/ratelimits/:name with Gin
+func (s *Server) GetRateLimitPolicy(c *gin.Context) {
+	name := c.Param("name")
+	namespace, err := s.requestNamespace(c)
+	if err != nil {
+		AbortWithError(c, err)
+		return
+	}
+
+	policy, etag, err := s.llmProviderService.GetRateLimitPolicy(c.Request.Context(), namespace, name)
+	if err != nil {
+		AbortWithError(c, err)
+		return
+	}
+
+	c.Header(HeaderETag, etag)
+	c.JSON(http.StatusOK, policy)
+}
+
+// CreateRateLimitPolicy handles POST /api/v1/llm/ratelimits with Gin
+func (s *Server) CreateRateLimitPolicy(c *gin.Context) {
+	var policy llm.RateLimitPolicy
+	if err := c.ShouldBindJSON(&policy); err != nil {
+		AbortWithError(c, apierror.Wrap(apierror.CodeInvalid, err, "invalid JSON: %v", err))
+		return
+	}
+
+	namespace, err := ResolveNamespace(c.QueryArray("namespace"), policy.Namespace, s.DefaultNamespace())
+	if err != nil {
+		AbortWithError(c, err)
+		return
+	}
+	policy.Namespace = namespace
+
+	created, err := s.llmProviderService.CreateRateLimitPolicy(c.Request.Context(), &policy)
+	if err != nil {
+		AbortWithError(c, err)
+		return
+	}
+
+	s.setRateLimitETag(c, created.Namespace, created.Name)
+	c.JSON(http.StatusCreated, created)
+}
+
+// UpdateRateLimitPolicy handles PUT /api/v1/llm/ratelimits/:name with Gin
+func (s *Server) UpdateRateLimitPolicy(c *gin.Context) {
+	name := c.Param("name")
+
+	var policy llm.RateLimitPolicy
+	if err := c.ShouldBindJSON(&policy); err != nil {
+		AbortWithError(c, apierror.Wrap(apierror.CodeInvalid, err, "invalid JSON: %v", err))
+		return
+	}
+
+	if policy.Name != "" && policy.Name != name {
+		AbortWithError(c, apierror.Invalid("rate limit policy name %q does not match %q", policy.Name, name))
+		return
+	}
+
+	namespace, err := ResolveNamespace(c.QueryArray("namespace"), policy.Namespace, s.DefaultNamespace())
+	if err != nil {
+		AbortWithError(c, err)
+		return
+	}
+	policy.Name, policy.Namespace = name, namespace
+
+	ifMatch, err := requireIfMatch(c)
+	if err != nil {
+		AbortWithError(c, err)
+		return
+	}
+
+	updated, err := s.llmProviderService.UpdateRateLimitPolicy(c.Request.Context(), &policy, ifMatch)
+	if err != nil {
+		AbortWithError(c, err)
+		return
+	}
+
+	s.setRateLimitETag(c, namespace, name)
+	c.JSON(http.StatusOK, updated)
+}
+
+// DeleteRateLimitPolicy handles DELETE /api/v1/llm/ratelimits/:name with Gin
+func (s *Server) DeleteRateLimitPolicy(c *gin.Context) {
+	name := c.Param("name")
+	namespace, err := s.requestNamespace(c)
+	if err != nil {
+		AbortWithError(c, err)
+		return
+	}
+
+	ifMatch, err := requireIfMatch(c)
+	if err != nil {
+		AbortWithError(c, err)
+		return
+	}
+
+	if err := s.llmProviderService.DeleteRateLimitPolicy(c.Request.Context(), namespace, name, ifMatch); err != nil {
+		AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, MessageResponse{Message: fmt.Sprintf("Rate limit policy '%s' deleted successfully", name)})
+}
@@ -9,11 +9,19 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"net/http"
-	"time"
-
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/apierror"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/audit"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/auth"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/authz"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/metrics"
 	"github.com/envoyproxy/ai-gateway/console/backend/internal/service"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/usage"
 	"github.com/envoyproxy/ai-gateway/console/backend/pkg/client"
 	"github.com/envoyproxy/ai-gateway/console/backend/pkg/llm"
 	"github.com/gin-gonic/gin"
@@ -22,63 +30,339 @@ import (
 
 // Server holds the HTTP server and service dependencies
 type Server struct {
-	clientManager      *client.Manager
+	clientManager      client.ManagerInterface
 	llmProviderService *service.LLMProviderService
+	authenticator      auth.Authenticator
+	authorizer         authz.Authorizer
+	auditor            *audit.Recorder
+	defaultNamespace   string
+	corsOrigins        []string
+	apiDocsDisabled    bool
+	swaggerUIURL       string
+	logger             logr.Logger
+	metrics            http.Handler
+	health             *health
+	allowedNamespaces  []string
+
+	// stop cancels the background work started with the server, which background tracks
+	stop       context.CancelFunc
+	background sync.WaitGroup
 }
 
-// NewServer creates a new server instance
-func NewServer() (*Server, error) {
-	// Create client manager
-	config := client.Config{
-		Logger: logr.Discard(), // Use a simple logger for now
-	}
+// Config holds the configuration of the server
+type Config struct {
+	// Auth configures how API callers are authenticated
+	Auth auth.Config
+	// Authz configures the console roles of authenticated users
+	Authz authz.Config
+	// Audit configures where provider mutations are recorded
+	Audit audit.Config
+	// Usage configures the scraping of AI Gateway token metrics
+	Usage usage.Config
+	// Client configures the connection to the Kubernetes API
+	Client client.Config
+
+	// DefaultNamespace is used by requests that name no namespace, defaults to "default"
+	DefaultNamespace string
+	// AllowedNamespaces restricts the console to these namespaces, all when empty
+	AllowedNamespaces []string
+	// CORSOrigins are the origins browsers may call the API from, none when empty
+	CORSOrigins []string
+	// Features toggles optional parts of the API
+	Features Features
+	// SwaggerUIURL is the base URL of the swagger-ui-dist assets, DefaultSwaggerUIURL when empty
+	SwaggerUIURL string
+	// Logger is the base logger of the server, requests log with values added to it
+	Logger logr.Logger
+}
 
-	clientManager, err := client.NewManager(config)
+// Features toggles optional parts of the API
+type Features struct {
+	// APIDocs serves the OpenAPI document and Swagger UI
+	APIDocs bool `json:"apiDocs"`
+	// Revisions records the revision history of providers
+	Revisions bool `json:"revisions"`
+	// Rollouts allows staged traffic split rollouts
+	Rollouts bool `json:"rollouts"`
+}
+
+// NewServer creates a new server instance connected to the cluster of the client configuration.
+// The server starts even if the cluster is unreachable and reports unready until it is.
+func NewServer(cfg Config) (*Server, error) {
+	if cfg.Client.Logger.GetSink() == nil && cfg.Logger.GetSink() != nil {
+		cfg.Client.Logger = cfg.Logger.WithName("client")
+	}
+	clientManager, err := client.NewManager(cfg.Client)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create client manager: %w", err)
 	}
 
-	// Test connection
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	return NewServerWithManager(cfg, clientManager)
+}
+
+// NewServerWithManager creates a server on top of an existing client manager,
+// e.g. one backed by a fake client in tests
+func NewServerWithManager(cfg Config, clientManager client.ManagerInterface) (*Server, error) {
+	logger := cfg.Logger
+	if logger.GetSink() == nil {
+		logger = logr.Discard()
+	}
+
+	authenticator, err := auth.New(context.Background(), cfg.Auth, clientManager.Client())
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure authentication: %w", err)
+	}
+
+	authorizer, err := authz.New(cfg.Authz, clientManager.Client())
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure authorization: %w", err)
+	}
+	switch {
+	case cfg.Authz.AllowAll:
+		logger.Info("Authorization is disabled, every authenticated user is an admin in every namespace")
+	case !cfg.Authz.Enabled():
+		logger.Info("No authorization policy is configured, every API request is denied")
+	}
+	if len(cfg.AllowedNamespaces) > 0 {
+		authorizer = authz.RestrictNamespaces(authorizer, cfg.AllowedNamespaces)
+	}
 
-	if err := clientManager.HealthCheck(ctx); err != nil {
-		return nil, fmt.Errorf("kubernetes connection failed: %w", err)
+	auditor, err := audit.New(cfg.Audit, clientManager.Client())
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure audit log: %w", err)
+	}
+
+	serviceOpts := []service.Option{service.WithAuditor(auditor)}
+	if cfg.Auth.Impersonate {
+		serviceOpts = append(serviceOpts, service.WithImpersonation())
+	}
+	if !cfg.Features.Revisions {
+		serviceOpts = append(serviceOpts, service.WithoutRevisions())
+	}
+	if !cfg.Features.Rollouts {
+		serviceOpts = append(serviceOpts, service.WithoutRollouts())
+	}
+	var collector *usage.Collector
+	if cfg.Usage.Enabled() {
+		collector = usage.NewCollector(cfg.Usage)
+		serviceOpts = append(serviceOpts, service.WithUsage(collector))
 	}
 
 	server := &Server{
 		clientManager:      clientManager,
-		llmProviderService: service.NewLLMProviderService(clientManager),
+		llmProviderService: service.NewLLMProviderService(clientManager, serviceOpts...),
+		authenticator:      authenticator,
+		authorizer:         authorizer,
+		auditor:            auditor,
+		defaultNamespace:   cfg.DefaultNamespace,
+		corsOrigins:        cfg.CORSOrigins,
+		apiDocsDisabled:    !cfg.Features.APIDocs,
+		swaggerUIURL:       cfg.SwaggerUIURL,
+		logger:             logger,
+		health:             newHealth(clientManager, logger.WithName("health")),
+		allowedNamespaces:  cfg.AllowedNamespaces,
 	}
+	var background context.Context
+	background, server.stop = context.WithCancel(context.Background())
+
+	if collector != nil {
+		server.goBackground(func() { collector.Run(logr.NewContext(background, logger.WithName("usage"))) })
+	}
+
+	// The API versions are selected before serving, readiness is unknown and /readyz answers
+	// 503 until the first check returns
+	server.health.discoverAPIVersions(healthCheckTimeout)
+	server.goBackground(func() { server.health.run(background) })
+
+	// Providers are counted across the namespaces the console manages
+	inventoryNamespaces := cfg.AllowedNamespaces
+	if len(inventoryNamespaces) == 0 {
+		inventoryNamespaces = []string{client.AllNamespaces}
+	}
+	providers := metrics.NewProviderCollector(func(ctx context.Context) ([]llm.LLMProvider, error) {
+		return server.llmProviderService.Inventory(ctx, inventoryNamespaces)
+	}, metrics.DefaultProviderCacheTTL, logger.WithName("metrics"))
+	server.metrics = metrics.Handler(metrics.NewRegistry(providers))
 
 	return server, nil
 }
 
+// Close stops the background work of the server once it no longer serves requests and
+// waits for it to return. Running rollouts stop at their current step, and the audit
+// events recorded so far are flushed.
+func (s *Server) Close() {
+	if s.stop != nil {
+		s.stop()
+	}
+	s.background.Wait()
+	if s.llmProviderService != nil {
+		s.llmProviderService.Close()
+	}
+	if s.auditor != nil {
+		if err := s.auditor.Close(); err != nil {
+			s.logger.Error(err, "Failed to close the audit log")
+		}
+	}
+}
+
+// goBackground runs fn in a goroutine Close waits for
+func (s *Server) goBackground(fn func()) {
+	s.background.Add(1)
+	go func() {
+		defer s.background.Done()
+		fn()
+	}()
+}
+
+// DefaultNamespace returns the namespace of requests that name none
+func (s *Server) DefaultNamespace() string {
+	if s.defaultNamespace == "" {
+		return "default"
+	}
+	return s.defaultNamespace
+}
+
+// requestNamespace returns the namespace of a request addressing a single resource
+func (s *Server) requestNamespace(c *gin.Context) (string, error) {
+	return singleNamespace(c.QueryArray("namespace"), s.DefaultNamespace())
+}
+
+// ResolveNamespace returns the namespace a request writes to: the namespace query parameter,
+// then the namespace of the body, then defaultNamespace. A body aimed at another namespace than
+// the query is rejected. The authorization middleware resolves it the same way, so a request
+// only writes to the namespace it was authorized for.
+func ResolveNamespace(query []string, body, defaultNamespace string) (string, error) {
+	namespace, err := singleNamespace(query, "")
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case namespace == "":
+		namespace = body
+	case body != "" && body != namespace:
+		return "", apierror.Invalid("namespace %q of the body does not match %q", body, namespace)
+	}
+	if namespace == "" {
+		return defaultNamespace, nil
+	}
+	return namespace, nil
+}
+
+// singleNamespace returns the namespace named by the namespace query values, defaultNamespace
+// when they name none. Lists and "*" select namespaces to list and are rejected rather than
+// sent to Kubernetes as a namespace name.
+func singleNamespace(values []string, defaultNamespace string) (string, error) {
+	namespace := ""
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		if strings.ContainsAny(value, ","+client.AllNamespaces) || (namespace != "" && value != namespace) {
+			return "", apierror.Invalid("invalid namespace %q: the request addresses a single namespace", strings.Join(values, ","))
+		}
+		namespace = value
+	}
+	if namespace == "" {
+		return defaultNamespace, nil
+	}
+	return namespace, nil
+}
+
+// CORSOrigins returns the origins browsers may call the API from
+func (s *Server) CORSOrigins() []string {
+	return s.corsOrigins
+}
+
+// Logger returns the base logger of the server
+func (s *Server) Logger() logr.Logger {
+	if s.logger.GetSink() == nil {
+		return logr.Discard()
+	}
+	return s.logger
+}
+
+// Metrics handles GET /metrics
+func (s *Server) Metrics() http.Handler {
+	if s.metrics == nil {
+		return metrics.Handler(metrics.NewRegistry())
+	}
+	return s.metrics
+}
+
+// Authenticator returns the authenticator for API requests
+func (s *Server) Authenticator() auth.Authenticator {
+	return s.authenticator
+}
+
+// Authorizer returns the authorizer enforcing console roles
+func (s *Server) Authorizer() authz.Authorizer {
+	return s.authorizer
+}
+
 // GetLLMProviders handles GET /api/v1/llm/providers
+// The namespace query parameter accepts a single namespace, a comma separated list,
+// repeated values or "*" for all namespaces. Results can be paged with limit/continue,
+// filtered by schema, authType, host and labelSelector and sorted with sort/order.
 func (s *Server) GetLLMProviders(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	namespace := r.URL.Query().Get("namespace")
-	if namespace == "" {
-		namespace = "default"
+	opts, err := parseListOptions(r.URL.Query(), s.DefaultNamespace())
+	if err != nil {
+		WriteError(w, r, err)
+		return
 	}
 
-	log.Printf("Getting LLM providers from namespace: %s", namespace)
+	logr.FromContextOrDiscard(ctx).V(1).Info("Listing LLM providers", "namespaces", opts.Namespaces)
 
-	providers, err := s.llmProviderService.ListProviders(ctx, namespace)
+	providers, err := s.llmProviderService.ListProviders(ctx, opts)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to list LLM providers: %v", err), http.StatusInternalServerError)
+		WriteError(w, r, err)
 		return
 	}
 
 	// Ensure we always return an array, never null
-	if providers == nil {
-		providers = []llm.LLMProvider{}
+	if providers.Items == nil {
+		providers.Items = []llm.LLMProvider{}
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(providers)
 }
 
+// parseListOptions builds the provider list options from the request query
+func parseListOptions(query url.Values, defaultNamespace string) (service.ListOptions, error) {
+	opts := service.ListOptions{
+		Namespaces:    client.ParseNamespaces(query["namespace"]...),
+		Continue:      query.Get("continue"),
+		LabelSelector: query.Get("labelSelector"),
+		Schema:        query.Get("schema"),
+		AuthType:      query.Get("authType"),
+		Host:          query.Get("host"),
+		SortBy:        query.Get("sort"),
+	}
+	if len(opts.Namespaces) == 0 {
+		opts.Namespaces = []string{defaultNamespace}
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		parsed, err := strconv.ParseInt(limit, 10, 64)
+		if err != nil {
+			return opts, apierror.Invalid("invalid limit %q: must be a number", limit)
+		}
+		opts.Limit = parsed
+	}
+
+	switch order := query.Get("order"); order {
+	case "", "asc":
+	case "desc":
+		opts.Descending = true
+	default:
+		return opts, apierror.Invalid("invalid order %q: must be asc or desc", order)
+	}
+
+	return opts, opts.Validate()
+}
+
 // GetLLMProvider handles GET /api/v1/llm/providers/{name}
 func (s *Server) GetLLMProvider(w http.ResponseWriter, r *http.Request) {
 	// Extract name from URL path manually or use query parameter
@@ -86,57 +370,50 @@ func (s *Server) GetLLMProvider(w http.ResponseWriter, r *http.Request) {
 	if name == "" {
 		// Extract from path - simple extraction for now
 		// This would be better handled by a proper router
-		http.Error(w, "Provider name is required", http.StatusBadRequest)
+		WriteError(w, r, apierror.Invalid("provider name is required"))
 		return
 	}
 
-	namespace := r.URL.Query().Get("namespace")
-	if namespace == "" {
-		namespace = "default"
+	namespace, err := singleNamespace(r.URL.Query()["namespace"], s.DefaultNamespace())
+	if err != nil {
+		WriteError(w, r, err)
+		return
 	}
 
 	ctx := r.Context()
 
-	provider, err := s.llmProviderService.GetProvider(ctx, namespace, name)
+	provider, etag, err := s.llmProviderService.GetProvider(ctx, namespace, name)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("LLM provider not found: %v", err), http.StatusNotFound)
+		WriteError(w, r, err)
 		return
 	}
 
+	w.Header().Set(HeaderETag, etag)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(provider)
 }
 
-// HealthCheck handles GET /health
-func (s *Server) HealthCheck(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
-	defer cancel()
-
-	if err := s.clientManager.HealthCheck(ctx); err != nil {
-		http.Error(w, fmt.Sprintf("Health check failed: %v", err), http.StatusServiceUnavailable)
-		return
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]string{"status": "healthy"})
-}
-
 // GetLLMProviderByName handles GET /api/v1/llm/providers/:name with Gin
 func (s *Server) GetLLMProviderByName(c *gin.Context) {
 	name := c.Param("name")
 	if name == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Provider name is required"})
+		AbortWithError(c, apierror.Invalid("provider name is required"))
 		return
 	}
 
-	namespace := c.DefaultQuery("namespace", "default")
+	namespace, err := s.requestNamespace(c)
+	if err != nil {
+		AbortWithError(c, err)
+		return
+	}
 
-	provider, err := s.llmProviderService.GetProvider(c.Request.Context(), namespace, name)
+	provider, etag, err := s.llmProviderService.GetProvider(c.Request.Context(), namespace, name)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("LLM provider not found: %v", err)})
+		AbortWithError(c, err)
 		return
 	}
 
+	c.Header(HeaderETag, etag)
 	c.JSON(http.StatusOK, provider)
 }
 
@@ -145,24 +422,27 @@ func (s *Server) CreateLLMProvider(c *gin.Context) {
 	var provider llm.LLMProvider
 
 	if err := c.ShouldBindJSON(&provider); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid JSON: %v", err)})
+		AbortWithError(c, apierror.Wrap(apierror.CodeInvalid, err, "invalid JSON: %v", err))
 		return
 	}
 
 	// Validate required fields
 	if provider.Name == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Provider name is required"})
+		AbortWithError(c, apierror.Invalid("provider name is required"))
 		return
 	}
 
-	if provider.Namespace == "" {
-		provider.Namespace = "default"
+	namespace, err := ResolveNamespace(c.QueryArray("namespace"), provider.Namespace, s.DefaultNamespace())
+	if err != nil {
+		AbortWithError(c, err)
+		return
 	}
+	provider.Namespace = namespace
 
 	// Create the provider
-	err := s.llmProviderService.CreateProvider(c.Request.Context(), &provider)
+	err = s.llmProviderService.CreateProvider(c.Request.Context(), &provider)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to create LLM provider: %v", err)})
+		AbortWithError(c, err)
 		return
 	}
 
@@ -174,19 +454,210 @@ func (s *Server) CreateLLMProvider(c *gin.Context) {
 // DeleteLLMProvider handles DELETE /api/v1/llm/providers/{name} with Gin
 func (s *Server) DeleteLLMProvider(c *gin.Context) {
 	name := c.Param("name")
-	namespace := c.DefaultQuery("namespace", "default")
-
 	if name == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Provider name is required"})
+		AbortWithError(c, apierror.Invalid("provider name is required"))
+		return
+	}
+
+	namespace, err := s.requestNamespace(c)
+	if err != nil {
+		AbortWithError(c, err)
+		return
+	}
+
+	ifMatch, err := requireIfMatch(c)
+	if err != nil {
+		AbortWithError(c, err)
 		return
 	}
 
 	// Delete the provider
-	err := s.llmProviderService.DeleteProvider(c.Request.Context(), namespace, name)
+	err = s.llmProviderService.DeleteProvider(c.Request.Context(), namespace, name, ifMatch)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to delete LLM provider: %v", err)})
+		AbortWithError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("Provider '%s' deleted successfully", name)})
+	c.JSON(http.StatusOK, MessageResponse{Message: fmt.Sprintf("Provider '%s' deleted successfully", name)})
+}
+
+// GetLLMProviderManifests handles GET /api/v1/llm/providers/:name/manifests with Gin
+func (s *Server) GetLLMProviderManifests(c *gin.Context) {
+	name := c.Param("name")
+	namespace, err := s.requestNamespace(c)
+	if err != nil {
+		AbortWithError(c, err)
+		return
+	}
+
+	includeSecrets, err := ParseIncludeSecrets(c.Query(IncludeSecretsParam))
+	if err != nil {
+		AbortWithError(c, err)
+		return
+	}
+
+	manifests, err := s.llmProviderService.GetProviderManifests(c.Request.Context(), namespace, name, includeSecrets)
+	if err != nil {
+		AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ManifestList{Items: manifests})
+}
+
+// IncludeSecretsParam is the query parameter revealing secret values in manifests
+const IncludeSecretsParam = "includeSecrets"
+
+// ParseIncludeSecrets parses the includeSecrets query parameter
+func ParseIncludeSecrets(value string) (bool, error) {
+	if value == "" {
+		return false, nil
+	}
+	include, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, apierror.Invalid("invalid %s %q: must be true or false", IncludeSecretsParam, value)
+	}
+	return include, nil
+}
+
+// RotateLLMProviderCredentials handles PUT /api/v1/llm/providers/:name/credentials with Gin
+func (s *Server) RotateLLMProviderCredentials(c *gin.Context) {
+	name := c.Param("name")
+	namespace, err := s.requestNamespace(c)
+	if err != nil {
+		AbortWithError(c, err)
+		return
+	}
+
+	var credentials llm.AuthConfig
+	if err := c.ShouldBindJSON(&credentials); err != nil {
+		AbortWithError(c, apierror.Wrap(apierror.CodeInvalid, err, "invalid JSON: %v", err))
+		return
+	}
+
+	ifMatch, err := requireIfMatch(c)
+	if err != nil {
+		AbortWithError(c, err)
+		return
+	}
+
+	provider, err := s.llmProviderService.RotateCredentials(c.Request.Context(), namespace, name, credentials, ifMatch)
+	if err != nil {
+		AbortWithError(c, err)
+		return
+	}
+
+	s.setProviderETag(c, namespace, name)
+	c.JSON(http.StatusOK, provider)
+}
+
+// UpdateLLMProvider handles PUT /api/v1/llm/providers/:name with Gin
+// Masked credentials in the body keep their current value.
+func (s *Server) UpdateLLMProvider(c *gin.Context) {
+	name := c.Param("name")
+
+	var provider llm.LLMProvider
+	if err := c.ShouldBindJSON(&provider); err != nil {
+		AbortWithError(c, apierror.Wrap(apierror.CodeInvalid, err, "invalid JSON: %v", err))
+		return
+	}
+
+	if provider.Name != "" && provider.Name != name {
+		AbortWithError(c, apierror.Invalid("provider name %q does not match %q", provider.Name, name))
+		return
+	}
+
+	namespace, err := ResolveNamespace(c.QueryArray("namespace"), provider.Namespace, s.DefaultNamespace())
+	if err != nil {
+		AbortWithError(c, err)
+		return
+	}
+	provider.Name, provider.Namespace = name, namespace
+
+	ifMatch, err := requireIfMatch(c)
+	if err != nil {
+		AbortWithError(c, err)
+		return
+	}
+
+	updated, err := s.llmProviderService.UpdateProvider(c.Request.Context(), &provider, ifMatch)
+	if err != nil {
+		AbortWithError(c, err)
+		return
+	}
+
+	s.setProviderETag(c, namespace, name)
+	c.JSON(http.StatusOK, updated)
+}
+
+// ListLLMProviderRevisions handles GET /api/v1/llm/providers/:name/revisions with Gin
+func (s *Server) ListLLMProviderRevisions(c *gin.Context) {
+	name := c.Param("name")
+	namespace, err := s.requestNamespace(c)
+	if err != nil {
+		AbortWithError(c, err)
+		return
+	}
+
+	revisions, err := s.llmProviderService.ListRevisions(c.Request.Context(), namespace, name)
+	if err != nil {
+		AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, revisions)
+}
+
+// RollbackRequest is the optional body of a rollback
+type RollbackRequest struct {
+	// Credentials replace the current credentials, which are kept when omitted
+	Credentials *llm.AuthConfig `json:"credentials,omitempty"`
+}
+
+// rollbackSuffix is the custom method suffix of the revision path segment
+const rollbackSuffix = ":rollback"
+
+// RollbackLLMProvider handles POST /api/v1/llm/providers/:name/revisions/:rev:rollback with Gin.
+// Gin cannot match a literal after a parameter in one segment, so the suffix is parsed here.
+func (s *Server) RollbackLLMProvider(c *gin.Context) {
+	name := c.Param("name")
+	namespace, err := s.requestNamespace(c)
+	if err != nil {
+		AbortWithError(c, err)
+		return
+	}
+
+	rev, ok := strings.CutSuffix(c.Param("rev"), rollbackSuffix)
+	if !ok {
+		AbortWithError(c, apierror.NotFound("no route for %s %s", c.Request.Method, c.Request.URL.Path))
+		return
+	}
+	revision, err := strconv.ParseInt(rev, 10, 64)
+	if err != nil || revision <= 0 {
+		AbortWithError(c, apierror.Invalid("invalid revision %q: must be a positive number", rev))
+		return
+	}
+
+	var request RollbackRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			AbortWithError(c, apierror.Wrap(apierror.CodeInvalid, err, "invalid JSON: %v", err))
+			return
+		}
+	}
+
+	ifMatch, err := requireIfMatch(c)
+	if err != nil {
+		AbortWithError(c, err)
+		return
+	}
+
+	provider, err := s.llmProviderService.RollbackProvider(c.Request.Context(), namespace, name, revision, request.Credentials, ifMatch)
+	if err != nil {
+		AbortWithError(c, err)
+		return
+	}
+
+	s.setProviderETag(c, namespace, name)
+	c.JSON(http.StatusOK, provider)
 }
@@ -0,0 +1,124 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package server
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/apierror"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/service"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/llm"
+	"github.com/gin-gonic/gin"
+)
+
+// routeRule extracts the namespace, route name and rule index of the request
+func (s *Server) routeRule(c *gin.Context) (string, string, int, error) {
+	rule, err := strconv.Atoi(c.Param("rule"))
+	if err != nil || rule < 0 {
+		return "", "", 0, apierror.Invalid("invalid rule %q: must be the index of a rule of the route", c.Param("rule"))
+	}
+	namespace, err := s.requestNamespace(c)
+	if err != nil {
+		return "", "", 0, err
+	}
+	return namespace, c.Param("name"), rule, nil
+}
+
+// GetTrafficSplit handles GET /api/v1/llm/routes/:name/rules/:rule/split with Gin
+func (s *Server) GetTrafficSplit(c *gin.Context) {
+	namespace, route, rule, err := s.routeRule(c)
+	if err != nil {
+		AbortWithError(c, err)
+		return
+	}
+
+	split, err := s.llmProviderService.GetTrafficSplit(c.Request.Context(), namespace, route, rule)
+	if err != nil {
+		AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, split)
+}
+
+// UpdateTrafficSplit handles PUT /api/v1/llm/routes/:name/rules/:rule/split with Gin
+func (s *Server) UpdateTrafficSplit(c *gin.Context) {
+	namespace, route, rule, err := s.routeRule(c)
+	if err != nil {
+		AbortWithError(c, err)
+		return
+	}
+
+	var split llm.TrafficSplit
+	if err := c.ShouldBindJSON(&split); err != nil {
+		AbortWithError(c, apierror.Wrap(apierror.CodeInvalid, err, "invalid JSON: %v", err))
+		return
+	}
+	if namespace, err = ResolveNamespace(c.QueryArray("namespace"), split.Namespace, s.DefaultNamespace()); err != nil {
+		AbortWithError(c, err)
+		return
+	}
+	split.Namespace, split.Route, split.Rule = namespace, route, rule
+
+	updated, err := s.llmProviderService.UpdateTrafficSplit(c.Request.Context(), &split)
+	if err != nil {
+		AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, updated)
+}
+
+// GetRollout handles GET /api/v1/llm/routes/:name/rules/:rule/rollout with Gin
+func (s *Server) GetRollout(c *gin.Context) {
+	s.handleRollout(c, http.StatusOK, s.llmProviderService.GetRollout)
+}
+
+// StartRollout handles POST /api/v1/llm/routes/:name/rules/:rule/rollout with Gin
+func (s *Server) StartRollout(c *gin.Context) {
+	var request service.RolloutRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		AbortWithError(c, apierror.Wrap(apierror.CodeInvalid, err, "invalid JSON: %v", err))
+		return
+	}
+
+	s.handleRollout(c, http.StatusAccepted, func(ctx context.Context, namespace, route string, rule int) (*service.Rollout, error) {
+		return s.llmProviderService.StartRollout(ctx, namespace, route, rule, request)
+	})
+}
+
+// PauseRollout handles POST /api/v1/llm/routes/:name/rules/:rule/rollout/pause with Gin
+func (s *Server) PauseRollout(c *gin.Context) {
+	s.handleRollout(c, http.StatusOK, s.llmProviderService.PauseRollout)
+}
+
+// ResumeRollout handles POST /api/v1/llm/routes/:name/rules/:rule/rollout/resume with Gin
+func (s *Server) ResumeRollout(c *gin.Context) {
+	s.handleRollout(c, http.StatusOK, s.llmProviderService.ResumeRollout)
+}
+
+// AbortRollout handles POST /api/v1/llm/routes/:name/rules/:rule/rollout/abort with Gin
+func (s *Server) AbortRollout(c *gin.Context) {
+	s.handleRollout(c, http.StatusOK, s.llmProviderService.AbortRollout)
+}
+
+func (s *Server) handleRollout(c *gin.Context, status int, op func(ctx context.Context, namespace, route string, rule int) (*service.Rollout, error)) {
+	namespace, route, rule, err := s.routeRule(c)
+	if err != nil {
+		AbortWithError(c, err)
+		return
+	}
+
+	rollout, err := op(c.Request.Context(), namespace, route, rule)
+	if err != nil {
+		AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(status, rollout)
+}
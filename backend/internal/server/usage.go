@@ -0,0 +1,80 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package server
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/apierror"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/usage"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/client"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultUsageWindow = 24 * time.Hour
+	defaultUsageBucket = time.Hour
+)
+
+// GetUsage handles GET /api/v1/usage with Gin
+func (s *Server) GetUsage(c *gin.Context) {
+	query, err := parseUsageQuery(c.Request.URL.Query(), s.DefaultNamespace(), time.Now())
+	if err != nil {
+		AbortWithError(c, err)
+		return
+	}
+
+	report, err := s.llmProviderService.GetUsage(c.Request.Context(), query)
+	if err != nil {
+		AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// parseUsageQuery builds the usage query from the request query, covering the last day in
+// hourly buckets by default
+func parseUsageQuery(values url.Values, defaultNamespace string, now time.Time) (usage.Query, error) {
+	query := usage.Query{
+		Namespaces: client.ParseNamespaces(values["namespace"]...),
+		Provider:   values.Get("provider"),
+		Model:      values.Get("model"),
+		Until:      now,
+		Bucket:     defaultUsageBucket,
+	}
+	switch {
+	case len(query.Namespaces) == 0:
+		query.Namespaces = []string{defaultNamespace}
+	case client.IsAllNamespaces(query.Namespaces[0]):
+		query.Namespaces = nil
+	}
+
+	if value := values.Get("bucket"); value != "" {
+		bucket, err := time.ParseDuration(value)
+		if err != nil || bucket <= 0 {
+			return query, apierror.Invalid("invalid bucket %q: must be a positive duration such as 1h", value)
+		}
+		query.Bucket = bucket
+	}
+
+	for name, target := range map[string]*time.Time{"since": &query.Since, "until": &query.Until} {
+		if value := values.Get(name); value != "" {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return query, apierror.Invalid("invalid %s %q: must be an RFC 3339 timestamp", name, value)
+			}
+			*target = parsed
+		}
+	}
+	if query.Since.IsZero() {
+		query.Since = query.Until.Add(-defaultUsageWindow)
+	}
+
+	return query, nil
+}
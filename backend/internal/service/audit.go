@@ -0,0 +1,57 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package service
+
+import (
+	"context"
+
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/apierror"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/audit"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/auth"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/requestid"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/llm"
+)
+
+// recordAudit records a provider mutation made by the user of the request.
+// before and after must be masked providers; nil stands for a missing provider.
+// A failed call records no changes, whatever was passed.
+func (s *LLMProviderService) recordAudit(ctx context.Context, action audit.Action, namespace, name string, before, after *llm.LLMProvider, err error) {
+	s.recordEvent(ctx, audit.KindLLMProvider, action, namespace, name, before, after, err)
+}
+
+// recordRateLimitAudit records a rate limit policy mutation made by the user of the request.
+// nil stands for a missing policy, a failed call records no changes.
+func (s *LLMProviderService) recordRateLimitAudit(ctx context.Context, action audit.Action, namespace, name string, before, after *llm.RateLimitPolicy, err error) {
+	s.recordEvent(ctx, audit.KindRateLimitPolicy, action, namespace, name, before, after, err)
+}
+
+func (s *LLMProviderService) recordEvent(ctx context.Context, kind audit.Kind, action audit.Action, namespace, name string, before, after any, err error) {
+	if s.auditor == nil {
+		return
+	}
+
+	event := audit.Event{
+		Action:    action,
+		Kind:      kind,
+		Namespace: namespace,
+		Provider:  name,
+		Outcome:   audit.OutcomeSuccess,
+		RequestID: requestid.FromContext(ctx),
+	}
+	if user, ok := auth.UserFromContext(ctx); ok {
+		event.Actor = user.Name
+		event.Groups = user.Groups
+	}
+
+	if err != nil {
+		event.Outcome = audit.OutcomeFailure
+		event.Error = apierror.MessageOf(err)
+	} else {
+		event.Changes = audit.Diff(before, after)
+	}
+
+	s.auditor.Record(ctx, event)
+}
@@ -0,0 +1,105 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/audit"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/client"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/llm"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// newAuditedService returns a fake service recording audit events in memory
+func newAuditedService(t *testing.T) (*LLMProviderService, *audit.MemoryStore) {
+	t.Helper()
+	scheme, err := client.NewScheme()
+	require.NoError(t, err)
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	store := audit.NewMemoryStore(0)
+	manager := client.NewManagerWithClient(k8sClient, logr.Discard())
+	return NewLLMProviderService(manager, WithAuditor(audit.NewRecorder(store))), store
+}
+
+// lastEvent returns the newest audit event
+func lastEvent(t *testing.T, store *audit.MemoryStore) audit.Event {
+	t.Helper()
+	events, err := store.Query(context.Background(), audit.Query{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	return events[0]
+}
+
+func TestAuditRecordsChangesOfSuccessfulCalls(t *testing.T) {
+	s, store := newAuditedService(t)
+	ctx := context.Background()
+
+	require.NoError(t, s.CreateProvider(ctx, openAIProvider("default", "openai")))
+	event := lastEvent(t, store)
+	assert.Equal(t, audit.ActionCreate, event.Action)
+	assert.Equal(t, audit.OutcomeSuccess, event.Outcome)
+	assert.NotEmpty(t, event.Changes)
+
+	updated := openAIProvider("default", "openai")
+	updated.Backend.Port = 8443
+	_, err := s.UpdateProvider(ctx, updated, "")
+	require.NoError(t, err)
+	event = lastEvent(t, store)
+	assert.Equal(t, audit.ActionUpdate, event.Action)
+	assert.Contains(t, event.Changes, audit.Change{Path: "backend.port", Before: float64(443), After: float64(8443)})
+}
+
+func TestAuditRecordsNoChangesOfFailedCalls(t *testing.T) {
+	s, store := newAuditedService(t)
+	ctx := context.Background()
+	require.NoError(t, s.CreateProvider(ctx, openAIProvider("default", "openai")))
+
+	tests := map[string]struct {
+		call   func() error
+		action audit.Action
+	}{
+		"create existing": {
+			call:   func() error { return s.CreateProvider(ctx, openAIProvider("default", "openai")) },
+			action: audit.ActionCreate,
+		},
+		"update with stale etag": {
+			call: func() error {
+				updated := openAIProvider("default", "openai")
+				updated.Backend.Port = 8443
+				_, err := s.UpdateProvider(ctx, updated, `"stale"`)
+				return err
+			},
+			action: audit.ActionUpdate,
+		},
+		"rotate with stale etag": {
+			call: func() error {
+				_, err := s.RotateCredentials(ctx, "default", "openai", llm.AuthConfig{Type: "APIKey", APIKey: "sk-new"}, `"stale"`)
+				return err
+			},
+			action: audit.ActionRotate,
+		},
+		"delete with stale etag": {
+			call:   func() error { return s.DeleteProvider(ctx, "default", "openai", `"stale"`) },
+			action: audit.ActionDelete,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Error(t, tt.call())
+			event := lastEvent(t, store)
+			assert.Equal(t, tt.action, event.Action)
+			assert.Equal(t, audit.OutcomeFailure, event.Outcome)
+			assert.NotEmpty(t, event.Error)
+			assert.Empty(t, event.Changes)
+		})
+	}
+}
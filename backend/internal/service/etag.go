@@ -0,0 +1,76 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/apierror"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// providerETag computes a strong ETag from the resourceVersions of every object of a provider.
+// Any change to one of the objects, including its Secret, yields a new ETag.
+func providerETag(resources []any) string {
+	versions := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		if object, ok := resource.(client.Object); ok {
+			versions = append(versions, fmt.Sprintf("%T/%s/%s=%s", object, object.GetNamespace(), object.GetName(), object.GetResourceVersion()))
+		}
+	}
+	sort.Strings(versions)
+
+	sum := sha256.Sum256([]byte(strings.Join(versions, "\n")))
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// checkETag verifies an If-Match header value against the current resources.
+// An empty value skips the check, "*" matches any existing provider.
+func checkETag(ifMatch string, resources []any) error {
+	if ifMatch == "" {
+		return nil
+	}
+
+	current := providerETag(resources)
+	if matchETag(ifMatch, current) {
+		return nil
+	}
+	return apierror.New(apierror.CodePreconditionFailed, "the LLM provider has been modified since it was read (ETag %s)", current)
+}
+
+// rateLimitETag computes the ETag of a rate limit policy from its BackendTrafficPolicy
+func rateLimitETag(btp client.Object) string {
+	return providerETag([]any{btp})
+}
+
+// checkRateLimitETag verifies an If-Match header value against the BackendTrafficPolicy
+// of a rate limit policy, with the same rules as checkETag
+func checkRateLimitETag(ifMatch string, btp client.Object) error {
+	if ifMatch == "" {
+		return nil
+	}
+
+	current := rateLimitETag(btp)
+	if matchETag(ifMatch, current) {
+		return nil
+	}
+	return apierror.New(apierror.CodePreconditionFailed, "the rate limit policy has been modified since it was read (ETag %s)", current)
+}
+
+// matchETag reports whether one of the comma separated If-Match values matches the ETag
+func matchETag(ifMatch, current string) bool {
+	for _, candidate := range strings.Split(ifMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || candidate == current {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,37 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package service
+
+import (
+	"testing"
+
+	aigatewayv1alpha1 "github.com/envoyproxy/ai-gateway/api/v1alpha1"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/apierror"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestProviderETag(t *testing.T) {
+	aisb := &aigatewayv1alpha1.AIServiceBackend{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "openai", ResourceVersion: "10"}}
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "openai", ResourceVersion: "11"}}
+
+	etag := providerETag([]any{aisb, secret})
+	assert.Equal(t, etag, providerETag([]any{secret, aisb}), "order of resources does not matter")
+	assert.Regexp(t, `^"[0-9a-f]{32}"$`, etag)
+
+	// Rotating the secret alone changes the ETag
+	rotated := secret.DeepCopy()
+	rotated.ResourceVersion = "12"
+	assert.NotEqual(t, etag, providerETag([]any{aisb, rotated}))
+
+	assert.NoError(t, checkETag("", []any{aisb, secret}))
+	assert.NoError(t, checkETag("*", []any{aisb, secret}))
+	assert.NoError(t, checkETag(`"other", `+etag, []any{aisb, secret}))
+
+	err := checkETag(etag, []any{aisb, rotated})
+	assert.True(t, apierror.Is(err, apierror.CodePreconditionFailed))
+}
@@ -7,12 +7,18 @@ package service
 
 import (
 	"context"
-	"fmt"
+	"strings"
 
 	aigatewayv1alpha1 "github.com/envoyproxy/ai-gateway/api/v1alpha1"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/apierror"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/audit"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/auth"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/metrics"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/usage"
 	"github.com/envoyproxy/ai-gateway/console/backend/pkg/client"
 	"github.com/envoyproxy/ai-gateway/console/backend/pkg/llm"
 	gatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	gwapiv1a3 "sigs.k8s.io/gateway-api/apis/v1alpha3"
@@ -21,133 +27,378 @@ import (
 // LLMProviderService handles business logic for LLM providers
 // It orchestrates loading Kubernetes resources and translating them to LLMProvider objects
 type LLMProviderService struct {
-	clientManager *client.Manager
+	clientManager client.ManagerInterface
+	impersonate   bool
+	auditor       *audit.Recorder
+	rollouts      *rollouts
+	usage         *usage.Collector
+	noRevisions   bool
+	noRollouts    bool
+}
+
+// Option configures an LLMProviderService
+type Option func(*LLMProviderService)
+
+// WithImpersonation makes the service act as the authenticated user of each request,
+// so Kubernetes RBAC decides which namespaces and secrets the user can read and write
+func WithImpersonation() Option {
+	return func(s *LLMProviderService) {
+		s.impersonate = true
+	}
+}
+
+// WithAuditor records every mutation of a provider with the audit recorder
+func WithAuditor(auditor *audit.Recorder) Option {
+	return func(s *LLMProviderService) {
+		s.auditor = auditor
+	}
+}
+
+// WithUsage answers usage queries from the collector
+func WithUsage(collector *usage.Collector) Option {
+	return func(s *LLMProviderService) {
+		s.usage = collector
+	}
+}
+
+// WithoutRevisions stops recording the revision history of providers
+func WithoutRevisions() Option {
+	return func(s *LLMProviderService) {
+		s.noRevisions = true
+	}
+}
+
+// WithoutRollouts rejects staged rollouts of traffic splits
+func WithoutRollouts() Option {
+	return func(s *LLMProviderService) {
+		s.noRollouts = true
+	}
 }
 
 // NewLLMProviderService creates a new LLMProviderService
-func NewLLMProviderService(clientManager *client.Manager) *LLMProviderService {
-	return &LLMProviderService{
+func NewLLMProviderService(clientManager client.ManagerInterface, opts ...Option) *LLMProviderService {
+	s := &LLMProviderService{
 		clientManager: clientManager,
+		rollouts:      newRollouts(),
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
-// ListProviders returns all available LLM providers
-func (s *LLMProviderService) ListProviders(ctx context.Context, namespace string) ([]llm.LLMProvider, error) {
-	backends, err := s.clientManager.AIServiceBackend.List(ctx, namespace)
+// consoleIdentityKey marks contexts acting as the console itself rather than a user
+type consoleIdentityKey struct{}
+
+// clientsFor returns the clients to use for the request, impersonating its user if enabled
+func (s *LLMProviderService) clientsFor(ctx context.Context) (client.ManagerInterface, error) {
+	if !s.impersonate || ctx.Value(consoleIdentityKey{}) != nil {
+		return s.clientManager, nil
+	}
+
+	user, ok := auth.UserFromContext(ctx)
+	if !ok || user.Name == "" {
+		return nil, apierror.New(apierror.CodeUnauthenticated, "an authenticated user is required")
+	}
+
+	clients, err := s.clientManager.Impersonate(client.Identity{
+		UserName: user.Name,
+		UID:      user.UID,
+		Groups:   user.Groups,
+	})
 	if err != nil {
-		return []llm.LLMProvider{}, fmt.Errorf("failed to list AIServiceBackends: %w", err)
+		return nil, apierror.Wrap(apierror.CodeInternal, err, "failed to act as user %s", user.Name)
 	}
+	return clients, nil
+}
+
+// ListProviders returns the LLM providers matching the list options.
+// Listing client.AllNamespaces returns providers across the whole cluster.
+func (s *LLMProviderService) ListProviders(ctx context.Context, opts ListOptions) (_ *ProviderList, err error) {
+	ctx, span := startSpan(ctx, "ListProviders", "LLMProvider", strings.Join(opts.Namespaces, ","), "")
+	defer func() { endSpan(span, err) }()
 
 	// Initialize with empty slice to ensure we never return nil
-	providers := make([]llm.LLMProvider, 0)
+	result := &ProviderList{
+		Items: make([]llm.LLMProvider, 0),
+	}
+
+	if err := opts.Validate(); err != nil {
+		return result, err
+	}
+
+	backends, err := s.listAIServiceBackends(ctx, opts, result)
+	if err != nil {
+		return result, err
+	}
 
-	for _, backend := range backends.Items {
+	logger := logr.FromContextOrDiscard(ctx)
+	for i := range backends {
+		backend := &backends[i]
+		// A broken provider must not hide the others, it is reported with what can be recovered
 		resources, err := s.loadProviderResources(ctx, backend.Namespace, backend.Name)
+		stage := ProviderErrorStageLoad
+		var provider *llm.LLMProvider
+		if err == nil {
+			stage = ProviderErrorStageTranslate
+			provider, err = translateProvider(ctx, backend.Namespace, backend.Name, resources)
+		}
 		if err != nil {
-			// Log error but continue with other providers
-			continue
+			logger.Error(err, "LLM provider is broken", "namespace", backend.Namespace, "name", backend.Name, "stage", stage)
+			result.Errors = append(result.Errors, ProviderError{
+				Name:      backend.Name,
+				Namespace: backend.Namespace,
+				Stage:     stage,
+				Message:   apierror.MessageOf(err),
+			})
+			provider = llm.RecoverLLMProvider(backend, resources)
 		}
 
-		provider, err := llm.ToLLMProvider(resources)
-		if err != nil {
-			// Log error but continue with other providers
+		if !opts.matches(provider) {
 			continue
 		}
 
 		// Mask sensitive information before adding to the list
 		maskedProvider := provider.MaskSecret()
-		providers = append(providers, *maskedProvider)
+		result.Items = append(result.Items, *maskedProvider)
 	}
 
-	return providers, nil
+	opts.sort(result.Items)
+	result.Total = len(result.Items)
+
+	return result, nil
 }
 
-// GetProvider returns a specific LLM provider by namespace and name
-func (s *LLMProviderService) GetProvider(ctx context.Context, namespace, name string) (*llm.LLMProvider, error) {
-	resources, err := s.loadProviderResources(ctx, namespace, name)
+// Inventory lists the providers of the namespaces, or of the whole cluster for
+// client.AllNamespaces, as the console itself rather than as a user
+func (s *LLMProviderService) Inventory(ctx context.Context, namespaces []string) ([]llm.LLMProvider, error) {
+	list, err := s.ListProviders(context.WithValue(ctx, consoleIdentityKey{}, true), ListOptions{Namespaces: namespaces})
 	if err != nil {
 		return nil, err
 	}
+	return list.Items, nil
+}
 
+// translateProvider translates the resources of a provider, counting failures of API requests in
+// the console metrics. The inventory behind the provider metrics is not counted, so metric scrapes
+// do not inflate the failures.
+func translateProvider(ctx context.Context, namespace, name string, resources []interface{}) (*llm.LLMProvider, error) {
 	provider, err := llm.ToLLMProvider(resources)
+	if err != nil {
+		if ctx.Value(consoleIdentityKey{}) == nil {
+			metrics.TranslationFailures.Inc()
+		}
+		return nil, apierror.Wrap(apierror.CodeInternal, err, "failed to translate LLM provider %s/%s: %v", namespace, name, err)
+	}
+	return provider, nil
+}
+
+// listAIServiceBackends lists the AIServiceBackends in the namespaces selected by the list options.
+// Namespaces that cannot be read are recorded on the result instead of failing the call, so
+// the readable namespaces are returned.
+func (s *LLMProviderService) listAIServiceBackends(ctx context.Context, opts ListOptions, result *ProviderList) ([]aigatewayv1alpha1.AIServiceBackend, error) {
+	clients, err := s.clientsFor(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	listOpts, err := client.LabelSelectorOptions(opts.LabelSelector)
+	if err != nil {
+		return nil, apierror.Wrap(apierror.CodeInvalid, err, "%v", err)
+	}
+
+	namespaces := opts.Namespaces
+	if len(namespaces) == 1 {
+		// Paging is only possible against a single Kubernetes list call
+		pageOpts := append(listOpts, client.PageOptions(opts.Limit, opts.Continue)...)
+
+		list, err := clients.GetAIServiceBackendClient().List(ctx, namespaces[0], pageOpts...)
+		if err == nil {
+			result.Continue = list.Continue
+			result.RemainingItemCount = list.RemainingItemCount
+			return list.Items, nil
+		}
+		if !errors.IsForbidden(err) {
+			return nil, apierror.FromKubernetes(err, "failed to list AIServiceBackends")
+		}
+		if !client.IsAllNamespaces(namespaces[0]) {
+			result.NamespaceErrors = append(result.NamespaceErrors, newNamespaceError(namespaces[0], err))
+			return nil, nil
+		}
+
+		// Cluster-wide listing is not permitted, fall back to listing namespace by namespace.
+		// Paging is not supported in this mode so every readable namespace is returned at once.
+		// Users without the right to list namespaces, the usual case under impersonation, get
+		// the error instead of the providers.
+		namespaces, err = clients.ListNamespaces(ctx)
+		if errors.IsForbidden(err) {
+			result.NamespaceErrors = append(result.NamespaceErrors, newNamespaceError(client.AllNamespaces, err))
+			return nil, nil
+		}
+		if err != nil {
+			return nil, apierror.FromKubernetes(err, "failed to list AIServiceBackends in all namespaces")
+		}
+	}
+
+	var backends []aigatewayv1alpha1.AIServiceBackend
+	for _, namespace := range namespaces {
+		list, err := clients.GetAIServiceBackendClient().List(ctx, namespace, listOpts...)
+		if err != nil {
+			result.NamespaceErrors = append(result.NamespaceErrors, newNamespaceError(namespace, err))
+			continue
+		}
+		backends = append(backends, list.Items...)
+	}
+
+	return backends, nil
+}
+
+// GetProvider returns a specific LLM provider by namespace and name together with
+// the ETag of its underlying resources
+func (s *LLMProviderService) GetProvider(ctx context.Context, namespace, name string) (_ *llm.LLMProvider, _ string, err error) {
+	ctx, span := startSpan(ctx, "GetProvider", "LLMProvider", namespace, name)
+	defer func() { endSpan(span, err) }()
+
+	resources, err := s.loadProviderResources(ctx, namespace, name)
+	if err != nil {
+		return nil, "", err
+	}
+
+	provider, err := translateProvider(ctx, namespace, name, resources)
+	if err != nil {
+		return nil, "", err
+	}
+
 	// Mask sensitive information before returning
-	return provider.MaskSecret(), nil
+	return provider.MaskSecret(), providerETag(resources), nil
 }
 
 // CreateProvider creates a new LLM provider by converting it to Kubernetes resources
-func (s *LLMProviderService) CreateProvider(ctx context.Context, provider *llm.LLMProvider) error {
-	// Convert LLMProvider to Kubernetes resources
-	resources, err := provider.ToEnvoyGatewayResources()
+func (s *LLMProviderService) CreateProvider(ctx context.Context, provider *llm.LLMProvider) (err error) {
+	ctx, span := startSpan(ctx, "CreateProvider", "LLMProvider", provider.Namespace, provider.Name)
+	defer func() { endSpan(span, err) }()
+
+	err = s.createProvider(ctx, provider)
+	s.recordAudit(ctx, audit.ActionCreate, provider.Namespace, provider.Name, nil, provider.MaskSecret(), err)
+	if err == nil {
+		s.recordRevision(ctx, audit.ActionCreate, provider.MaskSecret())
+	}
+	return err
+}
+
+func (s *LLMProviderService) createProvider(ctx context.Context, provider *llm.LLMProvider) error {
+	clients, err := s.clientsFor(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Convert LLMProvider to Kubernetes resources of the versions served by the cluster
+	resources, err := provider.ToEnvoyGatewayResourcesFor(clients.APIVersions())
+	if err != nil {
+		return apierror.Wrap(apierror.CodeInvalid, err, "invalid LLM provider: %v", err)
+	}
+	routes, err := modelOverrideRoutes(ctx, clients, provider, nil)
 	if err != nil {
-		return fmt.Errorf("failed to convert provider to Kubernetes resources: %w", err)
+		return err
 	}
 
 	// Create each resource in the cluster
 	for _, resource := range resources {
 		switch r := resource.(type) {
 		case *gatewayv1alpha1.Backend:
-			err = s.clientManager.Backend.Create(ctx, r)
+			err = clients.GetBackendClient().Create(ctx, r)
 			if err != nil {
 				if errors.IsAlreadyExists(err) {
-					return fmt.Errorf("backend '%s' already exists. Please choose a different name or delete the existing backend first", r.Name)
+					return apierror.Wrap(apierror.CodeAlreadyExists, err, "backend '%s' already exists. Please choose a different name or delete the existing backend first", r.Name)
 				}
-				return fmt.Errorf("failed to create Backend: %w", err)
+				return apierror.FromKubernetes(err, "failed to create Backend %s/%s", r.Namespace, r.Name)
 			}
 
 		case *gwapiv1a3.BackendTLSPolicy:
-			err = s.clientManager.BackendTLSPolicy.Create(ctx, r)
+			err = clients.GetBackendTLSPolicyClient().Create(ctx, r)
 			if err != nil {
 				if errors.IsAlreadyExists(err) {
-					return fmt.Errorf("backend TLS policy '%s' already exists. Please choose a different name or delete the existing policy first", r.Name)
+					return apierror.Wrap(apierror.CodeAlreadyExists, err, "backend TLS policy '%s' already exists. Please choose a different name or delete the existing policy first", r.Name)
 				}
-				return fmt.Errorf("failed to create BackendTLSPolicy: %w", err)
+				return apierror.FromKubernetes(err, "failed to create BackendTLSPolicy %s/%s", r.Namespace, r.Name)
+			}
+
+		case *gatewayv1alpha1.BackendTrafficPolicy:
+			err = clients.GetBackendTrafficPolicyClient().Create(ctx, r)
+			if err != nil {
+				if errors.IsAlreadyExists(err) {
+					return apierror.Wrap(apierror.CodeAlreadyExists, err, "backend traffic policy '%s' already exists. Please choose a different name or delete the existing policy first", r.Name)
+				}
+				return apierror.FromKubernetes(err, "failed to create BackendTrafficPolicy %s/%s", r.Namespace, r.Name)
 			}
 
 		case *aigatewayv1alpha1.BackendSecurityPolicy:
-			err = s.clientManager.BackendSecurityPolicy.Create(ctx, r)
+			err = clients.GetBackendSecurityPolicyClient().Create(ctx, r)
 			if err != nil {
 				if errors.IsAlreadyExists(err) {
-					return fmt.Errorf("backend security policy '%s' already exists. Please choose a different name or delete the existing policy first", r.Name)
+					return apierror.Wrap(apierror.CodeAlreadyExists, err, "backend security policy '%s' already exists. Please choose a different name or delete the existing policy first", r.Name)
 				}
-				return fmt.Errorf("failed to create BackendSecurityPolicy: %w", err)
+				return apierror.FromKubernetes(err, "failed to create BackendSecurityPolicy %s/%s", r.Namespace, r.Name)
 			}
 
 		case *aigatewayv1alpha1.AIServiceBackend:
-			err = s.clientManager.AIServiceBackend.Create(ctx, r)
+			err = clients.GetAIServiceBackendClient().Create(ctx, r)
 			if err != nil {
 				if errors.IsAlreadyExists(err) {
-					return fmt.Errorf("AI service backend '%s' already exists. Please choose a different name or delete the existing provider first", r.Name)
+					return apierror.Wrap(apierror.CodeAlreadyExists, err, "AI service backend '%s' already exists. Please choose a different name or delete the existing provider first", r.Name)
 				}
-				return fmt.Errorf("failed to create AIServiceBackend: %w", err)
+				return apierror.FromKubernetes(err, "failed to create AIServiceBackend %s/%s", r.Namespace, r.Name)
 			}
 
 		case *corev1.Secret:
-			err = s.clientManager.Secret.Create(ctx, r)
+			err = clients.GetSecretClient().Create(ctx, r)
 			if err != nil {
 				if errors.IsAlreadyExists(err) {
-					return fmt.Errorf("secret '%s' already exists. Please choose a different name or delete the existing secret first", r.Name)
+					return apierror.Wrap(apierror.CodeAlreadyExists, err, "secret '%s' already exists. Please choose a different name or delete the existing secret first", r.Name)
 				}
-				return fmt.Errorf("failed to create Secret: %w", err)
+				return apierror.FromKubernetes(err, "failed to create Secret %s/%s", r.Namespace, r.Name)
 			}
 
 		default:
-			return fmt.Errorf("unknown resource type: %T", r)
+			return apierror.Internal("unknown resource type: %T", r)
 		}
 	}
 
-	return nil
+	return syncModelOverrides(ctx, clients, provider, nil, routes)
 }
 
-// DeleteProvider deletes an LLM provider by removing all its Kubernetes resources
-func (s *LLMProviderService) DeleteProvider(ctx context.Context, namespace, name string) error {
+// DeleteProvider deletes an LLM provider by removing all its Kubernetes resources.
+// A non-empty ifMatch must match the current ETag of the provider.
+func (s *LLMProviderService) DeleteProvider(ctx context.Context, namespace, name, ifMatch string) (err error) {
+	ctx, span := startSpan(ctx, "DeleteProvider", "LLMProvider", namespace, name)
+	defer func() { endSpan(span, err) }()
+
+	deleted, err := s.deleteProvider(ctx, namespace, name, ifMatch)
+	s.recordAudit(ctx, audit.ActionDelete, namespace, name, deleted, nil, err)
+	return err
+}
+
+// deleteProvider deletes the provider and returns it masked as it was before the deletion
+func (s *LLMProviderService) deleteProvider(ctx context.Context, namespace, name, ifMatch string) (*llm.LLMProvider, error) {
+	clients, err := s.clientsFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// Load all resources for this provider first
 	resources, err := s.loadProviderResources(ctx, namespace, name)
 	if err != nil {
-		return fmt.Errorf("failed to load provider resources for deletion: %w", err)
+		return nil, err
+	}
+
+	// Keep what is being deleted for the audit log, broken providers can still be deleted
+	var deleted *llm.LLMProvider
+	if provider, err := translateProvider(ctx, namespace, name, resources); err == nil {
+		deleted = provider.MaskSecret()
+	}
+
+	if err := checkETag(ifMatch, resources); err != nil {
+		return deleted, err
 	}
 
 	// Delete resources in reverse order to avoid dependency issues
@@ -155,9 +406,9 @@ func (s *LLMProviderService) DeleteProvider(ctx context.Context, namespace, name
 	for _, resource := range resources {
 		switch r := resource.(type) {
 		case *aigatewayv1alpha1.AIServiceBackend:
-			err = s.clientManager.AIServiceBackend.Delete(ctx, r.Namespace, r.Name)
+			err = clients.GetAIServiceBackendClient().Delete(ctx, r.Namespace, r.Name)
 			if err != nil {
-				return fmt.Errorf("failed to delete AIServiceBackend: %w", err)
+				return deleted, apierror.FromKubernetes(err, "failed to delete AIServiceBackend %s/%s", r.Namespace, r.Name)
 			}
 		}
 	}
@@ -166,20 +417,30 @@ func (s *LLMProviderService) DeleteProvider(ctx context.Context, namespace, name
 	for _, resource := range resources {
 		switch r := resource.(type) {
 		case *aigatewayv1alpha1.BackendSecurityPolicy:
-			err = s.clientManager.BackendSecurityPolicy.Delete(ctx, r.Namespace, r.Name)
+			err = clients.GetBackendSecurityPolicyClient().Delete(ctx, r.Namespace, r.Name)
+			if err != nil {
+				return deleted, apierror.FromKubernetes(err, "failed to delete BackendSecurityPolicy %s/%s", r.Namespace, r.Name)
+			}
+		}
+	}
+
+	// Then delete the policies attached to the Backend
+	for _, resource := range resources {
+		switch r := resource.(type) {
+		case *gatewayv1alpha1.BackendTrafficPolicy:
+			err = clients.GetBackendTrafficPolicyClient().Delete(ctx, r.Namespace, r.Name)
 			if err != nil {
-				return fmt.Errorf("failed to delete BackendSecurityPolicy: %w", err)
+				return deleted, apierror.FromKubernetes(err, "failed to delete BackendTrafficPolicy %s/%s", r.Namespace, r.Name)
 			}
 		}
 	}
 
-	// Then delete BackendTLSPolicy
 	for _, resource := range resources {
 		switch r := resource.(type) {
 		case *gwapiv1a3.BackendTLSPolicy:
-			err = s.clientManager.BackendTLSPolicy.Delete(ctx, r.Namespace, r.Name)
+			err = clients.GetBackendTLSPolicyClient().Delete(ctx, r.Namespace, r.Name)
 			if err != nil {
-				return fmt.Errorf("failed to delete BackendTLSPolicy: %w", err)
+				return deleted, apierror.FromKubernetes(err, "failed to delete BackendTLSPolicy %s/%s", r.Namespace, r.Name)
 			}
 		}
 	}
@@ -188,9 +449,9 @@ func (s *LLMProviderService) DeleteProvider(ctx context.Context, namespace, name
 	for _, resource := range resources {
 		switch r := resource.(type) {
 		case *gatewayv1alpha1.Backend:
-			err = s.clientManager.Backend.Delete(ctx, r.Namespace, r.Name)
+			err = clients.GetBackendClient().Delete(ctx, r.Namespace, r.Name)
 			if err != nil {
-				return fmt.Errorf("failed to delete Backend: %w", err)
+				return deleted, apierror.FromKubernetes(err, "failed to delete Backend %s/%s", r.Namespace, r.Name)
 			}
 		}
 	}
@@ -199,24 +460,35 @@ func (s *LLMProviderService) DeleteProvider(ctx context.Context, namespace, name
 	for _, resource := range resources {
 		switch r := resource.(type) {
 		case *corev1.Secret:
-			err = s.clientManager.Secret.Delete(ctx, r.Namespace, r.Name)
+			err = clients.GetSecretClient().Delete(ctx, r.Namespace, r.Name)
 			if err != nil {
-				return fmt.Errorf("failed to delete Secret: %w", err)
+				return deleted, apierror.FromKubernetes(err, "failed to delete Secret %s/%s", r.Namespace, r.Name)
 			}
 		}
 	}
 
-	return nil
+	return deleted, nil
 }
 
 // loadProviderResources loads all resources for a specific provider hierarchically
-func (s *LLMProviderService) loadProviderResources(ctx context.Context, namespace, name string) ([]interface{}, error) {
+func (s *LLMProviderService) loadProviderResources(ctx context.Context, namespace, name string) (_ []interface{}, err error) {
+	ctx, span := startSpan(ctx, "loadProviderResources", "LLMProvider", namespace, name)
+	defer func() { endSpan(span, err) }()
+
+	clients, err := s.clientsFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	var resources []interface{}
 
 	// 1. First load AIServiceBackend
-	aisb, err := s.clientManager.AIServiceBackend.Get(ctx, namespace, name)
+	aisb, err := clients.GetAIServiceBackendClient().Get(ctx, namespace, name)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get AIServiceBackend %s/%s: %w", namespace, name, err)
+		if errors.IsNotFound(err) {
+			return nil, apierror.Wrap(apierror.CodeNotFound, err, "LLM provider %s/%s not found", namespace, name)
+		}
+		return nil, apierror.FromKubernetes(err, "failed to get AIServiceBackend %s/%s", namespace, name)
 	}
 	resources = append(resources, aisb)
 
@@ -227,14 +499,14 @@ func (s *LLMProviderService) loadProviderResources(ctx context.Context, namespac
 		backendNamespace = string(*aisb.Spec.BackendRef.Namespace)
 	}
 
-	backend, err := s.clientManager.Backend.Get(ctx, backendNamespace, backendName)
+	backend, err := clients.GetBackendClient().Get(ctx, backendNamespace, backendName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get Backend %s/%s: %w", backendNamespace, backendName, err)
+		return nil, apierror.FromKubernetes(err, "failed to get Backend %s/%s", backendNamespace, backendName)
 	}
 	resources = append(resources, backend)
 
 	// 5. Based on Backend find BackendTLSPolicy with matching targetRefs.name
-	tlsPolicies, err := s.clientManager.BackendTLSPolicy.List(ctx, backendNamespace)
+	tlsPolicies, err := clients.GetBackendTLSPolicyClient().List(ctx, backendNamespace)
 	if err == nil {
 		for _, policy := range tlsPolicies.Items {
 			// Check if this TLS policy targets our backend
@@ -249,9 +521,25 @@ func (s *LLMProviderService) loadProviderResources(ctx context.Context, namespac
 		}
 	}
 
+	// Find the BackendTrafficPolicy carrying the traffic settings of the Backend.
+	// Rate limit policies target routes and are never matched here.
+	trafficPolicies, err := clients.GetBackendTrafficPolicyClient().List(ctx, backendNamespace)
+	if err == nil {
+		for _, policy := range trafficPolicies.Items {
+			for _, targetRef := range policy.Spec.TargetRefs {
+				if string(targetRef.Name) == backendName &&
+					string(targetRef.Kind) == "Backend" &&
+					string(targetRef.Group) == "gateway.envoyproxy.io" {
+					resources = append(resources, &policy)
+					break
+				}
+			}
+		}
+	}
+
 	// 3. Find BackendSecurityPolicy that targets this AIServiceBackend via targetRefs
 	// This replaces the deprecated BackendSecurityPolicyRef field
-	securityPolicies, err := s.clientManager.BackendSecurityPolicy.List(ctx, namespace)
+	securityPolicies, err := clients.GetBackendSecurityPolicyClient().List(ctx, namespace)
 	if err == nil {
 		for _, policy := range securityPolicies.Items {
 			// Check if this security policy targets our AIServiceBackend
@@ -264,7 +552,7 @@ func (s *LLMProviderService) loadProviderResources(ctx context.Context, namespac
 					// 4. Based on BackendSecurityPolicy.secretRef find secret
 					err = s.loadSecretsForAuthType(ctx, &policy, namespace, &resources)
 					if err != nil {
-						return nil, fmt.Errorf("failed to load secrets for auth type: %w", err)
+						return nil, err
 					}
 					break
 				}
@@ -277,10 +565,15 @@ func (s *LLMProviderService) loadProviderResources(ctx context.Context, namespac
 
 // loadSecretsForAuthType loads secrets based on the authentication type
 func (s *LLMProviderService) loadSecretsForAuthType(ctx context.Context, securityPolicy interface{}, namespace string, resources *[]interface{}) error {
+	clients, err := s.clientsFor(ctx)
+	if err != nil {
+		return err
+	}
+
 	// Type assertion to get the actual BackendSecurityPolicy
 	bsp, ok := securityPolicy.(*aigatewayv1alpha1.BackendSecurityPolicy)
 	if !ok {
-		return fmt.Errorf("invalid security policy type")
+		return apierror.Internal("invalid security policy type %T", securityPolicy)
 	}
 
 	switch bsp.Spec.Type {
@@ -293,7 +586,7 @@ func (s *LLMProviderService) loadSecretsForAuthType(ctx context.Context, securit
 				secretNamespace = string(*bsp.Spec.APIKey.SecretRef.Namespace)
 			}
 
-			secret, err := s.clientManager.Secret.Get(ctx, secretNamespace, secretName)
+			secret, err := clients.GetSecretClient().Get(ctx, secretNamespace, secretName)
 			if err == nil {
 				*resources = append(*resources, secret)
 			}
@@ -309,7 +602,7 @@ func (s *LLMProviderService) loadSecretsForAuthType(ctx context.Context, securit
 				secretNamespace = string(*clientSecret.Namespace)
 			}
 
-			secret, err := s.clientManager.Secret.Get(ctx, secretNamespace, secretName)
+			secret, err := clients.GetSecretClient().Get(ctx, secretNamespace, secretName)
 			if err == nil {
 				*resources = append(*resources, secret)
 			}
@@ -326,7 +619,7 @@ func (s *LLMProviderService) loadSecretsForAuthType(ctx context.Context, securit
 				secretNamespace = string(*bsp.Spec.AWSCredentials.CredentialsFile.SecretRef.Namespace)
 			}
 
-			secret, err := s.clientManager.Secret.Get(ctx, secretNamespace, secretName)
+			secret, err := clients.GetSecretClient().Get(ctx, secretNamespace, secretName)
 			if err == nil {
 				*resources = append(*resources, secret)
 			}
@@ -341,7 +634,7 @@ func (s *LLMProviderService) loadSecretsForAuthType(ctx context.Context, securit
 				secretNamespace = string(*bsp.Spec.AzureCredentials.ClientSecretRef.Namespace)
 			}
 
-			secret, err := s.clientManager.Secret.Get(ctx, secretNamespace, secretName)
+			secret, err := clients.GetSecretClient().Get(ctx, secretNamespace, secretName)
 			if err == nil {
 				*resources = append(*resources, secret)
 			}
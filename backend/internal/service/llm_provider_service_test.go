@@ -0,0 +1,223 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	aigatewayv1alpha1 "github.com/envoyproxy/ai-gateway/api/v1alpha1"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/apierror"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/metrics"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/client"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/llm"
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// newFakeService returns a service backed by a fake client holding the objects
+func newFakeService(t *testing.T, objs ...ctrlclient.Object) (*LLMProviderService, ctrlclient.Client) {
+	t.Helper()
+	scheme, err := client.NewScheme()
+	require.NoError(t, err)
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	return NewLLMProviderService(client.NewManagerWithClient(k8sClient, logr.Discard())), k8sClient
+}
+
+func openAIProvider(namespace, name string) *llm.LLMProvider {
+	return &llm.LLMProvider{
+		Name:      name,
+		Namespace: namespace,
+		Schema:    "OpenAI",
+		Version:   "v1",
+		Auth:      llm.AuthConfig{Type: "APIKey", APIKey: "sk-test"},
+		Backend:   llm.Backend{Host: "api.openai.com", Port: 443},
+		TLS:       llm.TLSValidation{Hostname: "api.openai.com", WellKnownCACertificates: "System"},
+	}
+}
+
+func TestProviderLifecycle(t *testing.T) {
+	s, k8sClient := newFakeService(t)
+	ctx := context.Background()
+
+	require.NoError(t, s.CreateProvider(ctx, openAIProvider("default", "openai")))
+
+	err := s.CreateProvider(ctx, openAIProvider("default", "openai"))
+	assert.True(t, apierror.Is(err, apierror.CodeAlreadyExists), "got %v", err)
+
+	list, err := s.ListProviders(ctx, ListOptions{Namespaces: []string{"default"}})
+	require.NoError(t, err)
+	require.Len(t, list.Items, 1)
+	assert.Equal(t, 1, list.Total)
+	provider := list.Items[0]
+	assert.Equal(t, "openai", provider.Name)
+	assert.Equal(t, "api.openai.com", provider.Backend.Host)
+	assert.Equal(t, llm.MaskedSecretValue, provider.Auth.APIKey)
+
+	got, etag, err := s.GetProvider(ctx, "default", "openai")
+	require.NoError(t, err)
+	assert.Equal(t, "api.openai.com", got.TLS.Hostname)
+	assert.NotEmpty(t, etag)
+
+	var secrets corev1.SecretList
+	require.NoError(t, k8sClient.List(ctx, &secrets, ctrlclient.InNamespace("default")))
+	assert.NotEmpty(t, secrets.Items)
+
+	err = s.DeleteProvider(ctx, "default", "openai", `"stale"`)
+	assert.True(t, apierror.Is(err, apierror.CodePreconditionFailed), "got %v", err)
+
+	require.NoError(t, s.DeleteProvider(ctx, "default", "openai", etag))
+
+	_, _, err = s.GetProvider(ctx, "default", "openai")
+	assert.True(t, apierror.Is(err, apierror.CodeNotFound), "got %v", err)
+	for _, secret := range secrets.Items {
+		err := k8sClient.Get(ctx, ctrlclient.ObjectKeyFromObject(&secret), &corev1.Secret{})
+		assert.True(t, errors.IsNotFound(err), "secret %s was not deleted", secret.Name)
+	}
+
+	list, err = s.ListProviders(ctx, ListOptions{Namespaces: []string{"default"}})
+	require.NoError(t, err)
+	assert.Empty(t, list.Items)
+
+	err = s.DeleteProvider(ctx, "default", "openai", "")
+	assert.True(t, apierror.Is(err, apierror.CodeNotFound), "got %v", err)
+}
+
+func TestListProvidersAcrossNamespaces(t *testing.T) {
+	s, _ := newFakeService(t)
+	ctx := context.Background()
+
+	require.NoError(t, s.CreateProvider(ctx, openAIProvider("team-b", "openai")))
+	require.NoError(t, s.CreateProvider(ctx, openAIProvider("team-a", "openai")))
+	require.NoError(t, s.CreateProvider(ctx, openAIProvider("team-a", "backup")))
+
+	list, err := s.ListProviders(ctx, ListOptions{Namespaces: []string{client.AllNamespaces}})
+	require.NoError(t, err)
+	var names []string
+	for _, provider := range list.Items {
+		names = append(names, provider.Namespace+"/"+provider.Name)
+	}
+	assert.Equal(t, []string{"team-a/backup", "team-a/openai", "team-b/openai"}, names)
+
+	list, err = s.ListProviders(ctx, ListOptions{Namespaces: []string{"team-b", "team-c"}})
+	require.NoError(t, err)
+	require.Len(t, list.Items, 1)
+	assert.Equal(t, "team-b", list.Items[0].Namespace)
+
+	_, err = s.ListProviders(ctx, ListOptions{})
+	assert.True(t, apierror.Is(err, apierror.CodeInvalid), "got %v", err)
+}
+
+func TestClientsForRequiresUser(t *testing.T) {
+	s, _ := newFakeService(t)
+	WithImpersonation()(s)
+
+	_, err := s.ListProviders(context.Background(), ListOptions{Namespaces: []string{"default"}})
+	assert.True(t, apierror.Is(err, apierror.CodeUnauthenticated), "got %v", err)
+}
+
+func TestListProvidersReportsBrokenProviders(t *testing.T) {
+	s, k8sClient := newFakeService(t)
+	ctx := context.Background()
+	require.NoError(t, s.CreateProvider(ctx, openAIProvider("default", "openai")))
+	require.NoError(t, s.CreateProvider(ctx, openAIProvider("default", "untranslatable")))
+
+	// A provider whose Backend is gone fails to load, one without its security policy fails to translate
+	require.NoError(t, k8sClient.Create(ctx, &aigatewayv1alpha1.AIServiceBackend{
+		ObjectMeta: metav1.ObjectMeta{Name: "unloadable", Namespace: "default"},
+		Spec: aigatewayv1alpha1.AIServiceBackendSpec{
+			APISchema:  aigatewayv1alpha1.VersionedAPISchema{Name: aigatewayv1alpha1.APISchemaOpenAI},
+			BackendRef: gwapiv1.BackendObjectReference{Name: "missing"},
+		},
+	}))
+	var policies aigatewayv1alpha1.BackendSecurityPolicyList
+	require.NoError(t, k8sClient.List(ctx, &policies, ctrlclient.InNamespace("default")))
+	for _, policy := range policies.Items {
+		if strings.HasPrefix(policy.Name, "untranslatable") {
+			require.NoError(t, k8sClient.Delete(ctx, &policy))
+		}
+	}
+
+	failures := testutil.ToFloat64(metrics.TranslationFailures)
+	list, err := s.ListProviders(ctx, ListOptions{Namespaces: []string{"default"}})
+	require.NoError(t, err)
+	assert.InDelta(t, failures+1, testutil.ToFloat64(metrics.TranslationFailures), 0)
+	require.Len(t, list.Items, 3)
+	statuses := map[string]string{}
+	for _, provider := range list.Items {
+		statuses[provider.Name] = provider.Status
+	}
+	assert.Equal(t, llm.StatusBroken, statuses["unloadable"])
+	assert.Equal(t, llm.StatusBroken, statuses["untranslatable"])
+	assert.NotEqual(t, llm.StatusBroken, statuses["openai"])
+
+	require.Len(t, list.Errors, 2)
+	stages := map[string]ProviderError{}
+	for _, providerError := range list.Errors {
+		stages[providerError.Name] = providerError
+	}
+	assert.Equal(t, ProviderErrorStageLoad, stages["unloadable"].Stage)
+	assert.Equal(t, ProviderErrorStageTranslate, stages["untranslatable"].Stage)
+	assert.Contains(t, stages["untranslatable"].Message, "default/untranslatable")
+
+	for _, provider := range list.Items {
+		if provider.Name == "untranslatable" {
+			assert.Equal(t, "OpenAI", provider.Schema)
+			assert.Equal(t, "api.openai.com", provider.Backend.Host)
+		}
+	}
+
+	// Scrapes of the provider metrics are not requests hitting a broken provider
+	inventory, err := s.Inventory(ctx, []string{"default"})
+	require.NoError(t, err)
+	assert.Len(t, inventory, 3)
+	assert.InDelta(t, failures+1, testutil.ToFloat64(metrics.TranslationFailures), 0)
+}
+
+func TestGetProviderSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	s, _ := newFakeService(t)
+	ctx := context.Background()
+	require.NoError(t, s.CreateProvider(ctx, openAIProvider("default", "openai")))
+	_, _, err := s.GetProvider(ctx, "default", "missing")
+	require.Error(t, err)
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+	get := spans["LLMProviderService.GetProvider"]
+	require.NotNil(t, get)
+	assert.Contains(t, get.Attributes(), attribute.String("console.resource.name", "missing"))
+	assert.Contains(t, get.Attributes(), attribute.String("console.outcome", string(apierror.CodeNotFound)))
+	assert.Equal(t, codes.Error, get.Status().Code)
+
+	// Kubernetes calls are children of the service operation
+	load := spans["LLMProviderService.loadProviderResources"]
+	require.NotNil(t, load)
+	assert.Equal(t, get.SpanContext().SpanID(), load.Parent().SpanID())
+	k8sGet := spans["get AIServiceBackend"]
+	require.NotNil(t, k8sGet)
+	assert.Equal(t, load.SpanContext().SpanID(), k8sGet.Parent().SpanID())
+	assert.Contains(t, k8sGet.Attributes(), attribute.String("k8s.result", "NotFound"))
+	assert.Contains(t, k8sGet.Attributes(), attribute.String("k8s.namespace.name", "default"))
+}
@@ -0,0 +1,115 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/apierror"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/client"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/llm"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/retry"
+)
+
+// ModelList is the table of logical model names and the upstream names providers receive
+type ModelList struct {
+	Items []llm.ModelRoute `json:"items"`
+	// NamespaceErrors reports namespaces that could not be read, e.g. due to RBAC
+	NamespaceErrors []NamespaceError `json:"namespaceErrors,omitempty"`
+}
+
+// ListModels returns the model table of the namespaces, joining the model mappings of the
+// providers with the rules of the AIGatewayRoutes
+func (s *LLMProviderService) ListModels(ctx context.Context, namespaces []string) (_ *ModelList, err error) {
+	ctx, span := startSpan(ctx, "ListModels", "Model", strings.Join(namespaces, ","), "")
+	defer func() { endSpan(span, err) }()
+
+	result := &ModelList{Items: make([]llm.ModelRoute, 0)}
+
+	clients, err := s.clientsFor(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	for _, namespace := range namespaces {
+		rows, err := namespaceModels(ctx, clients, namespace)
+		if err != nil {
+			if errors.IsForbidden(err) {
+				result.NamespaceErrors = append(result.NamespaceErrors, newNamespaceError(namespace, err))
+				continue
+			}
+			return result, apierror.FromKubernetes(err, "failed to list models in namespace %s", namespace)
+		}
+		result.Items = append(result.Items, rows...)
+	}
+	return result, nil
+}
+
+// namespaceModels builds the model table of one namespace
+func namespaceModels(ctx context.Context, clients client.ManagerInterface, namespace string) ([]llm.ModelRoute, error) {
+	backends, err := clients.GetAIServiceBackendClient().List(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	routes, err := clients.GetAIGatewayRouteClient().List(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	providers := map[string][]llm.ModelMapping{}
+	for i := range backends.Items {
+		// Unreadable mappings are left out rather than failing the whole table
+		if models, err := llm.ModelMappings(&backends.Items[i]); err == nil {
+			providers[backends.Items[i].Name] = models
+		}
+	}
+	return llm.ModelTable(namespace, providers, routes.Items), nil
+}
+
+// modelOverrideRoutes returns the AIGatewayRoutes whose model overrides change with the model
+// mappings of the provider. It runs before any resource of the provider is written, so routes
+// that cannot be read fail the request while nothing has changed yet.
+func modelOverrideRoutes(ctx context.Context, clients client.ManagerInterface, provider *llm.LLMProvider, previous []llm.ModelMapping) ([]string, error) {
+	if slices.Equal(provider.Models, previous) {
+		return nil, nil
+	}
+
+	routes, err := clients.GetAIGatewayRouteClient().List(ctx, provider.Namespace)
+	if err != nil {
+		return nil, apierror.FromKubernetes(err, "failed to list AIGatewayRoutes in namespace %s", provider.Namespace)
+	}
+	var names []string
+	for i := range routes.Items {
+		if llm.ApplyModelOverrides(routes.Items[i].DeepCopy(), provider.Name, provider.Models, previous) {
+			names = append(names, routes.Items[i].Name)
+		}
+	}
+	return names, nil
+}
+
+// syncModelOverrides applies the model mappings of the provider to the modelNameOverride of the
+// routes found by modelOverrideRoutes, clearing the overrides of mappings removed since previous
+func syncModelOverrides(ctx context.Context, clients client.ManagerInterface, provider *llm.LLMProvider, previous []llm.ModelMapping, routes []string) error {
+	for _, name := range routes {
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			current, err := clients.GetAIGatewayRouteClient().Get(ctx, provider.Namespace, name)
+			if err != nil {
+				return err
+			}
+			if !llm.ApplyModelOverrides(current, provider.Name, provider.Models, previous) {
+				return nil
+			}
+			return clients.GetAIGatewayRouteClient().Update(ctx, current)
+		})
+		if err != nil && !errors.IsNotFound(err) {
+			return apierror.FromKubernetes(err, "failed to update the model overrides of AIGatewayRoute %s/%s", provider.Namespace, name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	aigatewayv1alpha1 "github.com/envoyproxy/ai-gateway/api/v1alpha1"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/apierror"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/client"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/llm"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// modelRoute returns a route sending gpt-4o to openai
+func modelRoute() *aigatewayv1alpha1.AIGatewayRoute {
+	route := chatRoute()
+	route.Spec.Rules = []aigatewayv1alpha1.AIGatewayRouteRule{{
+		Matches: []aigatewayv1alpha1.AIGatewayRouteRuleMatch{{
+			Headers: []gwapiv1.HTTPHeaderMatch{{Name: aigatewayv1alpha1.AIModelHeaderKey, Value: "gpt-4o"}},
+		}},
+		BackendRefs: []aigatewayv1alpha1.AIGatewayRouteRuleBackendRef{{Name: "openai"}},
+	}}
+	return route
+}
+
+// assertNothingWritten fails if any resource of the provider was created
+func assertNothingWritten(t *testing.T, k8sClient ctrlclient.Client) {
+	t.Helper()
+	var secrets corev1.SecretList
+	require.NoError(t, k8sClient.List(context.Background(), &secrets, ctrlclient.InNamespace("default")))
+	assert.Empty(t, secrets.Items)
+	var backends aigatewayv1alpha1.AIServiceBackendList
+	require.NoError(t, k8sClient.List(context.Background(), &backends, ctrlclient.InNamespace("default")))
+	assert.Empty(t, backends.Items)
+}
+
+func TestCreateProviderSetsModelOverrides(t *testing.T) {
+	s, k8sClient := newFakeService(t, modelRoute())
+	ctx := context.Background()
+
+	provider := openAIProvider("default", "openai")
+	provider.Models = []llm.ModelMapping{{Name: "gpt-4o", Upstream: "gpt-4o-2024-08-06"}}
+	require.NoError(t, s.CreateProvider(ctx, provider))
+
+	var route aigatewayv1alpha1.AIGatewayRoute
+	require.NoError(t, k8sClient.Get(ctx, ctrlclient.ObjectKey{Namespace: "default", Name: "chat"}, &route))
+	assert.Equal(t, "gpt-4o-2024-08-06", route.Spec.Rules[0].BackendRefs[0].ModelNameOverride)
+}
+
+func TestCreateProviderChecksRoutesBeforeWriting(t *testing.T) {
+	scheme, err := client.NewScheme()
+	require.NoError(t, err)
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(modelRoute()).WithInterceptorFuncs(interceptor.Funcs{
+		List: func(ctx context.Context, c ctrlclient.WithWatch, list ctrlclient.ObjectList, opts ...ctrlclient.ListOption) error {
+			if _, ok := list.(*aigatewayv1alpha1.AIGatewayRouteList); ok {
+				return errors.NewForbidden(schema.GroupResource{Group: llm.GroupAIGatewayEnvoyProxy, Resource: "aigatewayroutes"}, "", nil)
+			}
+			return c.List(ctx, list, opts...)
+		},
+	}).Build()
+	s := NewLLMProviderService(client.NewManagerWithClient(k8sClient, logr.Discard()))
+	t.Cleanup(s.Close)
+
+	provider := openAIProvider("default", "openai")
+	provider.Models = []llm.ModelMapping{{Name: "gpt-4o", Upstream: "gpt-4o-2024-08-06"}}
+	err = s.CreateProvider(context.Background(), provider)
+	assert.True(t, apierror.Is(err, apierror.CodeForbidden), "got %v", err)
+	assertNothingWritten(t, k8sClient)
+}
+
+func TestUpdateProviderClearsModelOverrides(t *testing.T) {
+	s, k8sClient := newFakeService(t, modelRoute())
+	ctx := context.Background()
+
+	provider := openAIProvider("default", "openai")
+	provider.Models = []llm.ModelMapping{{Name: "gpt-4o", Upstream: "gpt-4o-2024-08-06"}}
+	require.NoError(t, s.CreateProvider(ctx, provider))
+
+	provider.Models = nil
+	_, err := s.UpdateProvider(ctx, provider, "")
+	require.NoError(t, err)
+
+	var route aigatewayv1alpha1.AIGatewayRoute
+	require.NoError(t, k8sClient.Get(ctx, ctrlclient.ObjectKey{Namespace: "default", Name: "chat"}, &route))
+	assert.Empty(t, route.Spec.Rules[0].BackendRefs[0].ModelNameOverride)
+	var backend aigatewayv1alpha1.AIServiceBackend
+	require.NoError(t, k8sClient.Get(ctx, ctrlclient.ObjectKey{Namespace: "default", Name: "openai"}, &backend))
+	assert.NotContains(t, backend.Annotations, llm.AnnotationModels)
+}
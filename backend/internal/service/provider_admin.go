@@ -0,0 +1,170 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package service
+
+import (
+	"context"
+	"strings"
+
+	aigatewayv1alpha1 "github.com/envoyproxy/ai-gateway/api/v1alpha1"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/apierror"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/audit"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/llm"
+	gatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	gwapiv1a3 "sigs.k8s.io/gateway-api/apis/v1alpha3"
+)
+
+// GetProviderManifests returns the Kubernetes resources making up an LLM provider.
+// Secret values are masked unless includeSecrets is set.
+func (s *LLMProviderService) GetProviderManifests(ctx context.Context, namespace, name string, includeSecrets bool) (_ []any, err error) {
+	ctx, span := startSpan(ctx, "GetProviderManifests", "LLMProvider", namespace, name)
+	defer func() { endSpan(span, err) }()
+
+	resources, err := s.loadProviderResources(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := s.clientManager.APIVersions()
+	manifests := make([]any, 0, len(resources))
+	for _, resource := range resources {
+		switch r := resource.(type) {
+		case *aigatewayv1alpha1.AIServiceBackend:
+			r = r.DeepCopy()
+			r.ManagedFields = nil
+			r.APIVersion, r.Kind = versions.AIGatewayAPIVersion(), llm.KindAIServiceBackend
+			manifests = append(manifests, r)
+		case *aigatewayv1alpha1.BackendSecurityPolicy:
+			r = r.DeepCopy()
+			r.ManagedFields = nil
+			r.APIVersion, r.Kind = versions.AIGatewayAPIVersion(), llm.KindBackendSecurityPolicy
+			manifests = append(manifests, r)
+		case *gatewayv1alpha1.Backend:
+			r = r.DeepCopy()
+			r.ManagedFields = nil
+			r.APIVersion, r.Kind = llm.APIVersionGatewayV1Alpha1, llm.KindBackend
+			manifests = append(manifests, r)
+		case *gatewayv1alpha1.BackendTrafficPolicy:
+			r = r.DeepCopy()
+			r.ManagedFields = nil
+			r.APIVersion, r.Kind = llm.APIVersionGatewayV1Alpha1, llm.KindBackendTrafficPolicy
+			manifests = append(manifests, r)
+		case *gwapiv1a3.BackendTLSPolicy:
+			r = r.DeepCopy()
+			r.ManagedFields = nil
+			r.APIVersion, r.Kind = versions.BackendTLSPolicyAPIVersion(), llm.KindBackendTLSPolicy
+			manifests = append(manifests, r)
+		case *corev1.Secret:
+			r = r.DeepCopy()
+			r.ManagedFields = nil
+			r.APIVersion, r.Kind = llm.APIVersionV1, llm.KindSecret
+			if !includeSecrets {
+				maskSecretData(r)
+			}
+			manifests = append(manifests, r)
+		}
+	}
+
+	return manifests, nil
+}
+
+// maskSecretData replaces every value of the secret with llm.MaskedSecretValue
+func maskSecretData(secret *corev1.Secret) {
+	for key := range secret.Data {
+		secret.Data[key] = []byte(llm.MaskedSecretValue)
+	}
+	for key := range secret.StringData {
+		secret.StringData[key] = llm.MaskedSecretValue
+	}
+}
+
+// RotateCredentials replaces the credentials stored in the Secret of an LLM provider.
+// The authentication type cannot change; everything but the Secret is left untouched.
+// A non-empty ifMatch must match the current ETag of the provider.
+func (s *LLMProviderService) RotateCredentials(ctx context.Context, namespace, name string, credentials llm.AuthConfig, ifMatch string) (_ *llm.LLMProvider, err error) {
+	ctx, span := startSpan(ctx, "RotateCredentials", "LLMProvider", namespace, name)
+	defer func() { endSpan(span, err) }()
+
+	before, after, err := s.rotateCredentials(ctx, namespace, name, credentials, ifMatch)
+	s.recordAudit(ctx, audit.ActionRotate, namespace, name, before, after, err)
+	if err == nil {
+		s.recordRevision(ctx, audit.ActionRotate, after)
+	}
+	return after, err
+}
+
+// rotateCredentials rotates the credentials and returns the masked provider before and after
+func (s *LLMProviderService) rotateCredentials(ctx context.Context, namespace, name string, credentials llm.AuthConfig, ifMatch string) (*llm.LLMProvider, *llm.LLMProvider, error) {
+	clients, err := s.clientsFor(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resources, err := s.loadProviderResources(ctx, namespace, name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	provider, err := translateProvider(ctx, namespace, name, resources)
+	if err != nil {
+		return nil, nil, err
+	}
+	before := provider.MaskSecret()
+
+	if err := checkETag(ifMatch, resources); err != nil {
+		return before, nil, err
+	}
+
+	if credentials.Type == "" {
+		credentials.Type = provider.Auth.Type
+	}
+	if !strings.EqualFold(credentials.Type, provider.Auth.Type) {
+		return before, nil, apierror.Invalid("cannot change the authentication type of %s/%s from %s to %s", namespace, name, provider.Auth.Type, credentials.Type)
+	}
+
+	var current *corev1.Secret
+	for _, resource := range resources {
+		if secret, ok := resource.(*corev1.Secret); ok {
+			current = secret
+		}
+	}
+	if current == nil {
+		return before, nil, apierror.Invalid("LLM provider %s/%s has no credentials secret to rotate", namespace, name)
+	}
+
+	// Render the provider with the new credentials inline to get the new secret content
+	rotated := *provider
+	rotated.Auth = credentials
+	rotated.Auth.SecretRef = nil
+	rendered, err := rotated.ToEnvoyGatewayResources()
+	if err != nil {
+		return before, nil, apierror.Wrap(apierror.CodeInvalid, err, "invalid credentials: %v", err)
+	}
+
+	var desired *corev1.Secret
+	for _, resource := range rendered {
+		if secret, ok := resource.(*corev1.Secret); ok {
+			desired = secret
+		}
+	}
+	if desired == nil {
+		return before, nil, apierror.Invalid("no credentials supplied for authentication type %s", provider.Auth.Type)
+	}
+
+	updated := current.DeepCopy()
+	updated.StringData = desired.StringData
+	for key := range desired.StringData {
+		// StringData wins over Data, drop the stale value so no copy of it is sent back
+		delete(updated.Data, key)
+	}
+	if err := clients.GetSecretClient().Update(ctx, updated); err != nil {
+		return before, nil, apierror.FromKubernetes(err, "failed to update Secret %s/%s", updated.Namespace, updated.Name)
+	}
+
+	rotated.Auth.SecretRef = provider.Auth.SecretRef
+	return before, rotated.MaskSecret(), nil
+}
@@ -0,0 +1,172 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package service
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/apierror"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/client"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/llm"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Sort keys supported by ListProviders
+const (
+	SortByName              = "name"
+	SortBySchema            = "schema"
+	SortByCreationTimestamp = "creationTimestamp"
+	SortByStatus            = "status"
+)
+
+// ProviderList is the result of listing LLM providers across one or more namespaces
+type ProviderList struct {
+	Items []llm.LLMProvider `json:"items"`
+	// Total is the number of items in this response, not in the whole list: the filters
+	// apply to each page after it is read, so the count across pages is not known
+	Total int `json:"total"`
+	// Continue is the token for fetching the next page, empty on the last page. A page
+	// can hold fewer than limit items, or none, while Continue is set.
+	Continue string `json:"continue,omitempty"`
+	// RemainingItemCount is the number of AIServiceBackends after this page as estimated by Kubernetes
+	RemainingItemCount *int64 `json:"remainingItemCount,omitempty"`
+	// NamespaceErrors reports namespaces that could not be read, e.g. due to RBAC
+	NamespaceErrors []NamespaceError `json:"namespaceErrors,omitempty"`
+	// Errors reports the providers of this page that could not be loaded or translated.
+	// They are listed whether or not they match the filters; matching ones are also
+	// items with the Broken status.
+	Errors []ProviderError `json:"errors,omitempty"`
+}
+
+const (
+	// ProviderErrorStageLoad is a failure to read the resources of the provider
+	ProviderErrorStageLoad = "load"
+	// ProviderErrorStageTranslate is a failure to translate the resources to a provider
+	ProviderErrorStageTranslate = "translate"
+)
+
+// ProviderError describes a provider that is broken
+type ProviderError struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	// Stage is load or translate
+	Stage   string `json:"stage"`
+	Message string `json:"message"`
+}
+
+// NamespaceError describes a namespace whose providers could not be listed
+type NamespaceError struct {
+	Namespace string `json:"namespace"`
+	Reason    string `json:"reason"`
+	Message   string `json:"message"`
+}
+
+func newNamespaceError(namespace string, err error) NamespaceError {
+	return NamespaceError{
+		Namespace: namespace,
+		Reason:    string(errors.ReasonForError(err)),
+		Message:   err.Error(),
+	}
+}
+
+// ListOptions controls which providers ListProviders returns and in which order
+type ListOptions struct {
+	// Namespaces to list, a single client.AllNamespaces entry lists the whole cluster
+	Namespaces []string
+
+	// Limit and Continue page through the underlying AIServiceBackend list call.
+	// Paging requires a single namespace or all namespaces. Filters and sorting apply
+	// within each page, so filtered pages may be short or empty.
+	Limit    int64
+	Continue string
+
+	// LabelSelector is passed through to Kubernetes
+	LabelSelector string
+	// Schema and AuthType match case-insensitively, Host matches any substring of the backend host
+	Schema   string
+	AuthType string
+	Host     string
+
+	// SortBy is one of the SortBy* keys, defaults to sorting by namespace and name
+	SortBy     string
+	Descending bool
+}
+
+// Validate checks that the list options can be served
+func (o ListOptions) Validate() error {
+	if len(o.Namespaces) == 0 {
+		return apierror.Invalid("at least one namespace is required")
+	}
+	if o.Limit < 0 {
+		return apierror.Invalid("limit must not be negative")
+	}
+	if len(o.Namespaces) > 1 && (o.Limit > 0 || o.Continue != "") {
+		return apierror.Invalid("pagination requires a single namespace or all namespaces (%s)", client.AllNamespaces)
+	}
+	switch o.SortBy {
+	case "", SortByName, SortBySchema, SortByCreationTimestamp, SortByStatus:
+	default:
+		return apierror.Invalid("unsupported sort key %q", o.SortBy)
+	}
+	if _, err := client.LabelSelectorOptions(o.LabelSelector); err != nil {
+		return apierror.Wrap(apierror.CodeInvalid, err, "%v", err)
+	}
+	return nil
+}
+
+// matches reports whether the provider satisfies the schema, auth type and host filters
+func (o ListOptions) matches(provider *llm.LLMProvider) bool {
+	if o.Schema != "" && !strings.EqualFold(provider.Schema, o.Schema) {
+		return false
+	}
+	if o.AuthType != "" && !strings.EqualFold(provider.Auth.Type, o.AuthType) {
+		return false
+	}
+	if o.Host != "" && !strings.Contains(strings.ToLower(provider.Backend.Host), strings.ToLower(o.Host)) {
+		return false
+	}
+	return true
+}
+
+// sort orders the providers by the sort key, breaking ties by namespace and name
+func (o ListOptions) sort(providers []llm.LLMProvider) {
+	sort.SliceStable(providers, func(i, j int) bool {
+		a, b := &providers[i], &providers[j]
+		if o.Descending {
+			a, b = b, a
+		}
+
+		switch o.SortBy {
+		case SortBySchema:
+			if a.Schema != b.Schema {
+				return a.Schema < b.Schema
+			}
+		case SortByStatus:
+			if a.Status != b.Status {
+				return a.Status < b.Status
+			}
+		case SortByCreationTimestamp:
+			at, bt := a.CreatedAt, b.CreatedAt
+			switch {
+			case at == nil && bt != nil:
+				return true
+			case at != nil && bt == nil:
+				return false
+			case at != nil && bt != nil && !at.Equal(bt):
+				return at.Before(bt)
+			}
+		}
+
+		if a.Namespace != b.Namespace && o.SortBy != SortByName {
+			return a.Namespace < b.Namespace
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Namespace < b.Namespace
+	})
+}
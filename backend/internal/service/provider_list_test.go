@@ -0,0 +1,263 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	aigatewayv1alpha1 "github.com/envoyproxy/ai-gateway/api/v1alpha1"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/client"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/llm"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// newPagingService returns a service whose fake client pages AIServiceBackend lists
+// like the API server, the continue token being the index of the next item
+func newPagingService(t *testing.T) (*LLMProviderService, ctrlclient.Client) {
+	t.Helper()
+	scheme, err := client.NewScheme()
+	require.NoError(t, err)
+	k8sClient := interceptor.NewClient(fake.NewClientBuilder().WithScheme(scheme).Build(), interceptor.Funcs{
+		List: func(ctx context.Context, c ctrlclient.WithWatch, list ctrlclient.ObjectList, opts ...ctrlclient.ListOption) error {
+			backends, ok := list.(*aigatewayv1alpha1.AIServiceBackendList)
+			listOpts := (&ctrlclient.ListOptions{}).ApplyOptions(opts)
+			if !ok || (listOpts.Limit == 0 && listOpts.Continue == "") {
+				return c.List(ctx, list, opts...)
+			}
+			limit, token := listOpts.Limit, listOpts.Continue
+			listOpts.Limit, listOpts.Continue = 0, ""
+			if err := c.List(ctx, backends, listOpts); err != nil {
+				return err
+			}
+			start := 0
+			if token != "" {
+				start, _ = strconv.Atoi(token)
+			}
+			end := len(backends.Items)
+			if limit > 0 {
+				end = min(start+int(limit), end)
+			}
+			if end < len(backends.Items) {
+				backends.Continue = strconv.Itoa(end)
+			}
+			backends.Items = backends.Items[start:end]
+			return nil
+		},
+	})
+	return NewLLMProviderService(client.NewManagerWithClient(k8sClient, logr.Discard())), k8sClient
+}
+
+// createListedProviders creates providers with distinct schemas, auth types, hosts,
+// creation times, labels and statuses, named in creation order
+func createListedProviders(t *testing.T, s *LLMProviderService, k8sClient ctrlclient.Client) {
+	t.Helper()
+	ctx := context.Background()
+
+	openai := openAIProvider("default", "a-openai")
+	bedrock := &llm.LLMProvider{
+		Name: "b-bedrock", Namespace: "default", Schema: "AWSBedrock",
+		Auth:    llm.AuthConfig{Type: llm.AuthTypeAWS, AWS: &llm.AWSAuth{Region: "us-east-1", AccessKeyID: "id", SecretAccessKey: "key"}},
+		Backend: llm.Backend{Host: "bedrock-runtime.us-east-1.amazonaws.com", Port: 443},
+	}
+	azure := openAIProvider("default", "c-azure")
+	azure.Schema = "AzureOpenAI"
+	azure.Backend.Host, azure.TLS.Hostname = "example.openai.azure.com", "example.openai.azure.com"
+
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	// Creation times run against the names, statuses are set on two providers
+	for i, provider := range []struct {
+		*llm.LLMProvider
+		age    time.Duration
+		status string
+	}{
+		{openai, 2 * time.Hour, "Accepted"},
+		{bedrock, time.Hour, ""},
+		{azure, 0, "NotAccepted"},
+	} {
+		require.NoError(t, s.CreateProvider(ctx, provider.LLMProvider), "provider %d", i)
+
+		var aisb aigatewayv1alpha1.AIServiceBackend
+		require.NoError(t, k8sClient.Get(ctx, ctrlclient.ObjectKey{Namespace: "default", Name: provider.Name}, &aisb))
+		aisb.CreationTimestamp = metav1.NewTime(created.Add(-provider.age))
+		aisb.Labels = map[string]string{"tier": strconv.Itoa(i)}
+		if provider.status != "" {
+			aisb.Status.Conditions = []metav1.Condition{{
+				Type: provider.status, Status: metav1.ConditionTrue, Reason: provider.status, LastTransitionTime: metav1.NewTime(created),
+			}}
+		}
+		require.NoError(t, k8sClient.Update(ctx, &aisb))
+	}
+}
+
+func providerNames(list *ProviderList) []string {
+	names := make([]string, 0, len(list.Items))
+	for _, provider := range list.Items {
+		names = append(names, provider.Name)
+	}
+	return names
+}
+
+func TestListProvidersPaging(t *testing.T) {
+	s, k8sClient := newPagingService(t)
+	createListedProviders(t, s, k8sClient)
+	ctx := context.Background()
+
+	var pages [][]string
+	opts := ListOptions{Namespaces: []string{"default"}, Limit: 2}
+	for {
+		list, err := s.ListProviders(ctx, opts)
+		require.NoError(t, err)
+		assert.Equal(t, len(list.Items), list.Total, "total counts the providers of the page")
+		pages = append(pages, providerNames(list))
+		if list.Continue == "" {
+			break
+		}
+		opts.Continue = list.Continue
+	}
+	assert.Equal(t, [][]string{{"a-openai", "b-bedrock"}, {"c-azure"}}, pages)
+
+	// Filters apply within the page, which can come back empty with a continue token
+	list, err := s.ListProviders(ctx, ListOptions{Namespaces: []string{"default"}, Limit: 1, Schema: "AzureOpenAI"})
+	require.NoError(t, err)
+	assert.Empty(t, list.Items)
+	assert.Zero(t, list.Total)
+	assert.NotEmpty(t, list.Continue)
+
+	_, err = s.ListProviders(ctx, ListOptions{Namespaces: []string{"default", "other"}, Limit: 1})
+	assert.Error(t, err, "paging needs a single list call")
+}
+
+func TestListProvidersSorting(t *testing.T) {
+	s, k8sClient := newPagingService(t)
+	createListedProviders(t, s, k8sClient)
+	ctx := context.Background()
+
+	tests := []struct {
+		sortBy     string
+		descending bool
+		want       []string
+	}{
+		{sortBy: "", want: []string{"a-openai", "b-bedrock", "c-azure"}},
+		{sortBy: SortByName, descending: true, want: []string{"c-azure", "b-bedrock", "a-openai"}},
+		{sortBy: SortBySchema, want: []string{"b-bedrock", "c-azure", "a-openai"}},
+		{sortBy: SortByCreationTimestamp, want: []string{"a-openai", "b-bedrock", "c-azure"}},
+		{sortBy: SortByCreationTimestamp, descending: true, want: []string{"c-azure", "b-bedrock", "a-openai"}},
+		{sortBy: SortByStatus, want: []string{"b-bedrock", "a-openai", "c-azure"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sortBy+"/"+strconv.FormatBool(tt.descending), func(t *testing.T) {
+			list, err := s.ListProviders(ctx, ListOptions{Namespaces: []string{"default"}, SortBy: tt.sortBy, Descending: tt.descending})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, providerNames(list))
+		})
+	}
+
+	_, err := s.ListProviders(ctx, ListOptions{Namespaces: []string{"default"}, SortBy: "host"})
+	assert.Error(t, err)
+}
+
+func TestListProvidersFilters(t *testing.T) {
+	s, k8sClient := newPagingService(t)
+	createListedProviders(t, s, k8sClient)
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		opts ListOptions
+		want []string
+	}{
+		"schema":         {opts: ListOptions{Schema: "awsbedrock"}, want: []string{"b-bedrock"}},
+		"auth type":      {opts: ListOptions{AuthType: "AWS"}, want: []string{"b-bedrock"}},
+		"host substring": {opts: ListOptions{Host: "OPENAI"}, want: []string{"a-openai", "c-azure"}},
+		"combined":       {opts: ListOptions{Schema: "OpenAI", Host: "azure"}, want: []string{}},
+		"label selector": {opts: ListOptions{LabelSelector: "tier in (0,2)"}, want: []string{"a-openai", "c-azure"}},
+		"no match":       {opts: ListOptions{LabelSelector: "tier=9"}, want: []string{}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tt.opts.Namespaces = []string{"default"}
+			list, err := s.ListProviders(ctx, tt.opts)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, providerNames(list))
+			assert.Equal(t, len(tt.want), list.Total)
+		})
+	}
+
+	_, err := s.ListProviders(ctx, ListOptions{Namespaces: []string{"default"}, LabelSelector: "=="})
+	assert.Error(t, err)
+}
+
+func TestListProvidersReportsUnreadableNamespaces(t *testing.T) {
+	scheme, err := client.NewScheme()
+	require.NoError(t, err)
+	builder := fake.NewClientBuilder().WithScheme(scheme)
+	for _, namespace := range []string{"team-a", "team-b", "team-c"} {
+		builder.WithObjects(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}})
+	}
+	namespacesForbidden := true
+	k8sClient := interceptor.NewClient(builder.Build(), interceptor.Funcs{
+		List: func(ctx context.Context, c ctrlclient.WithWatch, list ctrlclient.ObjectList, opts ...ctrlclient.ListOption) error {
+			switch list.(type) {
+			case *corev1.NamespaceList:
+				if namespacesForbidden {
+					return errors.NewForbidden(schema.GroupResource{Resource: "namespaces"}, "", nil)
+				}
+			case *aigatewayv1alpha1.AIServiceBackendList:
+				resource := schema.GroupResource{Group: llm.GroupAIGatewayEnvoyProxy, Resource: "aiservicebackends"}
+				switch (&ctrlclient.ListOptions{}).ApplyOptions(opts).Namespace {
+				case "", "team-b":
+					return errors.NewForbidden(resource, "", nil)
+				case "team-c":
+					return errors.NewInternalError(fmt.Errorf("etcd is unavailable"))
+				}
+			}
+			return c.List(ctx, list, opts...)
+		},
+	})
+	s := NewLLMProviderService(client.NewManagerWithClient(k8sClient, logr.Discard()))
+	t.Cleanup(s.Close)
+	ctx := context.Background()
+	require.NoError(t, s.CreateProvider(ctx, openAIProvider("team-a", "openai")))
+
+	reasons := func(list *ProviderList) map[string]string {
+		reasons := map[string]string{}
+		for _, namespaceError := range list.NamespaceErrors {
+			reasons[namespaceError.Namespace] = namespaceError.Reason
+		}
+		return reasons
+	}
+
+	// Neither the cluster-wide list nor the namespaces can be read
+	list, err := s.ListProviders(ctx, ListOptions{Namespaces: []string{client.AllNamespaces}})
+	require.NoError(t, err)
+	assert.Empty(t, list.Items)
+	assert.Equal(t, map[string]string{client.AllNamespaces: string(metav1.StatusReasonForbidden)}, reasons(list))
+
+	// Every readable namespace is returned with the errors of the others
+	namespacesForbidden = false
+	for _, namespaces := range [][]string{{client.AllNamespaces}, {"team-a", "team-b", "team-c"}} {
+		list, err = s.ListProviders(ctx, ListOptions{Namespaces: namespaces})
+		require.NoError(t, err)
+		require.Len(t, list.Items, 1)
+		assert.Equal(t, "team-a", list.Items[0].Namespace)
+		assert.Equal(t, map[string]string{
+			"team-b": string(metav1.StatusReasonForbidden),
+			"team-c": string(metav1.StatusReasonInternalError),
+		}, reasons(list))
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package service
+
+import (
+	"context"
+
+	aigatewayv1alpha1 "github.com/envoyproxy/ai-gateway/api/v1alpha1"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/apierror"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/audit"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/client"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/llm"
+	gatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	gwapiv1a3 "sigs.k8s.io/gateway-api/apis/v1alpha3"
+)
+
+// UpdateProvider replaces the configuration of an existing LLM provider.
+// Masked credentials sent back by clients keep their current value.
+// A non-empty ifMatch must match the current ETag of the provider.
+func (s *LLMProviderService) UpdateProvider(ctx context.Context, provider *llm.LLMProvider, ifMatch string) (_ *llm.LLMProvider, err error) {
+	ctx, span := startSpan(ctx, "UpdateProvider", "LLMProvider", provider.Namespace, provider.Name)
+	defer func() { endSpan(span, err) }()
+
+	before, after, err := s.updateProvider(ctx, provider, ifMatch)
+	s.recordAudit(ctx, audit.ActionUpdate, provider.Namespace, provider.Name, before, after, err)
+	if err == nil {
+		s.recordRevision(ctx, audit.ActionUpdate, after)
+	}
+	return after, err
+}
+
+// updateProvider applies the provider and returns it masked before and after the update
+func (s *LLMProviderService) updateProvider(ctx context.Context, desired *llm.LLMProvider, ifMatch string) (*llm.LLMProvider, *llm.LLMProvider, error) {
+	clients, err := s.clientsFor(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resources, err := s.loadProviderResources(ctx, desired.Namespace, desired.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	current, err := translateProvider(ctx, desired.Namespace, desired.Name, resources)
+	if err != nil {
+		return nil, nil, err
+	}
+	before := current.MaskSecret()
+
+	if err := checkETag(ifMatch, resources); err != nil {
+		return before, nil, err
+	}
+
+	updated := *desired
+	updated.Auth = desired.Auth.RestoreSecrets(current.Auth)
+	updated.CreatedAt, updated.Status = current.CreatedAt, current.Status
+	if updated.Auth.HasMaskedSecrets() {
+		return before, nil, apierror.Invalid("credentials must be supplied when changing the authentication of %s/%s to %s", desired.Namespace, desired.Name, updated.Auth.Type)
+	}
+
+	if err := applyProvider(ctx, clients, &updated, resources); err != nil {
+		return before, nil, err
+	}
+	return before, updated.MaskSecret(), nil
+}
+
+// applyProvider creates or updates every resource translated from the provider.
+// Metadata of existing resources is preserved so labels and owners set by others survive.
+// Updates carry the resourceVersion of the loaded resources, so a concurrent change made
+// after they were read fails with a conflict instead of being overwritten.
+func applyProvider(ctx context.Context, clients client.ManagerInterface, provider *llm.LLMProvider, loaded []any) error {
+	resources, err := provider.ToEnvoyGatewayResourcesFor(clients.APIVersions())
+	if err != nil {
+		return apierror.Wrap(apierror.CodeInvalid, err, "invalid LLM provider: %v", err)
+	}
+
+	var previous []llm.ModelMapping
+	for _, resource := range loaded {
+		if r, ok := resource.(*aigatewayv1alpha1.AIServiceBackend); ok {
+			// Unreadable mappings were set by hand; overrides are then only added, not cleared
+			previous, _ = llm.ModelMappings(r)
+		}
+	}
+	routes, err := modelOverrideRoutes(ctx, clients, provider, previous)
+	if err != nil {
+		return err
+	}
+
+	for _, resource := range resources {
+		switch r := resource.(type) {
+		case *gatewayv1alpha1.Backend:
+			current, err := loadedOrGet(loaded, r.Namespace, r.Name, func() (*gatewayv1alpha1.Backend, error) {
+				return clients.GetBackendClient().Get(ctx, r.Namespace, r.Name)
+			})
+			switch {
+			case errors.IsNotFound(err):
+				err = clients.GetBackendClient().Create(ctx, r)
+			case err == nil:
+				r.ObjectMeta = current.ObjectMeta
+				err = clients.GetBackendClient().Update(ctx, r)
+			}
+			if err != nil {
+				return apierror.FromKubernetes(err, "failed to apply Backend %s/%s", r.Namespace, r.Name)
+			}
+
+		case *gwapiv1a3.BackendTLSPolicy:
+			current, err := loadedOrGet(loaded, r.Namespace, r.Name, func() (*gwapiv1a3.BackendTLSPolicy, error) {
+				return clients.GetBackendTLSPolicyClient().Get(ctx, r.Namespace, r.Name)
+			})
+			switch {
+			case errors.IsNotFound(err):
+				err = clients.GetBackendTLSPolicyClient().Create(ctx, r)
+			case err == nil:
+				r.ObjectMeta = current.ObjectMeta
+				err = clients.GetBackendTLSPolicyClient().Update(ctx, r)
+			}
+			if err != nil {
+				return apierror.FromKubernetes(err, "failed to apply BackendTLSPolicy %s/%s", r.Namespace, r.Name)
+			}
+
+		case *gatewayv1alpha1.BackendTrafficPolicy:
+			current, err := loadedOrGet(loaded, r.Namespace, r.Name, func() (*gatewayv1alpha1.BackendTrafficPolicy, error) {
+				return clients.GetBackendTrafficPolicyClient().Get(ctx, r.Namespace, r.Name)
+			})
+			switch {
+			case errors.IsNotFound(err):
+				err = clients.GetBackendTrafficPolicyClient().Create(ctx, r)
+			case err == nil:
+				r.ObjectMeta = current.ObjectMeta
+				err = clients.GetBackendTrafficPolicyClient().Update(ctx, r)
+			}
+			if err != nil {
+				return apierror.FromKubernetes(err, "failed to apply BackendTrafficPolicy %s/%s", r.Namespace, r.Name)
+			}
+
+		case *aigatewayv1alpha1.BackendSecurityPolicy:
+			current, err := loadedOrGet(loaded, r.Namespace, r.Name, func() (*aigatewayv1alpha1.BackendSecurityPolicy, error) {
+				return clients.GetBackendSecurityPolicyClient().Get(ctx, r.Namespace, r.Name)
+			})
+			switch {
+			case errors.IsNotFound(err):
+				err = clients.GetBackendSecurityPolicyClient().Create(ctx, r)
+			case err == nil:
+				r.ObjectMeta = current.ObjectMeta
+				err = clients.GetBackendSecurityPolicyClient().Update(ctx, r)
+			}
+			if err != nil {
+				return apierror.FromKubernetes(err, "failed to apply BackendSecurityPolicy %s/%s", r.Namespace, r.Name)
+			}
+
+		case *aigatewayv1alpha1.AIServiceBackend:
+			current, err := loadedOrGet(loaded, r.Namespace, r.Name, func() (*aigatewayv1alpha1.AIServiceBackend, error) {
+				return clients.GetAIServiceBackendClient().Get(ctx, r.Namespace, r.Name)
+			})
+			switch {
+			case errors.IsNotFound(err):
+				err = clients.GetAIServiceBackendClient().Create(ctx, r)
+			case err == nil:
+				// The model annotations are owned by the provider, the rest of the metadata is kept
+				desired := r.ObjectMeta
+				current.ObjectMeta.DeepCopyInto(&r.ObjectMeta)
+				llm.CopyModelAnnotations(&r.ObjectMeta, desired)
+				r.Status = current.Status
+				err = clients.GetAIServiceBackendClient().Update(ctx, r)
+			}
+			if err != nil {
+				return apierror.FromKubernetes(err, "failed to apply AIServiceBackend %s/%s", r.Namespace, r.Name)
+			}
+
+		case *corev1.Secret:
+			current, err := loadedOrGet(loaded, r.Namespace, r.Name, func() (*corev1.Secret, error) {
+				return clients.GetSecretClient().Get(ctx, r.Namespace, r.Name)
+			})
+			switch {
+			case errors.IsNotFound(err):
+				err = clients.GetSecretClient().Create(ctx, r)
+			case err == nil:
+				// Data is rebuilt from StringData so stale keys do not linger
+				r.ObjectMeta = current.ObjectMeta
+				err = clients.GetSecretClient().Update(ctx, r)
+			}
+			if err != nil {
+				return apierror.FromKubernetes(err, "failed to apply Secret %s/%s", r.Namespace, r.Name)
+			}
+
+		default:
+			return apierror.Internal("unknown resource type: %T", r)
+		}
+	}
+
+	if err := syncModelOverrides(ctx, clients, provider, previous, routes); err != nil {
+		return err
+	}
+
+	// Traffic settings removed from the provider drop their BackendTrafficPolicy
+	if provider.Traffic == nil {
+		for _, resource := range loaded {
+			if r, ok := resource.(*gatewayv1alpha1.BackendTrafficPolicy); ok {
+				if err := clients.GetBackendTrafficPolicyClient().Delete(ctx, r.Namespace, r.Name); err != nil && !errors.IsNotFound(err) {
+					return apierror.FromKubernetes(err, "failed to delete BackendTrafficPolicy %s/%s", r.Namespace, r.Name)
+				}
+			}
+		}
+	}
+
+	// Providers without a TLS hostname drop their BackendTLSPolicy
+	if provider.TLS.Hostname == "" {
+		for _, resource := range loaded {
+			if r, ok := resource.(*gwapiv1a3.BackendTLSPolicy); ok {
+				if err := clients.GetBackendTLSPolicyClient().Delete(ctx, r.Namespace, r.Name); err != nil && !errors.IsNotFound(err) {
+					return apierror.FromKubernetes(err, "failed to delete BackendTLSPolicy %s/%s", r.Namespace, r.Name)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// loadedOrGet returns the loaded resource of the same type and name, or reads it with get
+func loadedOrGet[T ctrlclient.Object](loaded []any, namespace, name string, get func() (T, error)) (T, error) {
+	for _, resource := range loaded {
+		if r, ok := resource.(T); ok && r.GetNamespace() == namespace && r.GetName() == name {
+			return r, nil
+		}
+	}
+	return get()
+}
@@ -0,0 +1,298 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package service
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+
+	aigatewayv1alpha1 "github.com/envoyproxy/ai-gateway/api/v1alpha1"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/apierror"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/audit"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/client"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/llm"
+	gatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RateLimitPolicyList is the result of listing rate limit policies
+type RateLimitPolicyList struct {
+	Items []llm.RateLimitPolicy `json:"items"`
+	// NamespaceErrors reports namespaces that could not be read, e.g. due to RBAC
+	NamespaceErrors []NamespaceError `json:"namespaceErrors,omitempty"`
+	// Errors reports rate limit policies edited beyond what the console can represent.
+	// They are not listed as items.
+	Errors []RateLimitPolicyError `json:"errors,omitempty"`
+}
+
+// RateLimitPolicyError describes a rate limit policy that could not be translated
+type RateLimitPolicyError struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Message   string `json:"message"`
+}
+
+// ListRateLimitPolicies returns the rate limit policies in the namespaces, sorted by namespace and name.
+// BackendTrafficPolicies that are not managed as rate limit policies are ignored.
+func (s *LLMProviderService) ListRateLimitPolicies(ctx context.Context, namespaces []string) (_ *RateLimitPolicyList, err error) {
+	ctx, span := startSpan(ctx, "ListRateLimitPolicies", "RateLimitPolicy", strings.Join(namespaces, ","), "")
+	defer func() { endSpan(span, err) }()
+
+	result := &RateLimitPolicyList{Items: make([]llm.RateLimitPolicy, 0)}
+
+	clients, err := s.clientsFor(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	for _, namespace := range namespaces {
+		list, err := clients.GetBackendTrafficPolicyClient().List(ctx, namespace, ctrlclient.HasLabels{llm.LabelRateLimitPolicy})
+		if err != nil {
+			if errors.IsForbidden(err) {
+				result.NamespaceErrors = append(result.NamespaceErrors, newNamespaceError(namespace, err))
+				continue
+			}
+			return result, apierror.FromKubernetes(err, "failed to list BackendTrafficPolicies in namespace %s", namespace)
+		}
+
+		for i := range list.Items {
+			policy, err := llm.ToRateLimitPolicy(&list.Items[i])
+			if err != nil {
+				result.Errors = append(result.Errors, RateLimitPolicyError{
+					Name:      list.Items[i].Name,
+					Namespace: list.Items[i].Namespace,
+					Message:   err.Error(),
+				})
+				continue
+			}
+			result.Items = append(result.Items, *policy)
+		}
+	}
+
+	sort.SliceStable(result.Items, func(i, j int) bool {
+		if result.Items[i].Namespace != result.Items[j].Namespace {
+			return result.Items[i].Namespace < result.Items[j].Namespace
+		}
+		return result.Items[i].Name < result.Items[j].Name
+	})
+	sort.SliceStable(result.Errors, func(i, j int) bool {
+		if result.Errors[i].Namespace != result.Errors[j].Namespace {
+			return result.Errors[i].Namespace < result.Errors[j].Namespace
+		}
+		return result.Errors[i].Name < result.Errors[j].Name
+	})
+	return result, nil
+}
+
+// GetRateLimitPolicy returns a rate limit policy by namespace and name together with
+// the ETag of its BackendTrafficPolicy
+func (s *LLMProviderService) GetRateLimitPolicy(ctx context.Context, namespace, name string) (_ *llm.RateLimitPolicy, _ string, err error) {
+	ctx, span := startSpan(ctx, "GetRateLimitPolicy", "RateLimitPolicy", namespace, name)
+	defer func() { endSpan(span, err) }()
+
+	clients, err := s.clientsFor(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	btp, err := getRateLimitTrafficPolicy(ctx, clients, namespace, name)
+	if err != nil {
+		return nil, "", err
+	}
+
+	policy, err := llm.ToRateLimitPolicy(btp)
+	if err != nil {
+		return nil, "", apierror.Wrap(apierror.CodeInternal, err, "failed to translate rate limit policy %s/%s: %v", namespace, name, err)
+	}
+	return policy, rateLimitETag(btp), nil
+}
+
+// CreateRateLimitPolicy creates a rate limit policy on its AIGatewayRoute, adding the request costs
+// the policy counts to the route when they are missing
+func (s *LLMProviderService) CreateRateLimitPolicy(ctx context.Context, policy *llm.RateLimitPolicy) (_ *llm.RateLimitPolicy, err error) {
+	ctx, span := startSpan(ctx, "CreateRateLimitPolicy", "RateLimitPolicy", policy.Namespace, policy.Name)
+	defer func() { endSpan(span, err) }()
+
+	created, err := s.createRateLimitPolicy(ctx, policy)
+	s.recordRateLimitAudit(ctx, audit.ActionCreate, policy.Namespace, policy.Name, nil, created, err)
+	return created, err
+}
+
+// createRateLimitPolicy creates the policy and returns it as created
+func (s *LLMProviderService) createRateLimitPolicy(ctx context.Context, policy *llm.RateLimitPolicy) (*llm.RateLimitPolicy, error) {
+	clients, err := s.clientsFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	btp, err := policy.ToBackendTrafficPolicy()
+	if err != nil {
+		return nil, apierror.Wrap(apierror.CodeInvalid, err, "invalid rate limit policy: %v", err)
+	}
+
+	if err := prepareRateLimitRoute(ctx, clients, policy); err != nil {
+		return nil, err
+	}
+
+	if err := clients.GetBackendTrafficPolicyClient().Create(ctx, btp); err != nil {
+		if errors.IsAlreadyExists(err) {
+			return nil, apierror.Wrap(apierror.CodeAlreadyExists, err, "BackendTrafficPolicy '%s' already exists. Please choose a different name", btp.Name)
+		}
+		return nil, apierror.FromKubernetes(err, "failed to create BackendTrafficPolicy %s/%s", btp.Namespace, btp.Name)
+	}
+
+	return llm.ToRateLimitPolicy(btp)
+}
+
+// UpdateRateLimitPolicy replaces the rules and route of an existing rate limit policy.
+// A non-empty ifMatch must match the current ETag of the policy.
+func (s *LLMProviderService) UpdateRateLimitPolicy(ctx context.Context, policy *llm.RateLimitPolicy, ifMatch string) (_ *llm.RateLimitPolicy, err error) {
+	ctx, span := startSpan(ctx, "UpdateRateLimitPolicy", "RateLimitPolicy", policy.Namespace, policy.Name)
+	defer func() { endSpan(span, err) }()
+
+	before, after, err := s.updateRateLimitPolicy(ctx, policy, ifMatch)
+	s.recordRateLimitAudit(ctx, audit.ActionUpdate, policy.Namespace, policy.Name, before, after, err)
+	return after, err
+}
+
+// updateRateLimitPolicy updates the policy and returns it before and after the update
+func (s *LLMProviderService) updateRateLimitPolicy(ctx context.Context, policy *llm.RateLimitPolicy, ifMatch string) (*llm.RateLimitPolicy, *llm.RateLimitPolicy, error) {
+	clients, err := s.clientsFor(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	desired, err := policy.ToBackendTrafficPolicy()
+	if err != nil {
+		return nil, nil, apierror.Wrap(apierror.CodeInvalid, err, "invalid rate limit policy: %v", err)
+	}
+
+	current, err := getRateLimitTrafficPolicy(ctx, clients, policy.Namespace, policy.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	// A policy edited beyond what the console represents has no before, it is replaced as a whole
+	before, _ := llm.ToRateLimitPolicy(current)
+
+	if err := checkRateLimitETag(ifMatch, current); err != nil {
+		return before, nil, err
+	}
+
+	if err := prepareRateLimitRoute(ctx, clients, policy); err != nil {
+		return before, nil, err
+	}
+
+	// Keep the metadata of the existing policy so the update carries its resourceVersion
+	current.Labels[llm.LabelRateLimitPolicy] = policy.Route
+	current.Spec = desired.Spec
+	if err := clients.GetBackendTrafficPolicyClient().Update(ctx, current); err != nil {
+		return before, nil, apierror.FromKubernetes(err, "failed to update BackendTrafficPolicy %s/%s", current.Namespace, current.Name)
+	}
+
+	after, err := llm.ToRateLimitPolicy(current)
+	return before, after, err
+}
+
+// DeleteRateLimitPolicy deletes a rate limit policy. A non-empty ifMatch must match
+// the current ETag of the policy. The request costs on the route are kept since
+// other policies may count them.
+func (s *LLMProviderService) DeleteRateLimitPolicy(ctx context.Context, namespace, name, ifMatch string) (err error) {
+	ctx, span := startSpan(ctx, "DeleteRateLimitPolicy", "RateLimitPolicy", namespace, name)
+	defer func() { endSpan(span, err) }()
+
+	deleted, err := s.deleteRateLimitPolicy(ctx, namespace, name, ifMatch)
+	s.recordRateLimitAudit(ctx, audit.ActionDelete, namespace, name, deleted, nil, err)
+	return err
+}
+
+// deleteRateLimitPolicy deletes the policy and returns it as it was
+func (s *LLMProviderService) deleteRateLimitPolicy(ctx context.Context, namespace, name, ifMatch string) (*llm.RateLimitPolicy, error) {
+	clients, err := s.clientsFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := getRateLimitTrafficPolicy(ctx, clients, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	deleted, _ := llm.ToRateLimitPolicy(current)
+
+	if err := checkRateLimitETag(ifMatch, current); err != nil {
+		return deleted, err
+	}
+
+	if err := clients.GetBackendTrafficPolicyClient().Delete(ctx, namespace, name); err != nil {
+		return deleted, apierror.FromKubernetes(err, "failed to delete BackendTrafficPolicy %s/%s", namespace, name)
+	}
+	return deleted, nil
+}
+
+// getRateLimitTrafficPolicy loads the BackendTrafficPolicy of a rate limit policy,
+// reporting BackendTrafficPolicies managed otherwise as not found
+func getRateLimitTrafficPolicy(ctx context.Context, clients client.ManagerInterface, namespace, name string) (*gatewayv1alpha1.BackendTrafficPolicy, error) {
+	btp, err := clients.GetBackendTrafficPolicyClient().Get(ctx, namespace, name)
+	if err != nil {
+		return nil, apierror.FromKubernetes(err, "failed to get rate limit policy %s/%s", namespace, name)
+	}
+	if _, ok := btp.Labels[llm.LabelRateLimitPolicy]; !ok {
+		return nil, apierror.NotFound("rate limit policy %s/%s not found", namespace, name)
+	}
+	return btp, nil
+}
+
+// prepareRateLimitRoute validates the policy against its AIGatewayRoute and adds the missing request costs.
+// Envoy Gateway only applies one BackendTrafficPolicy per route, so a route takes a single rate limit policy.
+func prepareRateLimitRoute(ctx context.Context, clients client.ManagerInterface, policy *llm.RateLimitPolicy) error {
+	route, err := clients.GetAIGatewayRouteClient().Get(ctx, policy.Namespace, policy.Route)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return apierror.Wrap(apierror.CodeInvalid, err, "AIGatewayRoute %s/%s does not exist", policy.Namespace, policy.Route)
+		}
+		return apierror.FromKubernetes(err, "failed to get AIGatewayRoute %s/%s", policy.Namespace, policy.Route)
+	}
+
+	for _, rule := range policy.Rules {
+		if rule.Provider != "" && !routesTo(route, rule.Provider) {
+			return apierror.Invalid("provider %q is not a backend of AIGatewayRoute %s/%s", rule.Provider, policy.Namespace, policy.Route)
+		}
+	}
+
+	existing, err := clients.GetBackendTrafficPolicyClient().List(ctx, policy.Namespace, ctrlclient.MatchingLabels{llm.LabelRateLimitPolicy: policy.Route})
+	if err != nil {
+		return apierror.FromKubernetes(err, "failed to list rate limit policies of AIGatewayRoute %s/%s", policy.Namespace, policy.Route)
+	}
+	for _, other := range existing.Items {
+		if other.Name != policy.Name {
+			return apierror.New(apierror.CodeConflict, "AIGatewayRoute %s/%s is already limited by rate limit policy %s", policy.Namespace, policy.Route, other.Name)
+		}
+	}
+
+	costs, changed, err := llm.MergeRequestCosts(route.Spec.LLMRequestCosts, policy.RequestCosts())
+	if err != nil {
+		return apierror.Wrap(apierror.CodeConflict, err, "AIGatewayRoute %s/%s: %v", policy.Namespace, policy.Route, err)
+	}
+	if !changed {
+		return nil
+	}
+	route.Spec.LLMRequestCosts = costs
+	if err := clients.GetAIGatewayRouteClient().Update(ctx, route); err != nil {
+		return apierror.FromKubernetes(err, "failed to add request costs to AIGatewayRoute %s/%s", route.Namespace, route.Name)
+	}
+	return nil
+}
+
+// routesTo reports whether a rule of the route sends traffic to the AIServiceBackend
+func routesTo(route *aigatewayv1alpha1.AIGatewayRoute, backend string) bool {
+	return slices.ContainsFunc(route.Spec.Rules, func(rule aigatewayv1alpha1.AIGatewayRouteRule) bool {
+		return slices.ContainsFunc(rule.BackendRefs, func(ref aigatewayv1alpha1.AIGatewayRouteRuleBackendRef) bool {
+			return ref.Name == backend
+		})
+	})
+}
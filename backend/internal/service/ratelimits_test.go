@@ -0,0 +1,97 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	aigatewayv1alpha1 "github.com/envoyproxy/ai-gateway/api/v1alpha1"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/apierror"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/audit"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/llm"
+	gatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func chatRoute() *aigatewayv1alpha1.AIGatewayRoute {
+	return &aigatewayv1alpha1.AIGatewayRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "chat"}}
+}
+
+func budgetPolicy(limit uint) *llm.RateLimitPolicy {
+	return &llm.RateLimitPolicy{
+		Name:      "budget",
+		Namespace: "default",
+		Route:     "chat",
+		Rules:     []llm.RateLimitRule{{TokenType: "total", Limit: limit, Unit: "Minute"}},
+	}
+}
+
+func TestListRateLimitPoliciesReportsErrors(t *testing.T) {
+	edited := &gatewayv1alpha1.BackendTrafficPolicy{ObjectMeta: metav1.ObjectMeta{
+		Namespace: "default", Name: "edited", Labels: map[string]string{llm.LabelRateLimitPolicy: "other"},
+	}}
+	s, _ := newFakeService(t, chatRoute(), edited)
+	ctx := context.Background()
+	_, err := s.CreateRateLimitPolicy(ctx, budgetPolicy(1000))
+	require.NoError(t, err)
+
+	list, err := s.ListRateLimitPolicies(ctx, []string{"default"})
+	require.NoError(t, err)
+	require.Len(t, list.Items, 1)
+	assert.Equal(t, "budget", list.Items[0].Name)
+	require.Len(t, list.Errors, 1)
+	assert.Equal(t, "edited", list.Errors[0].Name)
+	assert.Equal(t, "default", list.Errors[0].Namespace)
+	assert.Contains(t, list.Errors[0].Message, "has no global rate limit")
+}
+
+func TestRateLimitPolicyETagAndAudit(t *testing.T) {
+	s, store := newAuditedService(t)
+	ctx := context.Background()
+	require.NoError(t, s.clientManager.Client().Create(ctx, chatRoute()))
+
+	_, err := s.CreateRateLimitPolicy(ctx, budgetPolicy(1000))
+	require.NoError(t, err)
+	event := lastEvent(t, store)
+	assert.Equal(t, audit.KindRateLimitPolicy, event.Kind)
+	assert.Equal(t, audit.ActionCreate, event.Action)
+	assert.Equal(t, "budget", event.Provider)
+
+	_, etag, err := s.GetRateLimitPolicy(ctx, "default", "budget")
+	require.NoError(t, err)
+	assert.NotEmpty(t, etag)
+
+	_, err = s.UpdateRateLimitPolicy(ctx, budgetPolicy(2000), `"stale"`)
+	assert.True(t, apierror.Is(err, apierror.CodePreconditionFailed), "got %v", err)
+	event = lastEvent(t, store)
+	assert.Equal(t, audit.OutcomeFailure, event.Outcome)
+	assert.Empty(t, event.Changes)
+
+	updated, err := s.UpdateRateLimitPolicy(ctx, budgetPolicy(2000), etag)
+	require.NoError(t, err)
+	assert.Equal(t, uint(2000), updated.Rules[0].Limit)
+	event = lastEvent(t, store)
+	assert.Equal(t, audit.ActionUpdate, event.Action)
+	assert.Equal(t, audit.OutcomeSuccess, event.Outcome)
+	require.Len(t, event.Changes, 1)
+	assert.Equal(t, "rules", event.Changes[0].Path)
+
+	err = s.DeleteRateLimitPolicy(ctx, "default", "budget", etag)
+	assert.True(t, apierror.Is(err, apierror.CodePreconditionFailed), "the update changed the ETag, got %v", err)
+
+	_, etag, err = s.GetRateLimitPolicy(ctx, "default", "budget")
+	require.NoError(t, err)
+	require.NoError(t, s.DeleteRateLimitPolicy(ctx, "default", "budget", etag))
+	event = lastEvent(t, store)
+	assert.Equal(t, audit.ActionDelete, event.Action)
+	assert.Contains(t, event.Changes, audit.Change{Path: "route", Before: "chat"})
+
+	_, _, err = s.GetRateLimitPolicy(ctx, "default", "budget")
+	assert.True(t, apierror.Is(err, apierror.CodeNotFound), "got %v", err)
+}
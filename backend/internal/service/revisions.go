@@ -0,0 +1,225 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/apierror"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/audit"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/auth"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/llm"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	// RevisionLimit is the number of revisions kept per provider
+	RevisionLimit = 20
+
+	// LabelRevisionsOf marks the ConfigMap holding the revisions of a provider
+	LabelRevisionsOf = "console.aigateway.envoyproxy.io/revisions-of"
+
+	revisionsConfigMapPrefix = "llm-provider-revisions-"
+	revisionsKey             = "revisions.json"
+)
+
+// Revision is a snapshot of the masked configuration of a provider
+type Revision struct {
+	Revision int64        `json:"revision"`
+	Time     time.Time    `json:"time"`
+	Actor    string       `json:"actor,omitempty"`
+	Action   audit.Action `json:"action"`
+	// Provider is the masked provider after the change
+	Provider llm.LLMProvider `json:"provider"`
+	// Changes is the diff against the previous revision, only set when listing
+	Changes []audit.Change `json:"changes,omitempty"`
+}
+
+// RevisionList holds the revisions of a provider, newest first
+type RevisionList struct {
+	Items []Revision `json:"items"`
+}
+
+// revisionsConfigMapName returns the name of the ConfigMap holding the revisions of a provider
+func revisionsConfigMapName(provider string) string {
+	return revisionsConfigMapPrefix + provider
+}
+
+// ListRevisions returns the revision history of a provider with the diff between revisions
+func (s *LLMProviderService) ListRevisions(ctx context.Context, namespace, name string) (_ *RevisionList, err error) {
+	ctx, span := startSpan(ctx, "ListRevisions", "LLMProvider", namespace, name)
+	defer func() { endSpan(span, err) }()
+
+	// Reading the provider enforces the caller's access before the history is read
+	if _, _, err := s.GetProvider(ctx, namespace, name); err != nil {
+		return nil, err
+	}
+
+	revisions, err := s.loadRevisions(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RevisionList{Items: make([]Revision, 0, len(revisions))}
+	for i := len(revisions) - 1; i >= 0; i-- {
+		revision := revisions[i]
+		switch {
+		case i > 0:
+			revision.Changes = audit.Diff(&revisions[i-1].Provider, &revision.Provider)
+		case revision.Action == audit.ActionCreate:
+			revision.Changes = audit.Diff(nil, &revision.Provider)
+		}
+		result.Items = append(result.Items, revision)
+	}
+	return result, nil
+}
+
+// RollbackProvider reapplies the configuration of a previous revision. The current
+// credentials are kept unless new ones are supplied. A non-empty ifMatch must match
+// the current ETag of the provider.
+func (s *LLMProviderService) RollbackProvider(ctx context.Context, namespace, name string, revision int64, credentials *llm.AuthConfig, ifMatch string) (_ *llm.LLMProvider, err error) {
+	ctx, span := startSpan(ctx, "RollbackProvider", "LLMProvider", namespace, name)
+	defer func() { endSpan(span, err) }()
+
+	revisions, err := s.loadRevisions(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var target *llm.LLMProvider
+	for i := range revisions {
+		if revisions[i].Revision == revision {
+			provider := revisions[i].Provider
+			target = &provider
+		}
+	}
+	if target == nil {
+		return nil, apierror.NotFound("revision %d of LLM provider %s/%s not found", revision, namespace, name)
+	}
+
+	if credentials != nil {
+		if credentials.Type == "" {
+			credentials.Type = target.Auth.Type
+		}
+		if !strings.EqualFold(credentials.Type, target.Auth.Type) {
+			return nil, apierror.Invalid("revision %d uses authentication type %s, got credentials for %s", revision, target.Auth.Type, credentials.Type)
+		}
+		target.Auth = *credentials
+	}
+
+	before, after, err := s.updateProvider(ctx, target, ifMatch)
+	s.recordAudit(ctx, audit.ActionRollback, namespace, name, before, after, err)
+	if err == nil {
+		s.recordRevision(ctx, audit.ActionRollback, after)
+	}
+	return after, err
+}
+
+// loadRevisions reads the stored revisions of a provider, oldest first
+func (s *LLMProviderService) loadRevisions(ctx context.Context, namespace, name string) ([]Revision, error) {
+	configMap, err := s.clientManager.GetConfigMapClient().Get(ctx, namespace, revisionsConfigMapName(name))
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, apierror.FromKubernetes(err, "failed to get revisions of LLM provider %s/%s", namespace, name)
+	}
+	return decodeRevisions(configMap)
+}
+
+func decodeRevisions(configMap *corev1.ConfigMap) ([]Revision, error) {
+	var revisions []Revision
+	if data := configMap.Data[revisionsKey]; data != "" {
+		if err := json.Unmarshal([]byte(data), &revisions); err != nil {
+			return nil, apierror.Wrap(apierror.CodeInternal, err, "failed to decode revisions in ConfigMap %s/%s", configMap.Namespace, configMap.Name)
+		}
+	}
+	return revisions, nil
+}
+
+// recordRevision appends the masked provider to its revision history. The history is
+// stored with the console's own identity in a ConfigMap owned by the AIServiceBackend,
+// so it is garbage collected with the provider. Failures are logged and never fail the call.
+func (s *LLMProviderService) recordRevision(ctx context.Context, action audit.Action, provider *llm.LLMProvider) {
+	if provider == nil || s.noRevisions {
+		return
+	}
+
+	revision := Revision{Time: time.Now().UTC(), Action: action, Provider: *provider}
+	revision.Provider.CreatedAt, revision.Provider.Status = nil, ""
+	if user, ok := auth.UserFromContext(ctx); ok {
+		revision.Actor = user.Name
+	}
+
+	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		return s.appendRevision(ctx, provider.Namespace, provider.Name, revision)
+	}); err != nil {
+		logr.FromContextOrDiscard(ctx).Error(err, "Failed to record revision of LLM provider", "namespace", provider.Namespace, "name", provider.Name)
+	}
+}
+
+func (s *LLMProviderService) appendRevision(ctx context.Context, namespace, name string, revision Revision) error {
+	configMaps := s.clientManager.GetConfigMapClient()
+
+	configMap, err := configMaps.Get(ctx, namespace, revisionsConfigMapName(name))
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	create := errors.IsNotFound(err)
+	if create {
+		aisb, err := s.clientManager.GetAIServiceBackendClient().Get(ctx, namespace, name)
+		if err != nil {
+			return err
+		}
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      revisionsConfigMapName(name),
+				Namespace: namespace,
+				Labels:    map[string]string{LabelRevisionsOf: name},
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: s.clientManager.APIVersions().AIGatewayAPIVersion(),
+					Kind:       llm.KindAIServiceBackend,
+					Name:       aisb.Name,
+					UID:        aisb.UID,
+				}},
+			},
+		}
+	}
+
+	revisions, err := decodeRevisions(configMap)
+	if err != nil {
+		return err
+	}
+
+	revision.Revision = 1
+	if len(revisions) > 0 {
+		revision.Revision = revisions[len(revisions)-1].Revision + 1
+	}
+	revisions = append(revisions, revision)
+	if len(revisions) > RevisionLimit {
+		revisions = revisions[len(revisions)-RevisionLimit:]
+	}
+
+	data, err := json.Marshal(revisions)
+	if err != nil {
+		return fmt.Errorf("failed to encode revisions: %w", err)
+	}
+	configMap.Data = map[string]string{revisionsKey: string(data)}
+
+	if create {
+		return configMaps.Create(ctx, configMap)
+	}
+	return configMaps.Update(ctx, configMap)
+}
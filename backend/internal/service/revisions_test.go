@@ -0,0 +1,152 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/apierror"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/audit"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/llm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	gwapiv1a3 "sigs.k8s.io/gateway-api/apis/v1alpha3"
+)
+
+// storedAPIKey returns the unmasked API key stored for the provider
+func storedAPIKey(t *testing.T, s *LLMProviderService, namespace, name string) string {
+	t.Helper()
+	resources, err := s.loadProviderResources(context.Background(), namespace, name)
+	require.NoError(t, err)
+	provider, err := translateProvider(context.Background(), namespace, name, resources)
+	require.NoError(t, err)
+	return provider.Auth.APIKey
+}
+
+// withPort returns the OpenAI provider listening on port
+func withPort(namespace, name string, port int32) *llm.LLMProvider {
+	provider := openAIProvider(namespace, name)
+	provider.Backend.Port = port
+	return provider
+}
+
+func TestUpdateProvider(t *testing.T) {
+	s, k8sClient := newFakeService(t)
+	ctx := context.Background()
+	require.NoError(t, s.CreateProvider(ctx, openAIProvider("default", "openai")))
+
+	// Masked credentials sent back keep the stored key
+	update := withPort("default", "openai", 8443)
+	update.Auth.APIKey = llm.MaskedSecretValue
+	updated, err := s.UpdateProvider(ctx, update, "")
+	require.NoError(t, err)
+	assert.Equal(t, int32(8443), updated.Backend.Port)
+	assert.Equal(t, llm.MaskedSecretValue, updated.Auth.APIKey)
+	assert.Equal(t, "sk-test", storedAPIKey(t, s, "default", "openai"))
+
+	// Removing the TLS hostname deletes the BackendTLSPolicy
+	update.TLS = llm.TLSValidation{}
+	_, err = s.UpdateProvider(ctx, update, "")
+	require.NoError(t, err)
+	err = k8sClient.Get(ctx, ctrlclient.ObjectKey{Namespace: "default", Name: "openai"}, &gwapiv1a3.BackendTLSPolicy{})
+	assert.True(t, errors.IsNotFound(err), "got %v", err)
+
+	// Changing the authentication type needs new credentials
+	update.Auth = llm.AuthConfig{Type: "AWSCredentials", AWS: &llm.AWSAuth{Region: "us-east-1", AccessKeyID: llm.MaskedSecretValue, SecretAccessKey: llm.MaskedSecretValue}}
+	_, err = s.UpdateProvider(ctx, update, "")
+	assert.True(t, apierror.Is(err, apierror.CodeInvalid), "got %v", err)
+
+	_, err = s.UpdateProvider(ctx, openAIProvider("default", "missing"), "")
+	assert.True(t, apierror.Is(err, apierror.CodeNotFound), "got %v", err)
+}
+
+func TestRevisionsAreRecorded(t *testing.T) {
+	s, _ := newFakeService(t)
+	ctx := context.Background()
+	require.NoError(t, s.CreateProvider(ctx, openAIProvider("default", "openai")))
+	_, err := s.UpdateProvider(ctx, withPort("default", "openai", 8443), "")
+	require.NoError(t, err)
+	_, err = s.RotateCredentials(ctx, "default", "openai", llm.AuthConfig{Type: "apiKey", APIKey: "sk-rotated"}, "")
+	require.NoError(t, err)
+
+	list, err := s.ListRevisions(ctx, "default", "openai")
+	require.NoError(t, err)
+	require.Len(t, list.Items, 3)
+
+	// Newest first, every revision is masked
+	rotate, update, create := list.Items[0], list.Items[1], list.Items[2]
+	assert.Equal(t, []int64{3, 2, 1}, []int64{rotate.Revision, update.Revision, create.Revision})
+	assert.Equal(t, []audit.Action{audit.ActionRotate, audit.ActionUpdate, audit.ActionCreate}, []audit.Action{rotate.Action, update.Action, create.Action})
+	for _, revision := range list.Items {
+		assert.Equal(t, llm.MaskedSecretValue, revision.Provider.Auth.APIKey)
+	}
+
+	assert.Contains(t, update.Changes, audit.Change{Path: "backend.port", Before: float64(443), After: float64(8443)})
+	assert.NotEmpty(t, create.Changes, "the first revision is diffed against no provider")
+	for _, change := range rotate.Changes {
+		assert.NotEqual(t, "auth.apiKey", change.Path, "masked credentials never differ")
+	}
+}
+
+func TestRevisionsAreCapped(t *testing.T) {
+	s, _ := newFakeService(t)
+	ctx := context.Background()
+	require.NoError(t, s.CreateProvider(ctx, openAIProvider("default", "openai")))
+	for port := int32(1); port <= RevisionLimit+4; port++ {
+		_, err := s.UpdateProvider(ctx, withPort("default", "openai", port), "")
+		require.NoError(t, err)
+	}
+
+	list, err := s.ListRevisions(ctx, "default", "openai")
+	require.NoError(t, err)
+	require.Len(t, list.Items, RevisionLimit)
+	assert.Equal(t, int64(RevisionLimit+5), list.Items[0].Revision)
+	assert.Equal(t, int64(6), list.Items[RevisionLimit-1].Revision)
+	assert.Equal(t, int32(RevisionLimit+4), list.Items[0].Provider.Backend.Port)
+
+	// The oldest kept revision is not a create and has nothing to diff against
+	assert.Empty(t, list.Items[RevisionLimit-1].Changes)
+}
+
+func TestRollbackProvider(t *testing.T) {
+	s, _ := newFakeService(t)
+	ctx := context.Background()
+	require.NoError(t, s.CreateProvider(ctx, openAIProvider("default", "openai")))
+	_, err := s.UpdateProvider(ctx, withPort("default", "openai", 8443), "")
+	require.NoError(t, err)
+	_, err = s.RotateCredentials(ctx, "default", "openai", llm.AuthConfig{Type: "apiKey", APIKey: "sk-rotated"}, "")
+	require.NoError(t, err)
+
+	// The current credentials are kept
+	rolledBack, err := s.RollbackProvider(ctx, "default", "openai", 1, nil, "")
+	require.NoError(t, err)
+	assert.Equal(t, int32(443), rolledBack.Backend.Port)
+	assert.Equal(t, llm.MaskedSecretValue, rolledBack.Auth.APIKey)
+	assert.Equal(t, "sk-rotated", storedAPIKey(t, s, "default", "openai"))
+
+	// Supplied credentials replace them
+	_, err = s.RollbackProvider(ctx, "default", "openai", 2, &llm.AuthConfig{APIKey: "sk-supplied"}, "")
+	require.NoError(t, err)
+	assert.Equal(t, "sk-supplied", storedAPIKey(t, s, "default", "openai"))
+
+	list, err := s.ListRevisions(ctx, "default", "openai")
+	require.NoError(t, err)
+	require.Len(t, list.Items, 5)
+	assert.Equal(t, audit.ActionRollback, list.Items[0].Action)
+	assert.Equal(t, int32(8443), list.Items[0].Provider.Backend.Port)
+
+	_, err = s.RollbackProvider(ctx, "default", "openai", 2, &llm.AuthConfig{Type: "AWSCredentials"}, "")
+	assert.True(t, apierror.Is(err, apierror.CodeInvalid), "got %v", err)
+
+	_, err = s.RollbackProvider(ctx, "default", "openai", 42, nil, "")
+	assert.True(t, apierror.Is(err, apierror.CodeNotFound), "got %v", err)
+
+	_, err = s.RollbackProvider(ctx, "default", "openai", 1, nil, `"stale"`)
+	assert.True(t, apierror.Is(err, apierror.CodePreconditionFailed), "got %v", err)
+}
@@ -0,0 +1,470 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/apierror"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/client"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/llm"
+	"github.com/go-logr/logr"
+)
+
+// Rollout states
+const (
+	RolloutRunning   = "Running"
+	RolloutPaused    = "Paused"
+	RolloutCompleted = "Completed"
+	RolloutAborted   = "Aborted"
+	RolloutFailed    = "Failed"
+)
+
+const (
+	// rolloutStepTimeout bounds the Kubernetes calls of a single rollout step
+	rolloutStepTimeout = 30 * time.Second
+	// rolloutRetention is how long a finished rollout can still be read
+	rolloutRetention = 24 * time.Hour
+)
+
+// RolloutRequest starts a staged shift of traffic between two providers of a route rule
+type RolloutRequest struct {
+	From string `json:"from"` // provider losing traffic
+	To   string `json:"to"`   // provider gaining traffic, added to the rule when missing
+	// Steps are the ascending percentages of traffic sent to To, e.g. [10, 25, 50, 100]
+	Steps []int32 `json:"steps"`
+	// Interval is the time between steps, e.g. "10m"
+	Interval string `json:"interval"`
+}
+
+// Rollout reports the progress of a staged rollout
+type Rollout struct {
+	Namespace string  `json:"namespace"`
+	Route     string  `json:"route"`
+	Rule      int     `json:"rule"`
+	From      string  `json:"from"`
+	To        string  `json:"to"`
+	Steps     []int32 `json:"steps"`
+	Interval  string  `json:"interval"`
+
+	State string `json:"state"` // Running, Paused, Completed, Aborted or Failed
+	// Step is the index of the last applied step, -1 until the first is applied
+	Step       int        `json:"step"`
+	Percent    int32      `json:"percent"` // traffic currently sent to To
+	Message    string     `json:"message,omitempty"`
+	StartedAt  time.Time  `json:"startedAt"`
+	UpdatedAt  time.Time  `json:"updatedAt"`
+	NextStepAt *time.Time `json:"nextStepAt,omitempty"`
+}
+
+// active reports whether the rollout can still change the route
+func (r *Rollout) active() bool {
+	return r.State == RolloutRunning || r.State == RolloutPaused
+}
+
+// rolloutRun is a rollout driven by a background goroutine.
+// Pause, resume and abort change the state and wake the goroutine, which reacts to it.
+type rolloutRun struct {
+	mu       sync.Mutex
+	status   Rollout
+	original *llm.TrafficSplit
+	// applied is the split of the rule as last written by the rollout, only used by its goroutine
+	applied  *llm.TrafficSplit
+	interval time.Duration
+	clients  client.ManagerInterface
+	wake     chan struct{}
+	// ctx is cancelled when the service closes
+	ctx context.Context
+	// logger carries the values of the request that started the rollout
+	logger logr.Logger
+}
+
+// rollouts tracks the rollouts of this console instance by route rule.
+// They live in memory: a restart stops running rollouts at their current step.
+// Finished rollouts are kept for rolloutRetention.
+type rollouts struct {
+	mu   sync.Mutex
+	runs map[string]*rolloutRun
+	// ctx stops the goroutines of the runs, which wg tracks
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newRollouts() *rollouts {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &rollouts{runs: map[string]*rolloutRun{}, ctx: ctx, cancel: cancel}
+}
+
+func rolloutKey(namespace, route string, rule int) string {
+	return fmt.Sprintf("%s/%s/%d", namespace, route, rule)
+}
+
+func (r *rollouts) get(key string) *rolloutRun {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.evict(time.Now())
+	return r.runs[key]
+}
+
+// evict drops the rollouts that finished more than rolloutRetention ago. r.mu must be held.
+func (r *rollouts) evict(now time.Time) {
+	for key, run := range r.runs {
+		run.mu.Lock()
+		expired := !run.status.active() && now.Sub(run.status.UpdatedAt) > rolloutRetention
+		run.mu.Unlock()
+		if expired {
+			delete(r.runs, key)
+		}
+	}
+}
+
+// Close stops the running rollouts at their current step and waits for their goroutines
+func (s *LLMProviderService) Close() {
+	s.rollouts.cancel()
+	s.rollouts.wg.Wait()
+}
+
+// StartRollout validates the request, then shifts traffic step by step in the background.
+// A rule runs one rollout at a time.
+func (s *LLMProviderService) StartRollout(ctx context.Context, namespace, route string, rule int, request RolloutRequest) (_ *Rollout, err error) {
+	ctx, span := startSpan(ctx, "StartRollout", "AIGatewayRoute", namespace, route)
+	defer func() { endSpan(span, err) }()
+
+	if s.noRollouts {
+		return nil, apierror.New(apierror.CodeUnavailable, "staged rollouts are disabled")
+	}
+	clients, err := s.clientsFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	interval, err := validateRollout(request)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.GetTrafficSplit(ctx, namespace, route, rule)
+	if err != nil {
+		return nil, err
+	}
+	for _, backend := range current.Backends {
+		if backend.Provider != request.From && backend.Provider != request.To {
+			return nil, apierror.Invalid("rule %d of AIGatewayRoute %s/%s also routes to %s; a rollout only shifts traffic between two providers", rule, namespace, route, backend.Provider)
+		}
+	}
+	if !slices.ContainsFunc(current.Backends, func(b llm.WeightedBackend) bool { return b.Provider == request.From }) {
+		return nil, apierror.Invalid("provider %s is not a backend of rule %d of AIGatewayRoute %s/%s", request.From, rule, namespace, route)
+	}
+
+	// Check the target before anything changes so a bad request never reaches the route
+	routeObj, err := getRoute(ctx, clients, namespace, route)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := checkSplitBackends(ctx, clients, routeObj, current.Shift(request.From, request.To, request.Steps[0])); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	run := &rolloutRun{
+		status: Rollout{
+			Namespace: namespace, Route: route, Rule: rule,
+			From: request.From, To: request.To, Steps: request.Steps, Interval: request.Interval,
+			State: RolloutRunning, Step: -1, Percent: percentOf(current, request.From, request.To),
+			StartedAt: now, UpdatedAt: now,
+		},
+		original: current,
+		applied:  current,
+		interval: interval,
+		clients:  clients,
+		wake:     make(chan struct{}, 1),
+		ctx:      s.rollouts.ctx,
+		logger:   logr.FromContextOrDiscard(ctx).WithValues("route", route, "rule", rule),
+	}
+	key := rolloutKey(namespace, route, rule)
+	s.rollouts.mu.Lock()
+	s.rollouts.evict(now)
+	if existing, ok := s.rollouts.runs[key]; ok {
+		existing.mu.Lock()
+		active := existing.status.active()
+		existing.mu.Unlock()
+		if active {
+			s.rollouts.mu.Unlock()
+			return nil, apierror.New(apierror.CodeConflict, "a rollout is already in progress for rule %d of AIGatewayRoute %s/%s", rule, namespace, route)
+		}
+	}
+	s.rollouts.runs[key] = run
+	s.rollouts.wg.Add(1)
+	s.rollouts.mu.Unlock()
+
+	go func() {
+		defer s.rollouts.wg.Done()
+		run.run()
+	}()
+
+	return run.snapshot(), nil
+}
+
+// GetRollout returns the latest rollout of a route rule
+func (s *LLMProviderService) GetRollout(ctx context.Context, namespace, route string, rule int) (_ *Rollout, err error) {
+	ctx, span := startSpan(ctx, "GetRollout", "AIGatewayRoute", namespace, route)
+	defer func() { endSpan(span, err) }()
+
+	if _, err := s.clientsFor(ctx); err != nil {
+		return nil, err
+	}
+	run := s.rollouts.get(rolloutKey(namespace, route, rule))
+	if run == nil {
+		return nil, apierror.NotFound("no rollout for rule %d of AIGatewayRoute %s/%s", rule, namespace, route)
+	}
+	return run.snapshot(), nil
+}
+
+// PauseRollout stops a running rollout at its current step
+func (s *LLMProviderService) PauseRollout(ctx context.Context, namespace, route string, rule int) (_ *Rollout, err error) {
+	ctx, span := startSpan(ctx, "PauseRollout", "AIGatewayRoute", namespace, route)
+	defer func() { endSpan(span, err) }()
+
+	return s.controlRollout(ctx, namespace, route, rule, RolloutPaused, RolloutRunning)
+}
+
+// ResumeRollout continues a paused rollout, applying the next step after a full interval
+func (s *LLMProviderService) ResumeRollout(ctx context.Context, namespace, route string, rule int) (_ *Rollout, err error) {
+	ctx, span := startSpan(ctx, "ResumeRollout", "AIGatewayRoute", namespace, route)
+	defer func() { endSpan(span, err) }()
+
+	return s.controlRollout(ctx, namespace, route, rule, RolloutRunning, RolloutPaused)
+}
+
+// AbortRollout stops a rollout and restores the weights the rule had before it started,
+// unless the rule was changed outside the rollout since its last step
+func (s *LLMProviderService) AbortRollout(ctx context.Context, namespace, route string, rule int) (_ *Rollout, err error) {
+	ctx, span := startSpan(ctx, "AbortRollout", "AIGatewayRoute", namespace, route)
+	defer func() { endSpan(span, err) }()
+
+	return s.controlRollout(ctx, namespace, route, rule, RolloutAborted, RolloutRunning, RolloutPaused)
+}
+
+// controlRollout moves a rollout to the state if it is in one of the from states
+func (s *LLMProviderService) controlRollout(ctx context.Context, namespace, route string, rule int, state string, from ...string) (*Rollout, error) {
+	if _, err := s.clientsFor(ctx); err != nil {
+		return nil, err
+	}
+	run := s.rollouts.get(rolloutKey(namespace, route, rule))
+	if run == nil {
+		return nil, apierror.NotFound("no rollout for rule %d of AIGatewayRoute %s/%s", rule, namespace, route)
+	}
+
+	run.mu.Lock()
+	if !slices.Contains(from, run.status.State) {
+		current := run.status.State
+		run.mu.Unlock()
+		return nil, apierror.New(apierror.CodeConflict, "rollout is %s", current)
+	}
+	run.status.State = state
+	run.status.UpdatedAt = time.Now().UTC()
+	run.status.NextStepAt = nil
+	run.mu.Unlock()
+
+	// The goroutine only needs to know that the state changed
+	select {
+	case run.wake <- struct{}{}:
+	default:
+	}
+	return run.snapshot(), nil
+}
+
+// validateRollout checks the steps and returns the parsed interval
+func validateRollout(request RolloutRequest) (time.Duration, error) {
+	if request.From == "" || request.To == "" {
+		return 0, apierror.Invalid("rollout requires from and to providers")
+	}
+	if request.From == request.To {
+		return 0, apierror.Invalid("rollout from and to providers must differ")
+	}
+	if len(request.Steps) == 0 {
+		return 0, apierror.Invalid("rollout requires at least one step")
+	}
+	for i, step := range request.Steps {
+		if step <= 0 || step > 100 {
+			return 0, apierror.Invalid("rollout step %d must be between 1 and 100, got %d", i, step)
+		}
+		if i > 0 && step <= request.Steps[i-1] {
+			return 0, apierror.Invalid("rollout steps must be ascending")
+		}
+	}
+	interval, err := time.ParseDuration(request.Interval)
+	if err != nil || interval <= 0 {
+		return 0, apierror.Invalid("invalid rollout interval %q, e.g. 10m", request.Interval)
+	}
+	return interval, nil
+}
+
+// percentOf returns the share of the combined from and to weight sent to to
+func percentOf(split *llm.TrafficSplit, from, to string) int32 {
+	var fromWeight, toWeight int32
+	for _, backend := range split.Backends {
+		switch backend.Provider {
+		case from:
+			fromWeight = backend.Weight
+		case to:
+			toWeight = backend.Weight
+		}
+	}
+	if fromWeight+toWeight == 0 {
+		return 0
+	}
+	return toWeight * 100 / (fromWeight + toWeight)
+}
+
+func (r *rolloutRun) snapshot() *Rollout {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	status := r.status
+	status.Steps = slices.Clone(r.status.Steps)
+	return &status
+}
+
+// run applies the steps one interval apart until the last one, an abort or a failure.
+// Each step starts from the rule as the previous one left it.
+func (r *rolloutRun) run() {
+	for step, percent := range r.status.Steps {
+		if !r.wait(step) {
+			return
+		}
+
+		if err := r.apply(r.applied.Shift(r.status.From, r.status.To, percent)); err != nil {
+			r.logger.Error(err, "Rollout failed", "step", step, "percent", percent)
+			r.finish(RolloutFailed, fmt.Sprintf("step %d (%d%%) failed: %s", step, percent, apierror.MessageOf(err)))
+			return
+		}
+
+		r.mu.Lock()
+		r.status.Step = step
+		r.status.Percent = percent
+		r.status.UpdatedAt = time.Now().UTC()
+		r.mu.Unlock()
+	}
+
+	// An abort received while the last step was applied still restores the original weights
+	r.mu.Lock()
+	aborted := r.status.State == RolloutAborted
+	r.mu.Unlock()
+	if aborted {
+		r.abort()
+		return
+	}
+	r.finish(RolloutCompleted, "")
+}
+
+// wait blocks until the step is due, returning false when the rollout was aborted or the
+// service closed. The first step is applied immediately; pausing stops the clock and
+// resuming restarts it.
+func (r *rolloutRun) wait(step int) bool {
+	delay := r.interval
+	if step == 0 {
+		delay = 0
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	for {
+		r.mu.Lock()
+		state := r.status.State
+		switch state {
+		case RolloutRunning:
+			if r.status.NextStepAt == nil {
+				next := time.Now().UTC().Add(delay)
+				r.status.NextStepAt = &next
+				timer.Reset(delay)
+			}
+		case RolloutPaused:
+			timer.Stop()
+		}
+		r.mu.Unlock()
+
+		if state == RolloutAborted {
+			r.abort()
+			return false
+		}
+
+		var due <-chan time.Time
+		if state == RolloutRunning {
+			due = timer.C
+		}
+		select {
+		case <-due:
+			r.mu.Lock()
+			r.status.NextStepAt = nil
+			r.mu.Unlock()
+			return true
+		case <-r.wake:
+		case <-r.ctx.Done():
+			r.finish(RolloutFailed, fmt.Sprintf("stopped at %d%% by the console shutting down", r.snapshot().Percent))
+			return false
+		}
+	}
+}
+
+// abort restores the original weights of the rule
+func (r *rolloutRun) abort() {
+	if slices.Equal(r.applied.Backends, r.original.Backends) {
+		r.finish(RolloutAborted, "aborted before the weights changed")
+		return
+	}
+	if err := r.apply(r.original); err != nil {
+		r.logger.Error(err, "Failed to restore the original weights after aborting the rollout")
+		r.finish(RolloutAborted, "aborted, but restoring the original weights failed: "+apierror.MessageOf(err))
+		return
+	}
+	r.mu.Lock()
+	r.status.Percent = percentOf(r.original, r.status.From, r.status.To)
+	r.mu.Unlock()
+	r.finish(RolloutAborted, "aborted, the original weights were restored")
+}
+
+// apply writes the split if the rule still has the weights the rollout last applied.
+// A rule changed by someone else fails with a conflict instead of being overwritten.
+func (r *rolloutRun) apply(split *llm.TrafficSplit) error {
+	ctx, cancel := context.WithTimeout(r.ctx, rolloutStepTimeout)
+	defer cancel()
+
+	if err := split.Validate(); err != nil {
+		return apierror.Wrap(apierror.CodeInvalid, err, "invalid traffic split: %v", err)
+	}
+	route, err := getRoute(ctx, r.clients, split.Namespace, split.Route)
+	if err != nil {
+		return err
+	}
+	current, err := llm.ToTrafficSplit(route, split.Rule)
+	if err != nil {
+		return apierror.Wrap(apierror.CodeInvalid, err, "%v", err)
+	}
+	if !slices.Equal(current.Backends, r.applied.Backends) {
+		return apierror.New(apierror.CodeConflict, "rule %d of AIGatewayRoute %s/%s was changed outside the rollout", split.Rule, split.Namespace, split.Route)
+	}
+
+	applied, err := writeTrafficSplit(ctx, r.clients, route, split)
+	if err != nil {
+		return apierror.FromKubernetes(err, "failed to update AIGatewayRoute %s/%s", split.Namespace, split.Route)
+	}
+	r.applied = applied
+	return nil
+}
+
+func (r *rolloutRun) finish(state, message string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.status.State = state
+	r.status.Message = message
+	r.status.NextStepAt = nil
+	r.status.UpdatedAt = time.Now().UTC()
+}
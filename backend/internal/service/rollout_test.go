@@ -0,0 +1,240 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	aigatewayv1alpha1 "github.com/envoyproxy/ai-gateway/api/v1alpha1"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/apierror"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/llm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// newRolloutService returns a service whose route chat sends all its traffic to openai,
+// with azure as a second provider
+func newRolloutService(t *testing.T) (*LLMProviderService, ctrlclient.Client) {
+	t.Helper()
+	weight := int32(100)
+	route := &aigatewayv1alpha1.AIGatewayRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "chat"},
+		Spec: aigatewayv1alpha1.AIGatewayRouteSpec{
+			Rules: []aigatewayv1alpha1.AIGatewayRouteRule{{
+				BackendRefs: []aigatewayv1alpha1.AIGatewayRouteRuleBackendRef{{Name: "openai", Weight: &weight}},
+			}},
+		},
+	}
+	objs := []ctrlclient.Object{route}
+	for _, name := range []string{"openai", "azure"} {
+		objs = append(objs, &aigatewayv1alpha1.AIServiceBackend{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec: aigatewayv1alpha1.AIServiceBackendSpec{
+				APISchema: aigatewayv1alpha1.VersionedAPISchema{Name: aigatewayv1alpha1.APISchemaOpenAI},
+			},
+		})
+	}
+	s, k8sClient := newFakeService(t, objs...)
+	t.Cleanup(s.Close)
+	return s, k8sClient
+}
+
+// routeWeights returns the weights of the rule of route chat
+func routeWeights(t *testing.T, s *LLMProviderService) []llm.WeightedBackend {
+	t.Helper()
+	split, err := s.GetTrafficSplit(context.Background(), "default", "chat", 0)
+	require.NoError(t, err)
+	return split.Backends
+}
+
+// waitForRollout waits until the rollout matches the condition and returns it
+func waitForRollout(t *testing.T, s *LLMProviderService, condition func(*Rollout) bool) *Rollout {
+	t.Helper()
+	var rollout *Rollout
+	require.Eventually(t, func() bool {
+		var err error
+		rollout, err = s.GetRollout(context.Background(), "default", "chat", 0)
+		require.NoError(t, err)
+		return condition(rollout)
+	}, 5*time.Second, 5*time.Millisecond, "last seen %+v", rollout)
+	return rollout
+}
+
+func inState(state string) func(*Rollout) bool {
+	return func(r *Rollout) bool { return r.State == state }
+}
+
+func atStep(step int) func(*Rollout) bool {
+	return func(r *Rollout) bool { return r.Step == step }
+}
+
+// editWeights changes the weights of the rule behind the rollout's back
+func editWeights(t *testing.T, k8sClient ctrlclient.Client, openai int32) {
+	t.Helper()
+	var route aigatewayv1alpha1.AIGatewayRoute
+	require.NoError(t, k8sClient.Get(context.Background(), ctrlclient.ObjectKey{Namespace: "default", Name: "chat"}, &route))
+	route.Spec.Rules[0].BackendRefs[0].Weight = &openai
+	require.NoError(t, k8sClient.Update(context.Background(), &route))
+}
+
+func TestRolloutCompletes(t *testing.T) {
+	s, _ := newRolloutService(t)
+	ctx := context.Background()
+
+	started, err := s.StartRollout(ctx, "default", "chat", 0, RolloutRequest{From: "openai", To: "azure", Steps: []int32{25, 100}, Interval: "10ms"})
+	require.NoError(t, err)
+	assert.Equal(t, RolloutRunning, started.State)
+	assert.Equal(t, -1, started.Step)
+
+	rollout := waitForRollout(t, s, inState(RolloutCompleted))
+	assert.Equal(t, 1, rollout.Step)
+	assert.Equal(t, int32(100), rollout.Percent)
+	assert.Nil(t, rollout.NextStepAt)
+	assert.Equal(t, []llm.WeightedBackend{{Provider: "openai", Weight: 0}, {Provider: "azure", Weight: 100}}, routeWeights(t, s))
+
+	// A finished rollout frees the rule
+	_, err = s.StartRollout(ctx, "default", "chat", 0, RolloutRequest{From: "azure", To: "openai", Steps: []int32{100}, Interval: "10ms"})
+	require.NoError(t, err)
+	waitForRollout(t, s, inState(RolloutCompleted))
+}
+
+func TestRolloutPauseResumeAbort(t *testing.T) {
+	s, _ := newRolloutService(t)
+	ctx := context.Background()
+	request := RolloutRequest{From: "openai", To: "azure", Steps: []int32{10, 50}, Interval: "1h"}
+
+	_, err := s.StartRollout(ctx, "default", "chat", 0, request)
+	require.NoError(t, err)
+	rollout := waitForRollout(t, s, atStep(0))
+	assert.Equal(t, int32(10), rollout.Percent)
+	assert.NotNil(t, rollout.NextStepAt)
+	assert.Equal(t, []llm.WeightedBackend{{Provider: "openai", Weight: 90}, {Provider: "azure", Weight: 10}}, routeWeights(t, s))
+
+	_, err = s.StartRollout(ctx, "default", "chat", 0, request)
+	assert.True(t, apierror.Is(err, apierror.CodeConflict), "got %v", err)
+	_, err = s.ResumeRollout(ctx, "default", "chat", 0)
+	assert.True(t, apierror.Is(err, apierror.CodeConflict), "a running rollout cannot resume, got %v", err)
+
+	paused, err := s.PauseRollout(ctx, "default", "chat", 0)
+	require.NoError(t, err)
+	assert.Equal(t, RolloutPaused, paused.State)
+	assert.Nil(t, paused.NextStepAt)
+	_, err = s.PauseRollout(ctx, "default", "chat", 0)
+	assert.True(t, apierror.Is(err, apierror.CodeConflict), "got %v", err)
+
+	resumed, err := s.ResumeRollout(ctx, "default", "chat", 0)
+	require.NoError(t, err)
+	assert.Equal(t, RolloutRunning, resumed.State)
+	waitForRollout(t, s, func(r *Rollout) bool { return r.NextStepAt != nil })
+
+	_, err = s.AbortRollout(ctx, "default", "chat", 0)
+	require.NoError(t, err)
+	rollout = waitForRollout(t, s, func(r *Rollout) bool { return r.Message != "" })
+	assert.Equal(t, RolloutAborted, rollout.State)
+	assert.Equal(t, "aborted, the original weights were restored", rollout.Message)
+	assert.Equal(t, int32(0), rollout.Percent)
+	assert.Equal(t, []llm.WeightedBackend{{Provider: "openai", Weight: 100}}, routeWeights(t, s))
+
+	_, err = s.AbortRollout(ctx, "default", "chat", 0)
+	assert.True(t, apierror.Is(err, apierror.CodeConflict), "got %v", err)
+}
+
+func TestRolloutFailsWhenTheRuleChanges(t *testing.T) {
+	s, k8sClient := newRolloutService(t)
+	ctx := context.Background()
+
+	_, err := s.StartRollout(ctx, "default", "chat", 0, RolloutRequest{From: "openai", To: "azure", Steps: []int32{10, 50}, Interval: "50ms"})
+	require.NoError(t, err)
+	waitForRollout(t, s, atStep(0))
+	editWeights(t, k8sClient, 70)
+
+	rollout := waitForRollout(t, s, inState(RolloutFailed))
+	assert.Equal(t, 0, rollout.Step)
+	assert.Contains(t, rollout.Message, "was changed outside the rollout")
+	assert.Equal(t, []llm.WeightedBackend{{Provider: "openai", Weight: 70}, {Provider: "azure", Weight: 10}}, routeWeights(t, s))
+}
+
+func TestRolloutAbortKeepsChangedWeights(t *testing.T) {
+	s, k8sClient := newRolloutService(t)
+	ctx := context.Background()
+
+	_, err := s.StartRollout(ctx, "default", "chat", 0, RolloutRequest{From: "openai", To: "azure", Steps: []int32{10, 50}, Interval: "1h"})
+	require.NoError(t, err)
+	waitForRollout(t, s, atStep(0))
+	editWeights(t, k8sClient, 70)
+
+	_, err = s.AbortRollout(ctx, "default", "chat", 0)
+	require.NoError(t, err)
+	rollout := waitForRollout(t, s, func(r *Rollout) bool { return r.Message != "" })
+	assert.Equal(t, RolloutAborted, rollout.State)
+	assert.Contains(t, rollout.Message, "restoring the original weights failed")
+	assert.Equal(t, []llm.WeightedBackend{{Provider: "openai", Weight: 70}, {Provider: "azure", Weight: 10}}, routeWeights(t, s))
+}
+
+func TestRolloutValidation(t *testing.T) {
+	s, _ := newRolloutService(t)
+	ctx := context.Background()
+
+	for name, request := range map[string]RolloutRequest{
+		"same providers":     {From: "openai", To: "openai", Steps: []int32{50}, Interval: "1m"},
+		"no steps":           {From: "openai", To: "azure", Interval: "1m"},
+		"descending steps":   {From: "openai", To: "azure", Steps: []int32{50, 10}, Interval: "1m"},
+		"step above 100":     {From: "openai", To: "azure", Steps: []int32{150}, Interval: "1m"},
+		"invalid interval":   {From: "openai", To: "azure", Steps: []int32{50}, Interval: "soon"},
+		"from not in rule":   {From: "azure", To: "openai", Steps: []int32{50}, Interval: "1m"},
+		"unknown to":         {From: "openai", To: "bedrock", Steps: []int32{50}, Interval: "1m"},
+		"negative interval":  {From: "openai", To: "azure", Steps: []int32{50}, Interval: "-1m"},
+		"missing to":         {From: "openai", Steps: []int32{50}, Interval: "1m"},
+		"zero step":          {From: "openai", To: "azure", Steps: []int32{0}, Interval: "1m"},
+		"missing from":       {To: "azure", Steps: []int32{50}, Interval: "1m"},
+		"unsupported target": {From: "openai", To: "missing", Steps: []int32{50}, Interval: "1m"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := s.StartRollout(ctx, "default", "chat", 0, request)
+			assert.True(t, apierror.Is(err, apierror.CodeInvalid), "got %v", err)
+		})
+	}
+
+	_, err := s.GetRollout(ctx, "default", "chat", 0)
+	assert.True(t, apierror.Is(err, apierror.CodeNotFound), "got %v", err)
+}
+
+func TestFinishedRolloutsAreEvicted(t *testing.T) {
+	s, _ := newRolloutService(t)
+	ctx := context.Background()
+
+	_, err := s.StartRollout(ctx, "default", "chat", 0, RolloutRequest{From: "openai", To: "azure", Steps: []int32{100}, Interval: "10ms"})
+	require.NoError(t, err)
+	waitForRollout(t, s, inState(RolloutCompleted))
+
+	run := s.rollouts.get(rolloutKey("default", "chat", 0))
+	run.mu.Lock()
+	run.status.UpdatedAt = time.Now().Add(-rolloutRetention - time.Minute)
+	run.mu.Unlock()
+
+	_, err = s.GetRollout(ctx, "default", "chat", 0)
+	assert.True(t, apierror.Is(err, apierror.CodeNotFound), "got %v", err)
+}
+
+func TestCloseStopsRollouts(t *testing.T) {
+	s, _ := newRolloutService(t)
+	ctx := context.Background()
+
+	_, err := s.StartRollout(ctx, "default", "chat", 0, RolloutRequest{From: "openai", To: "azure", Steps: []int32{10, 50}, Interval: "1h"})
+	require.NoError(t, err)
+	waitForRollout(t, s, atStep(0))
+
+	s.Close()
+	rollout, err := s.GetRollout(ctx, "default", "chat", 0)
+	require.NoError(t, err)
+	assert.Equal(t, RolloutFailed, rollout.State)
+	assert.Equal(t, "stopped at 10% by the console shutting down", rollout.Message)
+	assert.Equal(t, []llm.WeightedBackend{{Provider: "openai", Weight: 90}, {Provider: "azure", Weight: 10}}, routeWeights(t, s))
+}
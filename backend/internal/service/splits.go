@@ -0,0 +1,127 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package service
+
+import (
+	"context"
+
+	aigatewayv1alpha1 "github.com/envoyproxy/ai-gateway/api/v1alpha1"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/apierror"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/client"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/llm"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/retry"
+)
+
+// GetTrafficSplit returns the weighted providers of a rule of an AIGatewayRoute
+func (s *LLMProviderService) GetTrafficSplit(ctx context.Context, namespace, route string, rule int) (_ *llm.TrafficSplit, err error) {
+	ctx, span := startSpan(ctx, "GetTrafficSplit", "AIGatewayRoute", namespace, route)
+	defer func() { endSpan(span, err) }()
+
+	clients, err := s.clientsFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := getRoute(ctx, clients, namespace, route)
+	if err != nil {
+		return nil, err
+	}
+
+	split, err := llm.ToTrafficSplit(current, rule)
+	if err != nil {
+		return nil, apierror.Wrap(apierror.CodeInvalid, err, "%v", err)
+	}
+	return split, nil
+}
+
+// UpdateTrafficSplit replaces the weighted providers of a route rule after checking that every
+// provider exists and speaks a schema the route can be translated to
+func (s *LLMProviderService) UpdateTrafficSplit(ctx context.Context, split *llm.TrafficSplit) (_ *llm.TrafficSplit, err error) {
+	ctx, span := startSpan(ctx, "UpdateTrafficSplit", "AIGatewayRoute", split.Namespace, split.Route)
+	defer func() { endSpan(span, err) }()
+
+	clients, err := s.clientsFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return applyTrafficSplit(ctx, clients, split)
+}
+
+// applyTrafficSplit validates and writes the split, retrying when the route changed concurrently
+func applyTrafficSplit(ctx context.Context, clients client.ManagerInterface, split *llm.TrafficSplit) (*llm.TrafficSplit, error) {
+	if err := split.Validate(); err != nil {
+		return nil, apierror.Wrap(apierror.CodeInvalid, err, "invalid traffic split: %v", err)
+	}
+
+	var applied *llm.TrafficSplit
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		route, err := getRoute(ctx, clients, split.Namespace, split.Route)
+		if err != nil {
+			return err
+		}
+		applied, err = writeTrafficSplit(ctx, clients, route, split)
+		return err
+	})
+	if err != nil {
+		return nil, apierror.FromKubernetes(err, "failed to update AIGatewayRoute %s/%s", split.Namespace, split.Route)
+	}
+	return applied, nil
+}
+
+// writeTrafficSplit applies the split to the route as read and updates it at its resourceVersion
+func writeTrafficSplit(ctx context.Context, clients client.ManagerInterface, route *aigatewayv1alpha1.AIGatewayRoute, split *llm.TrafficSplit) (*llm.TrafficSplit, error) {
+	models, err := checkSplitBackends(ctx, clients, route, split)
+	if err != nil {
+		return nil, err
+	}
+	if err := split.ApplyTo(route); err != nil {
+		return nil, apierror.Wrap(apierror.CodeInvalid, err, "%v", err)
+	}
+	// Providers joining the rule send the model name they expect
+	for provider, mappings := range models {
+		llm.ApplyModelOverrides(route, provider, mappings, nil)
+	}
+	if err := clients.GetAIGatewayRouteClient().Update(ctx, route); err != nil {
+		return nil, err
+	}
+	return llm.ToTrafficSplit(route, split.Rule)
+}
+
+// checkSplitBackends requires every provider of the split to exist in the route's namespace with
+// a schema compatible with the route, and returns their model mappings by provider name
+func checkSplitBackends(ctx context.Context, clients client.ManagerInterface, route *aigatewayv1alpha1.AIGatewayRoute, split *llm.TrafficSplit) (map[string][]llm.ModelMapping, error) {
+	schema := llm.RouteSchema(route)
+	models := map[string][]llm.ModelMapping{}
+	for _, backend := range split.Backends {
+		aisb, err := clients.GetAIServiceBackendClient().Get(ctx, route.Namespace, backend.Provider)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return nil, apierror.Wrap(apierror.CodeInvalid, err, "LLM provider %s/%s does not exist", route.Namespace, backend.Provider)
+			}
+			return nil, apierror.FromKubernetes(err, "failed to get AIServiceBackend %s/%s", route.Namespace, backend.Provider)
+		}
+		if !llm.CompatibleSchema(schema, aisb.Spec.APISchema.Name) {
+			return nil, apierror.Invalid("LLM provider %s speaks %s, which AIGatewayRoute %s/%s cannot translate %s requests to",
+				backend.Provider, aisb.Spec.APISchema.Name, route.Namespace, route.Name, schema)
+		}
+		// Providers with unreadable mappings are routed without overrides
+		models[backend.Provider], _ = llm.ModelMappings(aisb)
+	}
+	return models, nil
+}
+
+// getRoute loads an AIGatewayRoute, reporting a missing route as not found
+func getRoute(ctx context.Context, clients client.ManagerInterface, namespace, name string) (*aigatewayv1alpha1.AIGatewayRoute, error) {
+	route, err := clients.GetAIGatewayRouteClient().Get(ctx, namespace, name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, apierror.Wrap(apierror.CodeNotFound, err, "AIGatewayRoute %s/%s not found", namespace, name)
+		}
+		return nil, apierror.FromKubernetes(err, "failed to get AIGatewayRoute %s/%s", namespace, name)
+	}
+	return route, nil
+}
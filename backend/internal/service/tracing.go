@@ -0,0 +1,39 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package service
+
+import (
+	"context"
+
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/apierror"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/envoyproxy/ai-gateway/console/backend/internal/service")
+
+// startSpan starts the span of a service operation on a resource of the kind
+func startSpan(ctx context.Context, operation, kind, namespace, name string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, "LLMProviderService."+operation, trace.WithAttributes(
+		attribute.String("console.resource.kind", kind),
+		attribute.String("console.resource.namespace", namespace),
+		attribute.String("console.resource.name", name),
+	))
+}
+
+// endSpan records the outcome of the operation, the error code if it failed, and ends the span
+func endSpan(span trace.Span, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = string(apierror.CodeOf(err))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, apierror.MessageOf(err))
+	}
+	span.SetAttributes(attribute.String("console.outcome", outcome))
+	span.End()
+}
@@ -0,0 +1,154 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/apierror"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/usage"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/client"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/llm"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+// UsageReport is the token usage and cost of models by provider, namespace and time bucket
+type UsageReport struct {
+	Since  time.Time      `json:"since"`
+	Until  time.Time      `json:"until"`
+	Bucket string         `json:"bucket"`
+	Items  []usage.Record `json:"items"`
+	// TotalCost sums the cost of the priced items in USD
+	TotalCost float64 `json:"totalCost"`
+	Currency  string  `json:"currency"`
+	// LastScrape is the time the AI Gateway metrics were last read
+	LastScrape  *time.Time `json:"lastScrape,omitempty"`
+	ScrapeError string     `json:"scrapeError,omitempty"`
+	// NamespaceErrors reports namespaces whose prices could not be read, e.g. due to RBAC
+	NamespaceErrors []NamespaceError `json:"namespaceErrors,omitempty"`
+}
+
+// GetUsage returns the token usage matching the query priced with the model catalogs of the
+// providers. Usage of models without a price has no cost.
+func (s *LLMProviderService) GetUsage(ctx context.Context, query usage.Query) (_ *UsageReport, err error) {
+	ctx, span := startSpan(ctx, "GetUsage", "Usage", strings.Join(query.Namespaces, ","), "")
+	defer func() { endSpan(span, err) }()
+
+	if s.usage == nil {
+		return nil, apierror.New(apierror.CodeUnavailable, "usage is not configured, set USAGE_METRICS_URL to the AI Gateway metrics endpoint")
+	}
+	if query.Bucket < s.usage.Interval() || query.Bucket%s.usage.Interval() != 0 {
+		return nil, apierror.Invalid("bucket %s must be a multiple of the scrape interval %s", query.Bucket, s.usage.Interval())
+	}
+	if !query.Since.Before(query.Until) {
+		return nil, apierror.Invalid("since must be before until")
+	}
+
+	clients, err := s.clientsFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := attributeSystemUsage(ctx, clients, query, s.usage.Query(query))
+	if err != nil {
+		return nil, err
+	}
+	report := &UsageReport{
+		Since:    query.Since,
+		Until:    query.Until,
+		Bucket:   query.Bucket.String(),
+		Items:    items,
+		Currency: "USD",
+	}
+	if lastScrape, err := s.usage.Status(); err != nil {
+		report.ScrapeError = err.Error()
+	} else if !lastScrape.IsZero() {
+		report.LastScrape = &lastScrape
+	}
+
+	// Model catalogs by namespace and provider, read once per namespace
+	catalogs := map[string]map[string][]llm.ModelMapping{}
+	for i := range report.Items {
+		item := &report.Items[i]
+		if item.Namespace == "" {
+			continue
+		}
+		catalog, ok := catalogs[item.Namespace]
+		if !ok {
+			catalog = map[string][]llm.ModelMapping{}
+			backends, err := clients.GetAIServiceBackendClient().List(ctx, item.Namespace)
+			switch {
+			case errors.IsForbidden(err):
+				report.NamespaceErrors = append(report.NamespaceErrors, newNamespaceError(item.Namespace, err))
+			case err != nil:
+				return nil, apierror.FromKubernetes(err, "failed to list AIServiceBackends in namespace %s", item.Namespace)
+			default:
+				for j := range backends.Items {
+					// Providers with unreadable catalogs are left unpriced
+					catalog[backends.Items[j].Name], _ = llm.ModelMappings(&backends.Items[j])
+				}
+			}
+			catalogs[item.Namespace] = catalog
+		}
+
+		if price := llm.ModelPrice(catalog[item.Provider], item.Model); price != nil {
+			cost := price.Cost(float64(item.InputTokens), float64(item.OutputTokens))
+			item.Cost = &cost
+			report.TotalCost += cost
+		}
+	}
+	return report, nil
+}
+
+// attributeSystemUsage attributes the usage AI Gateway reports by system name to the provider
+// of that schema when it is the only one of the cluster, or of the namespace the usage is
+// labelled with, and drops what the query then no longer selects. Usage that stays
+// unattributed may come from any namespace and is only listed for queries of every namespace.
+func attributeSystemUsage(ctx context.Context, clients client.ManagerInterface, query usage.Query, records []usage.Record) ([]usage.Record, error) {
+	if !slices.ContainsFunc(records, func(r usage.Record) bool { return r.System != "" }) {
+		return records, nil
+	}
+
+	// Providers by schema; users who may not list every namespace get their usage unattributed
+	providers := map[string][]usage.Key{}
+	backends, err := clients.GetAIServiceBackendClient().List(ctx, client.AllNamespaces)
+	switch {
+	case errors.IsForbidden(err):
+	case err != nil:
+		return nil, apierror.FromKubernetes(err, "failed to list AIServiceBackends")
+	default:
+		for _, backend := range backends.Items {
+			schema := string(backend.Spec.APISchema.Name)
+			providers[schema] = append(providers[schema], usage.Key{Namespace: backend.Namespace, Provider: backend.Name})
+		}
+	}
+
+	attributed := records[:0]
+	for _, record := range records {
+		if record.System != "" && record.Provider == "" {
+			var candidates []usage.Key
+			for _, provider := range providers[usage.SystemSchemas[record.System]] {
+				if record.Namespace == "" || record.Namespace == provider.Namespace {
+					candidates = append(candidates, provider)
+				}
+			}
+			if len(candidates) == 1 {
+				record.Namespace, record.Provider = candidates[0].Namespace, candidates[0].Provider
+			}
+		}
+		if (record.Namespace == "" && len(query.Namespaces) > 0) || (record.Provider == "" && query.Provider != "") {
+			continue
+		}
+		if query.Matches(record.Key) {
+			attributed = append(attributed, record)
+		}
+	}
+	usage.SortRecords(attributed)
+	return attributed, nil
+}
@@ -0,0 +1,115 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	aigatewayv1alpha1 "github.com/envoyproxy/ai-gateway/api/v1alpha1"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/usage"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/client"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/llm"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// openAIBackend returns an AIServiceBackend of the OpenAI schema pricing gpt-4o
+func openAIBackend(namespace, name string) *aigatewayv1alpha1.AIServiceBackend {
+	return &aigatewayv1alpha1.AIServiceBackend{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Annotations: map[string]string{
+			llm.AnnotationModels: `[{"name":"gpt-4o","pricing":{"input":2,"output":10}}]`,
+		}},
+		Spec: aigatewayv1alpha1.AIServiceBackendSpec{
+			APISchema: aigatewayv1alpha1.VersionedAPISchema{Name: aigatewayv1alpha1.APISchemaOpenAI},
+		},
+	}
+}
+
+// newUsageService returns a service whose collector has scraped 1000 input and 100 output
+// tokens of gpt-4o that AI Gateway reported by the openai system name
+func newUsageService(t *testing.T, objs ...ctrlclient.Object) *LLMProviderService {
+	t.Helper()
+	var input, output int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintf(w, "%[1]s{gen_ai_request_model=\"gpt-4o\",gen_ai_system_name=\"openai\",gen_ai_token_type=\"input\"} %[2]d\n"+
+			"%[1]s{gen_ai_request_model=\"gpt-4o\",gen_ai_system_name=\"openai\",gen_ai_token_type=\"output\"} %[3]d\n",
+			usage.DefaultMetric, input, output)
+	}))
+	t.Cleanup(srv.Close)
+
+	collector := usage.NewCollector(usage.Config{
+		MetricsURL:     srv.URL,
+		ScrapeInterval: time.Minute,
+		Retention:      time.Hour,
+		Metric:         usage.DefaultMetric,
+		ModelLabel:     usage.DefaultModelLabel,
+		BackendLabel:   usage.DefaultBackendLabel,
+		TokenTypeLabel: usage.DefaultTokenTypeLabel,
+	})
+	require.NoError(t, collector.Scrape(context.Background()))
+	input, output = 1000, 100
+	require.NoError(t, collector.Scrape(context.Background()))
+
+	scheme, err := client.NewScheme()
+	require.NoError(t, err)
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	s := NewLLMProviderService(client.NewManagerWithClient(k8sClient, logr.Discard()), WithUsage(collector))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// usageQuery covers the last and next hour in one bucket
+func usageQuery(namespaces ...string) usage.Query {
+	now := time.Now()
+	return usage.Query{Since: now.Add(-time.Hour), Until: now.Add(time.Hour), Bucket: time.Hour, Namespaces: namespaces}
+}
+
+func TestGetUsageAttributesSystemNames(t *testing.T) {
+	s := newUsageService(t, openAIBackend("default", "openai"))
+
+	report, err := s.GetUsage(context.Background(), usageQuery("default"))
+	require.NoError(t, err)
+	require.Len(t, report.Items, 1)
+	item := report.Items[0]
+	assert.Equal(t, usage.Key{Namespace: "default", Provider: "openai", Model: "gpt-4o", System: "openai"}, item.Key)
+	assert.Equal(t, int64(1100), item.TotalTokens)
+	require.NotNil(t, item.Cost)
+	assert.InDelta(t, 0.003, *item.Cost, 1e-9)
+
+	query := usageQuery("default")
+	query.Provider = "azure"
+	report, err = s.GetUsage(context.Background(), query)
+	require.NoError(t, err)
+	assert.Empty(t, report.Items)
+
+	report, err = s.GetUsage(context.Background(), usageQuery("team-b"))
+	require.NoError(t, err)
+	assert.Empty(t, report.Items)
+}
+
+func TestGetUsageLeavesAmbiguousSystemNames(t *testing.T) {
+	s := newUsageService(t, openAIBackend("default", "openai"), openAIBackend("team-b", "openai"))
+
+	// Either provider may have served the tokens
+	report, err := s.GetUsage(context.Background(), usageQuery("default"))
+	require.NoError(t, err)
+	assert.Empty(t, report.Items)
+
+	report, err = s.GetUsage(context.Background(), usageQuery())
+	require.NoError(t, err)
+	require.Len(t, report.Items, 1)
+	assert.Equal(t, usage.Key{Model: "gpt-4o", System: "openai"}, report.Items[0].Key)
+	assert.Nil(t, report.Items[0].Cost)
+}
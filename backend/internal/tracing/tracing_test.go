@@ -0,0 +1,31 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+func TestSetup(t *testing.T) {
+	ctx := context.Background()
+
+	for _, exporter := range []string{ExporterNone, ExporterStdout, ExporterOTLP} {
+		shutdown, err := Setup(ctx, Config{Exporter: exporter, Endpoint: "http://localhost:4318"}, logr.Discard())
+		require.NoError(t, err, exporter)
+		assert.NoError(t, shutdown(ctx), exporter)
+	}
+	assert.ElementsMatch(t, []string{"traceparent", "tracestate", "baggage"}, otel.GetTextMapPropagator().Fields())
+
+	_, err := Setup(ctx, Config{Exporter: "jaeger"}, logr.Discard())
+	assert.Error(t, err)
+	assert.Error(t, Config{Exporter: "jaeger"}.Validate())
+}
@@ -0,0 +1,315 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+// Package usage scrapes the token metrics of the AI Gateway and aggregates them over time
+package usage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	TokenTypeInput  = "input"
+	TokenTypeOutput = "output"
+)
+
+// SystemSchemas maps the system names AI Gateway reports instead of the backend name to the
+// API schema of those backends
+var SystemSchemas = map[string]string{"openai": "OpenAI", "aws.bedrock": "AWSBedrock"}
+
+// Key identifies the usage of a model through a provider
+type Key struct {
+	Namespace string `json:"namespace"`
+	Provider  string `json:"provider"`
+	Model     string `json:"model"`
+	// System is the system name AI Gateway reported instead of the backend. The namespace and
+	// provider are then unknown until attributed to the provider of that schema.
+	System string `json:"system,omitempty"`
+}
+
+// Record is the usage of a model through a provider during a time bucket
+type Record struct {
+	Key
+	Start        time.Time `json:"start"`
+	InputTokens  int64     `json:"inputTokens"`
+	OutputTokens int64     `json:"outputTokens"`
+	TotalTokens  int64     `json:"totalTokens"`
+	// Cost in USD, omitted when the provider has no price for the model
+	Cost *float64 `json:"cost,omitempty"`
+}
+
+// Query selects and aggregates usage
+type Query struct {
+	Since, Until time.Time
+	// Bucket is the width of the time buckets, a multiple of the scrape interval
+	Bucket time.Duration
+	// Namespaces restricts the usage to these namespaces, all when empty
+	Namespaces []string
+	Provider   string
+	Model      string
+}
+
+type tokens struct {
+	input, output float64
+}
+
+// Collector periodically scrapes the token counters of the AI Gateway and keeps the tokens
+// consumed between scrapes in memory
+type Collector struct {
+	cfg        Config
+	httpClient *http.Client
+	now        func() time.Time
+
+	mu         sync.RWMutex
+	last       map[string]float64 // counter value by series
+	buckets    map[time.Time]map[Key]*tokens
+	lastScrape time.Time
+	lastErr    error
+}
+
+// NewCollector creates a collector for the configuration
+func NewCollector(cfg Config) *Collector {
+	return &Collector{
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		now:        time.Now,
+		buckets:    map[time.Time]map[Key]*tokens{},
+	}
+}
+
+// Interval returns the scrape interval, the finest bucket of queries
+func (c *Collector) Interval() time.Duration {
+	return c.cfg.ScrapeInterval
+}
+
+// Run scrapes the metrics every scrape interval until the context is done.
+// Failed scrapes are logged with the logger of the context.
+func (c *Collector) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.cfg.ScrapeInterval)
+	defer ticker.Stop()
+	for {
+		if err := c.Scrape(ctx); err != nil {
+			logr.FromContextOrDiscard(ctx).Error(err, "Failed to scrape AI Gateway usage metrics", "url", c.cfg.MetricsURL)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Status returns the time of the last successful scrape and the error of the last one
+func (c *Collector) Status() (time.Time, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.lastScrape, c.lastErr
+}
+
+// Scrape reads the counters once and records the tokens consumed since the previous scrape.
+// The first scrape only sets the baseline, as earlier tokens cannot be placed in time.
+func (c *Collector) Scrape(ctx context.Context) error {
+	samples, err := c.fetch(ctx)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lastErr = err
+	if err != nil {
+		return err
+	}
+
+	now := c.now()
+	primed := c.last != nil
+	current := make(map[string]float64, len(samples))
+	bucket := now.Truncate(c.cfg.ScrapeInterval)
+	for _, s := range samples {
+		series := seriesKey(s.labels)
+		current[series] = s.value
+		if !primed {
+			continue
+		}
+
+		delta := s.value - c.last[series]
+		if delta < 0 {
+			// The counter was reset, e.g. by a restart of the gateway
+			delta = s.value
+		}
+		if delta == 0 {
+			continue
+		}
+		c.add(bucket, c.key(s.labels), s.labels[c.cfg.TokenTypeLabel], delta)
+	}
+	c.last = current
+	c.lastScrape = now
+
+	for start := range c.buckets {
+		if start.Before(now.Add(-c.cfg.Retention)) {
+			delete(c.buckets, start)
+		}
+	}
+	return nil
+}
+
+// fetch reads the samples of the token metric from the metrics endpoint
+func (c *Collector) fetch(ctx context.Context) ([]sample, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.cfg.MetricsURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid metrics URL: %w", err)
+	}
+	req.Header.Set("Accept", "text/plain")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", c.cfg.MetricsURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get %s: %s", c.cfg.MetricsURL, resp.Status)
+	}
+	return parseSamples(resp.Body, c.cfg.Metric)
+}
+
+// add records tokens of a type in a bucket
+func (c *Collector) add(bucket time.Time, key Key, tokenType string, value float64) {
+	if tokenType != TokenTypeInput && tokenType != TokenTypeOutput {
+		// Totals are derived from input and output
+		return
+	}
+	usage := c.buckets[bucket]
+	if usage == nil {
+		usage = map[Key]*tokens{}
+		c.buckets[bucket] = usage
+	}
+	t := usage[key]
+	if t == nil {
+		t = &tokens{}
+		usage[key] = t
+	}
+	if tokenType == TokenTypeInput {
+		t.input += value
+	} else {
+		t.output += value
+	}
+}
+
+// key resolves the provider and namespace of a series. AI Gateway names backends name.namespace,
+// except for the schemas it reports by system name, which name no provider.
+func (c *Collector) key(labels map[string]string) Key {
+	key := Key{Model: labels[c.cfg.ModelLabel]}
+	if c.cfg.NamespaceLabel != "" {
+		key.Namespace = labels[c.cfg.NamespaceLabel]
+	}
+	backend := labels[c.cfg.BackendLabel]
+	switch i := strings.LastIndexByte(backend, '.'); {
+	case SystemSchemas[backend] != "":
+		key.System = backend
+	case key.Namespace == "" && i > 0:
+		key.Provider, key.Namespace = backend[:i], backend[i+1:]
+	default:
+		key.Provider = backend
+	}
+	return key
+}
+
+// Query returns the usage aggregated by namespace, provider, model and time bucket, sorted by
+// bucket, namespace, provider and model. Usage reported by system name matches every provider
+// and, without a namespace, every namespace, as its provider is unknown.
+func (c *Collector) Query(q Query) []Record {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	type aggregate struct {
+		Key
+		start time.Time
+	}
+	totals := map[aggregate]*tokens{}
+	for start, usage := range c.buckets {
+		if start.Before(q.Since) || !start.Before(q.Until) {
+			continue
+		}
+		for key, t := range usage {
+			if !q.Matches(key) {
+				continue
+			}
+			agg := aggregate{Key: key, start: start.Truncate(q.Bucket)}
+			if totals[agg] == nil {
+				totals[agg] = &tokens{}
+			}
+			totals[agg].input += t.input
+			totals[agg].output += t.output
+		}
+	}
+
+	records := make([]Record, 0, len(totals))
+	for agg, t := range totals {
+		records = append(records, Record{
+			Key:          agg.Key,
+			Start:        agg.start,
+			InputTokens:  int64(t.input),
+			OutputTokens: int64(t.output),
+			TotalTokens:  int64(t.input) + int64(t.output),
+		})
+	}
+	SortRecords(records)
+	return records
+}
+
+// Matches reports whether the usage of the key is selected by the query. Unknown namespaces and
+// providers of keys with a system name match.
+func (q Query) Matches(key Key) bool {
+	if len(q.Namespaces) > 0 && !slices.Contains(q.Namespaces, key.Namespace) && (key.System == "" || key.Namespace != "") {
+		return false
+	}
+	if q.Provider != "" && q.Provider != key.Provider && (key.System == "" || key.Provider != "") {
+		return false
+	}
+	return q.Model == "" || q.Model == key.Model
+}
+
+// SortRecords sorts records by bucket, namespace, provider, system and model
+func SortRecords(records []Record) {
+	sort.Slice(records, func(i, j int) bool {
+		a, b := records[i], records[j]
+		if !a.Start.Equal(b.Start) {
+			return a.Start.Before(b.Start)
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Provider != b.Provider {
+			return a.Provider < b.Provider
+		}
+		if a.System != b.System {
+			return a.System < b.System
+		}
+		return a.Model < b.Model
+	})
+}
+
+// seriesKey identifies a series by its sorted labels
+func seriesKey(labels map[string]string) string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString(name)
+		b.WriteByte('=')
+		b.WriteString(labels[name])
+		b.WriteByte(0)
+	}
+	return b.String()
+}
@@ -0,0 +1,44 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package usage
+
+import "time"
+
+const (
+	// DefaultMetric is the token usage histogram sum exported by the AI Gateway external processor
+	DefaultMetric         = "gen_ai_client_token_usage_token_sum"
+	DefaultModelLabel     = "gen_ai_request_model"
+	DefaultBackendLabel   = "gen_ai_system_name"
+	DefaultTokenTypeLabel = "gen_ai_token_type"
+
+	DefaultScrapeInterval = time.Minute
+	DefaultRetention      = 7 * 24 * time.Hour
+)
+
+// Config configures the scraping of AI Gateway token metrics
+type Config struct {
+	// MetricsURL is the Prometheus endpoint of the AI Gateway; usage is disabled when empty
+	MetricsURL string
+	// ScrapeInterval is the time between scrapes and the finest bucket of the usage API
+	ScrapeInterval time.Duration
+	// Retention is how long usage is kept in memory
+	Retention time.Duration
+
+	// Metric is the counter of tokens, labelled by model, backend and token type
+	Metric     string
+	ModelLabel string
+	// BackendLabel is name.namespace of the AIServiceBackend, or the system name AI Gateway
+	// reports instead for the schemas of SystemSchemas
+	BackendLabel   string
+	TokenTypeLabel string
+	// NamespaceLabel is an optional label carrying the namespace of the backend
+	NamespaceLabel string
+}
+
+// Enabled reports whether usage is scraped
+func (c Config) Enabled() bool {
+	return c.MetricsURL != ""
+}
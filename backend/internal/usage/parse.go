@@ -0,0 +1,117 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package usage
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// sample is a value of a Prometheus series
+type sample struct {
+	labels map[string]string
+	value  float64
+}
+
+// parseSamples reads the samples of one metric from the Prometheus text exposition format
+func parseSamples(r io.Reader, metric string) ([]sample, error) {
+	var samples []sample
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		name := text
+		if i := strings.IndexAny(text, "{ \t"); i >= 0 {
+			name = text[:i]
+		}
+		if name != metric {
+			continue
+		}
+
+		s, err := parseSample(text[len(name):])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		samples = append(samples, s)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read metrics: %w", err)
+	}
+	return samples, nil
+}
+
+// parseSample parses the optional label set, the value and the ignored timestamp following a metric name
+func parseSample(text string) (sample, error) {
+	s := sample{labels: map[string]string{}}
+	if strings.HasPrefix(text, "{") {
+		rest, err := parseLabels(text[1:], s.labels)
+		if err != nil {
+			return s, err
+		}
+		text = rest
+	}
+
+	fields := strings.Fields(text)
+	if len(fields) == 0 || len(fields) > 2 {
+		return s, fmt.Errorf("expected a value and an optional timestamp, got %q", text)
+	}
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return s, fmt.Errorf("invalid value %q", fields[0])
+	}
+	s.value = value
+	return s, nil
+}
+
+// parseLabels reads name="value" pairs up to the closing brace and returns the remaining text
+func parseLabels(text string, labels map[string]string) (string, error) {
+	for {
+		text = strings.TrimLeft(text, " \t,")
+		if strings.HasPrefix(text, "}") {
+			return text[1:], nil
+		}
+
+		eq := strings.IndexByte(text, '=')
+		if eq <= 0 || len(text) < eq+2 || text[eq+1] != '"' {
+			return "", fmt.Errorf("invalid label set")
+		}
+		name := strings.TrimSpace(text[:eq])
+		text = text[eq+2:]
+
+		var value strings.Builder
+		closed := false
+		for i := 0; i < len(text); i++ {
+			switch c := text[i]; {
+			case c == '\\' && i+1 < len(text):
+				i++
+				switch text[i] {
+				case 'n':
+					value.WriteByte('\n')
+				default:
+					value.WriteByte(text[i])
+				}
+			case c == '"':
+				text, closed = text[i+1:], true
+			default:
+				value.WriteByte(c)
+			}
+			if closed {
+				break
+			}
+		}
+		if !closed {
+			return "", fmt.Errorf("unterminated value of label %s", name)
+		}
+		labels[name] = value.String()
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package usage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSamples(t *testing.T) {
+	text := `# HELP gen_ai_client_token_usage_token Number of tokens processed.
+# TYPE gen_ai_client_token_usage_token histogram
+gen_ai_client_token_usage_token_bucket{gen_ai_token_type="input",le="1"} 0
+gen_ai_client_token_usage_token_sum{gen_ai_request_model="gpt-4o",gen_ai_system_name="azure.default",gen_ai_token_type="input"} 1200
+gen_ai_client_token_usage_token_sum{gen_ai_request_model="say \"hi\"\\now",gen_ai_token_type="output",} 3.5e+02 1712345678000
+gen_ai_client_token_usage_token_count 4
+`
+	samples, err := parseSamples(strings.NewReader(text), DefaultMetric)
+	require.NoError(t, err)
+	require.Len(t, samples, 2)
+	assert.Equal(t, map[string]string{
+		"gen_ai_request_model": "gpt-4o",
+		"gen_ai_system_name":   "azure.default",
+		"gen_ai_token_type":    "input",
+	}, samples[0].labels)
+	assert.InDelta(t, 1200, samples[0].value, 0)
+	assert.Equal(t, `say "hi"\now`, samples[1].labels["gen_ai_request_model"])
+	assert.InDelta(t, 350, samples[1].value, 0)
+
+	_, err = parseSamples(strings.NewReader(DefaultMetric+`{gen_ai_token_type="input} 1`), DefaultMetric)
+	assert.Error(t, err)
+}
+
+// newTestCollector returns a collector scraping the counters returned by the metrics function
+func newTestCollector(t *testing.T, metrics func() string) (*Collector, *time.Time) {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, metrics())
+	}))
+	t.Cleanup(srv.Close)
+
+	now := time.Date(2025, 8, 1, 10, 0, 0, 0, time.UTC)
+	c := NewCollector(Config{
+		MetricsURL:     srv.URL,
+		ScrapeInterval: time.Minute,
+		Retention:      time.Hour,
+		Metric:         DefaultMetric,
+		ModelLabel:     DefaultModelLabel,
+		BackendLabel:   DefaultBackendLabel,
+		TokenTypeLabel: DefaultTokenTypeLabel,
+	})
+	c.now = func() time.Time { return now }
+	return c, &now
+}
+
+func counters(input, output float64) string {
+	return fmt.Sprintf(`%[1]s{gen_ai_request_model="gpt-4o",gen_ai_system_name="azure.team-a",gen_ai_token_type="input"} %[2]g
+%[1]s{gen_ai_request_model="gpt-4o",gen_ai_system_name="azure.team-a",gen_ai_token_type="output"} %[3]g
+%[1]s{gen_ai_request_model="gpt-4o",gen_ai_system_name="azure.team-a",gen_ai_token_type="total"} %[4]g
+%[1]s{gen_ai_request_model="gpt-4o-mini",gen_ai_system_name="aws.bedrock",gen_ai_token_type="input"} 10
+`, DefaultMetric, input, output, input+output)
+}
+
+func TestCollectorScrape(t *testing.T) {
+	input, output := 1000.0, 500.0
+	c, now := newTestCollector(t, func() string { return counters(input, output) })
+	ctx := context.Background()
+
+	// The first scrape only sets the baseline
+	require.NoError(t, c.Scrape(ctx))
+	assert.Empty(t, c.Query(Query{Since: now.Add(-time.Hour), Until: now.Add(time.Hour), Bucket: time.Hour}))
+
+	*now = now.Add(time.Minute)
+	input, output = 1300, 600
+	require.NoError(t, c.Scrape(ctx))
+
+	// The counters restart with the gateway
+	*now = now.Add(time.Minute)
+	input, output = 50, 20
+	require.NoError(t, c.Scrape(ctx))
+
+	records := c.Query(Query{Since: now.Add(-time.Hour), Until: now.Add(time.Hour), Bucket: time.Hour})
+	assert.Equal(t, []Record{{
+		Key:          Key{Namespace: "team-a", Provider: "azure", Model: "gpt-4o"},
+		Start:        now.Truncate(time.Hour),
+		InputTokens:  350,
+		OutputTokens: 120,
+		TotalTokens:  470,
+	}}, records)
+
+	perMinute := c.Query(Query{Since: now.Add(-time.Hour), Until: now.Add(time.Hour), Bucket: time.Minute, Namespaces: []string{"team-a"}})
+	require.Len(t, perMinute, 2)
+	assert.Equal(t, int64(300), perMinute[0].InputTokens)
+	assert.Equal(t, int64(50), perMinute[1].InputTokens)
+
+	assert.Empty(t, c.Query(Query{Since: now.Add(-time.Hour), Until: now.Add(time.Hour), Bucket: time.Hour, Namespaces: []string{"team-b"}}))
+	assert.Empty(t, c.Query(Query{Since: now.Add(-time.Hour), Until: now.Add(time.Hour), Bucket: time.Hour, Model: "o3"}))
+
+	// Buckets older than the retention are dropped
+	*now = now.Add(2 * time.Hour)
+	require.NoError(t, c.Scrape(ctx))
+	assert.Empty(t, c.Query(Query{Since: now.Add(-24 * time.Hour), Until: now.Add(time.Hour), Bucket: time.Hour}))
+
+	lastScrape, err := c.Status()
+	require.NoError(t, err)
+	assert.Equal(t, *now, lastScrape)
+}
+
+func TestCollectorKey(t *testing.T) {
+	c := NewCollector(Config{BackendLabel: "backend", ModelLabel: "model"})
+	assert.Equal(t, Key{Namespace: "default", Provider: "my.provider", Model: "gpt-4o"},
+		c.key(map[string]string{"backend": "my.provider.default", "model": "gpt-4o"}))
+	assert.Equal(t, Key{Model: "claude", System: "aws.bedrock"},
+		c.key(map[string]string{"backend": "aws.bedrock", "model": "claude"}))
+
+	c.cfg.NamespaceLabel = "namespace"
+	assert.Equal(t, Key{Namespace: "team-a", System: "openai"},
+		c.key(map[string]string{"backend": "openai", "namespace": "team-a"}))
+	assert.Equal(t, Key{Namespace: "team-a", Provider: "azure.eu"},
+		c.key(map[string]string{"backend": "azure.eu", "namespace": "team-a"}))
+}
+
+func TestQueryMatches(t *testing.T) {
+	query := Query{Namespaces: []string{"team-a"}, Provider: "openai", Model: "gpt-4o"}
+	assert.True(t, query.Matches(Key{Namespace: "team-a", Provider: "openai", Model: "gpt-4o"}))
+	assert.False(t, query.Matches(Key{Namespace: "team-b", Provider: "openai", Model: "gpt-4o"}))
+	assert.False(t, query.Matches(Key{Namespace: "team-a", Provider: "azure", Model: "gpt-4o"}))
+	assert.False(t, query.Matches(Key{Namespace: "team-a", Provider: "openai", Model: "o3"}))
+
+	// The namespace and provider of usage reported by system name are not known yet
+	assert.True(t, query.Matches(Key{Model: "gpt-4o", System: "openai"}))
+	assert.False(t, query.Matches(Key{Namespace: "team-b", Model: "gpt-4o", System: "openai"}))
+	assert.False(t, query.Matches(Key{Model: "o3", System: "openai"}))
+}
+
+func TestCollectorScrapeError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c := NewCollector(Config{MetricsURL: srv.URL, ScrapeInterval: time.Minute, Metric: DefaultMetric})
+	require.Error(t, c.Scrape(context.Background()))
+	_, err := c.Status()
+	assert.ErrorContains(t, err, "503")
+}
+
+func TestCollectorRunStops(t *testing.T) {
+	c, _ := newTestCollector(t, func() string { return counters(1, 1) })
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		c.Run(ctx)
+		close(done)
+	}()
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("collector still running after its context was canceled")
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	aigv1a1 "github.com/envoyproxy/ai-gateway/api/v1alpha1"
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// AIGatewayRouteClient handles operations for AIGatewayRoute resources
+type AIGatewayRouteClient struct {
+	client client.Client
+	logger logr.Logger
+}
+
+// NewAIGatewayRouteClient creates a new AIGatewayRouteClient
+func NewAIGatewayRouteClient(client client.Client, logger logr.Logger) *AIGatewayRouteClient {
+	return &AIGatewayRouteClient{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Create creates a new AIGatewayRoute
+func (c *AIGatewayRouteClient) Create(ctx context.Context, route *aigv1a1.AIGatewayRoute) error {
+	if err := c.client.Create(ctx, route); err != nil {
+		return fmt.Errorf("failed to create AIGatewayRoute: %w", err)
+	}
+	return nil
+}
+
+// Get retrieves a specific AIGatewayRoute by name in a namespace
+func (c *AIGatewayRouteClient) Get(ctx context.Context, namespace, name string) (*aigv1a1.AIGatewayRoute, error) {
+	var route aigv1a1.AIGatewayRoute
+	if err := c.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &route); err != nil {
+		return nil, fmt.Errorf("failed to get AIGatewayRoute: %w", err)
+	}
+	return &route, nil
+}
+
+// List retrieves all AIGatewayRoute resources in a namespace, or in all namespaces for AllNamespaces
+func (c *AIGatewayRouteClient) List(ctx context.Context, namespace string, opts ...client.ListOption) (*aigv1a1.AIGatewayRouteList, error) {
+	var list aigv1a1.AIGatewayRouteList
+	if err := c.client.List(ctx, &list, append(namespaceListOptions(namespace), opts...)...); err != nil {
+		return nil, fmt.Errorf("failed to list AIGatewayRoutes: %w", err)
+	}
+	return &list, nil
+}
+
+// Update updates an existing AIGatewayRoute
+func (c *AIGatewayRouteClient) Update(ctx context.Context, route *aigv1a1.AIGatewayRoute) error {
+	if err := c.client.Update(ctx, route); err != nil {
+		return fmt.Errorf("failed to update AIGatewayRoute: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes an AIGatewayRoute by name in a namespace
+func (c *AIGatewayRouteClient) Delete(ctx context.Context, namespace, name string) error {
+	route := &aigv1a1.AIGatewayRoute{}
+	route.Namespace = namespace
+	route.Name = name
+	if err := c.client.Delete(ctx, route); err != nil {
+		return fmt.Errorf("failed to delete AIGatewayRoute: %w", err)
+	}
+	return nil
+}
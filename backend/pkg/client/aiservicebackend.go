@@ -45,10 +45,10 @@ func (c *AIServiceBackendClient) Get(ctx context.Context, namespace, name string
 	return &backend, nil
 }
 
-// List retrieves all AIServiceBackend resources in a namespace
-func (c *AIServiceBackendClient) List(ctx context.Context, namespace string) (*aigv1a1.AIServiceBackendList, error) {
+// List retrieves all AIServiceBackend resources in a namespace, or in all namespaces for AllNamespaces
+func (c *AIServiceBackendClient) List(ctx context.Context, namespace string, opts ...client.ListOption) (*aigv1a1.AIServiceBackendList, error) {
 	var list aigv1a1.AIServiceBackendList
-	if err := c.client.List(ctx, &list, client.InNamespace(namespace)); err != nil {
+	if err := c.client.List(ctx, &list, append(namespaceListOptions(namespace), opts...)...); err != nil {
 		return nil, fmt.Errorf("failed to list AIServiceBackends: %w", err)
 	}
 	return &list, nil
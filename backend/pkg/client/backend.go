@@ -45,10 +45,10 @@ func (c *BackendClient) Get(ctx context.Context, namespace, name string) (*gwapi
 	return &backend, nil
 }
 
-// List retrieves all Backend resources in a namespace
-func (c *BackendClient) List(ctx context.Context, namespace string) (*gwapiv1a1.BackendList, error) {
+// List retrieves all Backend resources in a namespace, or in all namespaces for AllNamespaces
+func (c *BackendClient) List(ctx context.Context, namespace string, opts ...client.ListOption) (*gwapiv1a1.BackendList, error) {
 	var list gwapiv1a1.BackendList
-	if err := c.client.List(ctx, &list, client.InNamespace(namespace)); err != nil {
+	if err := c.client.List(ctx, &list, append(namespaceListOptions(namespace), opts...)...); err != nil {
 		return nil, fmt.Errorf("failed to list Backends: %w", err)
 	}
 	return &list, nil
@@ -45,10 +45,10 @@ func (c *BackendSecurityPolicyClient) Get(ctx context.Context, namespace, name s
 	return &policy, nil
 }
 
-// List retrieves all BackendSecurityPolicy resources in a namespace
-func (c *BackendSecurityPolicyClient) List(ctx context.Context, namespace string) (*aigv1a1.BackendSecurityPolicyList, error) {
+// List retrieves all BackendSecurityPolicy resources in a namespace, or in all namespaces for AllNamespaces
+func (c *BackendSecurityPolicyClient) List(ctx context.Context, namespace string, opts ...client.ListOption) (*aigv1a1.BackendSecurityPolicyList, error) {
 	var list aigv1a1.BackendSecurityPolicyList
-	if err := c.client.List(ctx, &list, client.InNamespace(namespace)); err != nil {
+	if err := c.client.List(ctx, &list, append(namespaceListOptions(namespace), opts...)...); err != nil {
 		return nil, fmt.Errorf("failed to list BackendSecurityPolicies: %w", err)
 	}
 	return &list, nil
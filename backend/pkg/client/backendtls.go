@@ -10,17 +10,26 @@ import (
 	"fmt"
 
 	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	gwapiv1a3 "sigs.k8s.io/gateway-api/apis/v1alpha3"
 )
 
-// BackendTLSPolicyClient handles operations for BackendTLSPolicy resources
+// BackendTLSPolicyClient handles operations for BackendTLSPolicy resources.
+// Policies are read and written in the served version: v1alpha3 through the typed
+// client, v1 as unstructured objects converted from and to the v1alpha3 type. The
+// vendored Gateway API has no v1 type; a v1 spec with fields the v1alpha3 type does not
+// have fails to read rather than losing the fields on the next write.
 type BackendTLSPolicyClient struct {
 	client client.Client
 	logger logr.Logger
+	// version returns the Gateway API version to use, v1alpha3 if nil
+	version func() string
 }
 
-// NewBackendTLSPolicyClient creates a new BackendTLSPolicyClient
+// NewBackendTLSPolicyClient creates a new BackendTLSPolicyClient for v1alpha3
 func NewBackendTLSPolicyClient(client client.Client, logger logr.Logger) *BackendTLSPolicyClient {
 	return &BackendTLSPolicyClient{
 		client: client,
@@ -28,9 +37,24 @@ func NewBackendTLSPolicyClient(client client.Client, logger logr.Logger) *Backen
 	}
 }
 
+// NewBackendTLSPolicyClientForVersion creates a new BackendTLSPolicyClient for the Gateway API version
+func NewBackendTLSPolicyClientForVersion(client client.Client, version string, logger logr.Logger) *BackendTLSPolicyClient {
+	c := NewBackendTLSPolicyClient(client, logger)
+	c.version = func() string { return version }
+	return c
+}
+
+// Version returns the Gateway API version the client reads and writes
+func (c *BackendTLSPolicyClient) Version() string {
+	if c.version == nil {
+		return gwapiv1a3.GroupVersion.Version
+	}
+	return c.version()
+}
+
 // Create creates a new BackendTLSPolicy
 func (c *BackendTLSPolicyClient) Create(ctx context.Context, policy *gwapiv1a3.BackendTLSPolicy) error {
-	if err := c.client.Create(ctx, policy); err != nil {
+	if err := c.write(policy, func(obj client.Object) error { return c.client.Create(ctx, obj) }); err != nil {
 		return fmt.Errorf("failed to create BackendTLSPolicy: %w", err)
 	}
 	return nil
@@ -39,24 +63,53 @@ func (c *BackendTLSPolicyClient) Create(ctx context.Context, policy *gwapiv1a3.B
 // Get retrieves a specific BackendTLSPolicy by name in a namespace
 func (c *BackendTLSPolicyClient) Get(ctx context.Context, namespace, name string) (*gwapiv1a3.BackendTLSPolicy, error) {
 	var policy gwapiv1a3.BackendTLSPolicy
-	if err := c.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &policy); err != nil {
-		return nil, fmt.Errorf("failed to get BackendTLSPolicy: %w", err)
+	key := client.ObjectKey{Namespace: namespace, Name: name}
+	if gvk, typed := c.gvk(); typed {
+		if err := c.client.Get(ctx, key, &policy); err != nil {
+			return nil, fmt.Errorf("failed to get BackendTLSPolicy: %w", err)
+		}
+	} else {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gvk)
+		if err := c.client.Get(ctx, key, obj); err != nil {
+			return nil, fmt.Errorf("failed to get BackendTLSPolicy: %w", err)
+		}
+		if err := readPolicy(obj.Object, &policy); err != nil {
+			return nil, fmt.Errorf("failed to read BackendTLSPolicy %s: %w", gvk.Version, err)
+		}
 	}
 	return &policy, nil
 }
 
-// List retrieves all BackendTLSPolicy resources in a namespace
-func (c *BackendTLSPolicyClient) List(ctx context.Context, namespace string) (*gwapiv1a3.BackendTLSPolicyList, error) {
+// List retrieves all BackendTLSPolicy resources in a namespace, or in all namespaces for AllNamespaces
+func (c *BackendTLSPolicyClient) List(ctx context.Context, namespace string, opts ...client.ListOption) (*gwapiv1a3.BackendTLSPolicyList, error) {
 	var list gwapiv1a3.BackendTLSPolicyList
-	if err := c.client.List(ctx, &list, client.InNamespace(namespace)); err != nil {
-		return nil, fmt.Errorf("failed to list BackendTLSPolicies: %w", err)
+	opts = append(namespaceListOptions(namespace), opts...)
+	if gvk, typed := c.gvk(); typed {
+		if err := c.client.List(ctx, &list, opts...); err != nil {
+			return nil, fmt.Errorf("failed to list BackendTLSPolicies: %w", err)
+		}
+	} else {
+		objs := &unstructured.UnstructuredList{}
+		objs.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := c.client.List(ctx, objs, opts...); err != nil {
+			return nil, fmt.Errorf("failed to list BackendTLSPolicies: %w", err)
+		}
+		list.ResourceVersion, list.Continue = objs.GetResourceVersion(), objs.GetContinue()
+		list.RemainingItemCount = objs.GetRemainingItemCount()
+		list.Items = make([]gwapiv1a3.BackendTLSPolicy, len(objs.Items))
+		for i := range objs.Items {
+			if err := readPolicy(objs.Items[i].Object, &list.Items[i]); err != nil {
+				return nil, fmt.Errorf("failed to read BackendTLSPolicy %s %s/%s: %w", gvk.Version, objs.Items[i].GetNamespace(), objs.Items[i].GetName(), err)
+			}
+		}
 	}
 	return &list, nil
 }
 
 // Update updates an existing BackendTLSPolicy
 func (c *BackendTLSPolicyClient) Update(ctx context.Context, policy *gwapiv1a3.BackendTLSPolicy) error {
-	if err := c.client.Update(ctx, policy); err != nil {
+	if err := c.write(policy, func(obj client.Object) error { return c.client.Update(ctx, obj) }); err != nil {
 		return fmt.Errorf("failed to update BackendTLSPolicy: %w", err)
 	}
 	return nil
@@ -64,11 +117,54 @@ func (c *BackendTLSPolicyClient) Update(ctx context.Context, policy *gwapiv1a3.B
 
 // Delete deletes a BackendTLSPolicy by name in a namespace
 func (c *BackendTLSPolicyClient) Delete(ctx context.Context, namespace, name string) error {
-	policy := &gwapiv1a3.BackendTLSPolicy{}
-	policy.Namespace = namespace
-	policy.Name = name
+	var policy client.Object = &gwapiv1a3.BackendTLSPolicy{}
+	if gvk, typed := c.gvk(); !typed {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gvk)
+		policy = obj
+	}
+	policy.SetNamespace(namespace)
+	policy.SetName(name)
 	if err := c.client.Delete(ctx, policy); err != nil {
 		return fmt.Errorf("failed to delete BackendTLSPolicy: %w", err)
 	}
 	return nil
 }
+
+// gvk returns the GroupVersionKind of the policies and whether the typed client serves it
+func (c *BackendTLSPolicyClient) gvk() (schema.GroupVersionKind, bool) {
+	version := c.Version()
+	return schema.GroupVersionKind{Group: GroupGatewayAPI, Version: version, Kind: "BackendTLSPolicy"}, version == gwapiv1a3.GroupVersion.Version
+}
+
+// write creates or updates the policy in the served version and reads back the result
+func (c *BackendTLSPolicyClient) write(policy *gwapiv1a3.BackendTLSPolicy, call func(client.Object) error) error {
+	gvk, typed := c.gvk()
+	if typed {
+		return call(policy)
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(policy)
+	if err != nil {
+		return fmt.Errorf("failed to convert to %s: %w", gvk.Version, err)
+	}
+	obj := &unstructured.Unstructured{Object: content}
+	obj.SetGroupVersionKind(gvk)
+	if err := call(obj); err != nil {
+		return err
+	}
+	return readPolicy(obj.Object, policy)
+}
+
+// readPolicy converts a policy of another version to the v1alpha3 type. The spec must only
+// have fields of the v1alpha3 type, the status is read as far as the type knows it.
+func readPolicy(content map[string]interface{}, policy *gwapiv1a3.BackendTLSPolicy) error {
+	spec, _, err := unstructured.NestedMap(content, "spec")
+	if err != nil {
+		return err
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructuredWithValidation(spec, &gwapiv1a3.BackendTLSPolicySpec{}, true); err != nil {
+		return fmt.Errorf("the spec does not fit the v1alpha3 schema: %w", err)
+	}
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(content, policy)
+}
@@ -0,0 +1,74 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	gwapiv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// BackendTrafficPolicyClient handles operations for BackendTrafficPolicy resources
+type BackendTrafficPolicyClient struct {
+	client client.Client
+	logger logr.Logger
+}
+
+// NewBackendTrafficPolicyClient creates a new BackendTrafficPolicyClient
+func NewBackendTrafficPolicyClient(client client.Client, logger logr.Logger) *BackendTrafficPolicyClient {
+	return &BackendTrafficPolicyClient{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Create creates a new BackendTrafficPolicy
+func (c *BackendTrafficPolicyClient) Create(ctx context.Context, policy *gwapiv1a1.BackendTrafficPolicy) error {
+	if err := c.client.Create(ctx, policy); err != nil {
+		return fmt.Errorf("failed to create BackendTrafficPolicy: %w", err)
+	}
+	return nil
+}
+
+// Get retrieves a specific BackendTrafficPolicy by name in a namespace
+func (c *BackendTrafficPolicyClient) Get(ctx context.Context, namespace, name string) (*gwapiv1a1.BackendTrafficPolicy, error) {
+	var policy gwapiv1a1.BackendTrafficPolicy
+	if err := c.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &policy); err != nil {
+		return nil, fmt.Errorf("failed to get BackendTrafficPolicy: %w", err)
+	}
+	return &policy, nil
+}
+
+// List retrieves all BackendTrafficPolicy resources in a namespace, or in all namespaces for AllNamespaces
+func (c *BackendTrafficPolicyClient) List(ctx context.Context, namespace string, opts ...client.ListOption) (*gwapiv1a1.BackendTrafficPolicyList, error) {
+	var list gwapiv1a1.BackendTrafficPolicyList
+	if err := c.client.List(ctx, &list, append(namespaceListOptions(namespace), opts...)...); err != nil {
+		return nil, fmt.Errorf("failed to list BackendTrafficPolicies: %w", err)
+	}
+	return &list, nil
+}
+
+// Update updates an existing BackendTrafficPolicy
+func (c *BackendTrafficPolicyClient) Update(ctx context.Context, policy *gwapiv1a1.BackendTrafficPolicy) error {
+	if err := c.client.Update(ctx, policy); err != nil {
+		return fmt.Errorf("failed to update BackendTrafficPolicy: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes a BackendTrafficPolicy by name in a namespace
+func (c *BackendTrafficPolicyClient) Delete(ctx context.Context, namespace, name string) error {
+	policy := &gwapiv1a1.BackendTrafficPolicy{}
+	policy.Namespace = namespace
+	policy.Name = name
+	if err := c.client.Delete(ctx, policy); err != nil {
+		return fmt.Errorf("failed to delete BackendTrafficPolicy: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ConfigMapClient handles operations for ConfigMap resources
+type ConfigMapClient struct {
+	client client.Client
+	logger logr.Logger
+}
+
+// NewConfigMapClient creates a new ConfigMapClient
+func NewConfigMapClient(client client.Client, logger logr.Logger) *ConfigMapClient {
+	return &ConfigMapClient{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Create creates a new ConfigMap
+func (c *ConfigMapClient) Create(ctx context.Context, configMap *corev1.ConfigMap) error {
+	if err := c.client.Create(ctx, configMap); err != nil {
+		return fmt.Errorf("failed to create ConfigMap: %w", err)
+	}
+	return nil
+}
+
+// Get retrieves a specific ConfigMap by name in a namespace
+func (c *ConfigMapClient) Get(ctx context.Context, namespace, name string) (*corev1.ConfigMap, error) {
+	var configMap corev1.ConfigMap
+	if err := c.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &configMap); err != nil {
+		return nil, fmt.Errorf("failed to get ConfigMap: %w", err)
+	}
+	return &configMap, nil
+}
+
+// List retrieves all ConfigMap resources in a namespace, or in all namespaces for AllNamespaces
+func (c *ConfigMapClient) List(ctx context.Context, namespace string, opts ...client.ListOption) (*corev1.ConfigMapList, error) {
+	var list corev1.ConfigMapList
+	if err := c.client.List(ctx, &list, append(namespaceListOptions(namespace), opts...)...); err != nil {
+		return nil, fmt.Errorf("failed to list ConfigMaps: %w", err)
+	}
+	return &list, nil
+}
+
+// Update updates an existing ConfigMap
+func (c *ConfigMapClient) Update(ctx context.Context, configMap *corev1.ConfigMap) error {
+	if err := c.client.Update(ctx, configMap); err != nil {
+		return fmt.Errorf("failed to update ConfigMap: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes a ConfigMap by name in a namespace
+func (c *ConfigMapClient) Delete(ctx context.Context, namespace, name string) error {
+	configMap := &corev1.ConfigMap{}
+	configMap.Namespace = namespace
+	configMap.Name = name
+	if err := c.client.Delete(ctx, configMap); err != nil {
+		return fmt.Errorf("failed to delete ConfigMap: %w", err)
+	}
+	return nil
+}
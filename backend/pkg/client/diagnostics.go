@@ -0,0 +1,186 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// API groups of the resources the console manages
+const (
+	GroupAIGateway    = "aigateway.envoyproxy.io"
+	GroupEnvoyGateway = "gateway.envoyproxy.io"
+	GroupGatewayAPI   = "gateway.networking.k8s.io"
+)
+
+// ServedKinds maps the kinds served by the API server to their served versions,
+// the preferred version first
+type ServedKinds map[schema.GroupKind][]string
+
+// Versions returns the served versions of the kind, none if it is not installed
+func (s ServedKinds) Versions(groupKind schema.GroupKind) []string {
+	return s[groupKind]
+}
+
+// Serves reports whether the version of the kind is served
+func (s ServedKinds) Serves(gvk schema.GroupVersionKind) bool {
+	return slices.Contains(s[gvk.GroupKind()], gvk.Version)
+}
+
+// SetDiscovery replaces the discovery client of the manager, e.g. with a fake in tests
+func (m *Manager) SetDiscovery(discoveryClient discovery.DiscoveryInterface) {
+	m.discovery = discoveryClient
+}
+
+// ServerVersion returns the version of the Kubernetes API server
+func (m *Manager) ServerVersion(ctx context.Context) (string, error) {
+	if m.discovery == nil {
+		return "", fmt.Errorf("client manager has no discovery client")
+	}
+	info, err := m.discovery.ServerVersion()
+	if err != nil {
+		return "", fmt.Errorf("failed to get the Kubernetes version: %w", err)
+	}
+	return info.GitVersion, nil
+}
+
+// DiscoverKinds returns the kinds of the AI Gateway, Envoy Gateway and Gateway API
+// groups served by the API server
+func (m *Manager) DiscoverKinds(ctx context.Context) (ServedKinds, error) {
+	if m.discovery == nil {
+		return nil, fmt.Errorf("client manager has no discovery client")
+	}
+	groups, err := m.discovery.ServerGroups()
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover API groups: %w", err)
+	}
+
+	kinds := ServedKinds{}
+	for _, group := range groups.Groups {
+		if group.Name != GroupAIGateway && group.Name != GroupEnvoyGateway && group.Name != GroupGatewayAPI {
+			continue
+		}
+		// The preferred version is listed first
+		versions := []string{group.PreferredVersion.Version}
+		for _, version := range group.Versions {
+			if version.Version != group.PreferredVersion.Version {
+				versions = append(versions, version.Version)
+			}
+		}
+
+		for _, version := range versions {
+			resources, err := m.discovery.ServerResourcesForGroupVersion(schema.GroupVersion{Group: group.Name, Version: version}.String())
+			if err != nil {
+				if errors.IsNotFound(err) {
+					continue
+				}
+				return nil, fmt.Errorf("failed to discover the resources of %s/%s: %w", group.Name, version, err)
+			}
+			for _, resource := range resources.APIResources {
+				if strings.Contains(resource.Name, "/") {
+					continue // subresource
+				}
+				groupKind := schema.GroupKind{Group: group.Name, Kind: resource.Kind}
+				if !slices.Contains(kinds[groupKind], version) {
+					kinds[groupKind] = append(kinds[groupKind], version)
+				}
+			}
+		}
+	}
+	return kinds, nil
+}
+
+// AccessCheck is a verb on a resource the console needs, answered by CheckAccess
+type AccessCheck struct {
+	Group    string `json:"group"`
+	Resource string `json:"resource"`
+	Verb     string `json:"verb"`
+	// Namespace is empty for all namespaces
+	Namespace string `json:"namespace,omitempty"`
+
+	Allowed bool   `json:"allowed"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// CheckAccess asks the API server with a SelfSubjectAccessReview whether the client
+// may perform each check, filling in Allowed and Reason
+func (m *Manager) CheckAccess(ctx context.Context, checks []AccessCheck) ([]AccessCheck, error) {
+	results := make([]AccessCheck, 0, len(checks))
+	for _, check := range checks {
+		review := &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Namespace: check.Namespace,
+					Verb:      check.Verb,
+					Group:     check.Group,
+					Resource:  check.Resource,
+				},
+			},
+		}
+		if err := m.client.Create(ctx, review); err != nil {
+			return nil, fmt.Errorf("failed to review access to %s %s: %w", check.Verb, check.Resource, err)
+		}
+		check.Allowed = review.Status.Allowed
+		check.Reason = review.Status.Reason
+		results = append(results, check)
+	}
+	return results, nil
+}
+
+// Controller is a controller the console relies on, found by its Deployment
+type Controller struct {
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace"`
+	Deployment string `json:"deployment"`
+}
+
+// DefaultControllers are the Envoy Gateway and AI Gateway controllers as installed by their Helm charts
+var DefaultControllers = []Controller{
+	{Name: "Envoy Gateway", Namespace: "envoy-gateway-system", Deployment: "envoy-gateway"},
+	{Name: "AI Gateway", Namespace: "envoy-ai-gateway-system", Deployment: "ai-gateway-controller"},
+}
+
+// ControllerStatus reports whether a controller is installed and running
+type ControllerStatus struct {
+	Controller
+	Present       bool   `json:"present"`
+	ReadyReplicas int32  `json:"readyReplicas"`
+	Image         string `json:"image,omitempty"`
+	// Error is set when the Deployment could not be read, e.g. for lack of RBAC
+	Error string `json:"error,omitempty"`
+}
+
+// GetControllerStatus reads the Deployment of the controller
+func (m *Manager) GetControllerStatus(ctx context.Context, controller Controller) ControllerStatus {
+	status := ControllerStatus{Controller: controller}
+
+	var deployment appsv1.Deployment
+	err := m.client.Get(ctx, client.ObjectKey{Namespace: controller.Namespace, Name: controller.Deployment}, &deployment)
+	switch {
+	case errors.IsNotFound(err):
+		return status
+	case err != nil:
+		status.Error = err.Error()
+		return status
+	}
+
+	status.Present = true
+	status.ReadyReplicas = deployment.Status.ReadyReplicas
+	if containers := deployment.Spec.Template.Spec.Containers; len(containers) > 0 {
+		status.Image = containers[0].Image
+	}
+	return status
+}
@@ -0,0 +1,114 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// newFakeDiscovery serves the resources, the first version of a group is preferred
+func newFakeDiscovery(resources ...*metav1.APIResourceList) *fakediscovery.FakeDiscovery {
+	return &fakediscovery.FakeDiscovery{Fake: &k8stesting.Fake{Resources: resources}}
+}
+
+func TestManager_DiscoverKinds(t *testing.T) {
+	scheme, err := NewScheme()
+	require.NoError(t, err)
+	manager := NewManagerWithClient(fake.NewClientBuilder().WithScheme(scheme).Build(), logr.Discard())
+
+	_, err = manager.DiscoverKinds(context.Background())
+	assert.Error(t, err, "managers without discovery cannot discover kinds")
+
+	manager.SetDiscovery(newFakeDiscovery(
+		&metav1.APIResourceList{GroupVersion: "gateway.networking.k8s.io/v1", APIResources: []metav1.APIResource{
+			{Name: "backendtlspolicies", Kind: "BackendTLSPolicy"},
+			{Name: "backendtlspolicies/status", Kind: "BackendTLSPolicy"},
+			{Name: "httproutes", Kind: "HTTPRoute"},
+		}},
+		&metav1.APIResourceList{GroupVersion: "gateway.networking.k8s.io/v1alpha3", APIResources: []metav1.APIResource{
+			{Name: "backendtlspolicies", Kind: "BackendTLSPolicy"},
+		}},
+		&metav1.APIResourceList{GroupVersion: "aigateway.envoyproxy.io/v1alpha1", APIResources: []metav1.APIResource{
+			{Name: "aiservicebackends", Kind: "AIServiceBackend"},
+		}},
+		&metav1.APIResourceList{GroupVersion: "apps/v1", APIResources: []metav1.APIResource{
+			{Name: "deployments", Kind: "Deployment"},
+		}},
+	))
+
+	kinds, err := manager.DiscoverKinds(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, ServedKinds{
+		{Group: GroupGatewayAPI, Kind: "BackendTLSPolicy"}: {"v1", "v1alpha3"},
+		{Group: GroupGatewayAPI, Kind: "HTTPRoute"}:        {"v1"},
+		{Group: GroupAIGateway, Kind: "AIServiceBackend"}:  {"v1alpha1"},
+	}, kinds)
+	assert.True(t, kinds.Serves(schema.GroupVersionKind{Group: GroupGatewayAPI, Version: "v1alpha3", Kind: "BackendTLSPolicy"}))
+	assert.False(t, kinds.Serves(schema.GroupVersionKind{Group: GroupEnvoyGateway, Version: "v1alpha1", Kind: "Backend"}))
+
+	version, err := manager.ServerVersion(context.Background())
+	require.NoError(t, err)
+	assert.NotEmpty(t, version)
+}
+
+func TestManager_CheckAccess(t *testing.T) {
+	scheme, err := NewScheme()
+	require.NoError(t, err)
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			review := obj.(*authorizationv1.SelfSubjectAccessReview)
+			review.Status.Allowed = review.Spec.ResourceAttributes.Verb != "delete"
+			return nil
+		},
+	}).Build()
+	manager := NewManagerWithClient(k8sClient, logr.Discard())
+
+	results, err := manager.CheckAccess(context.Background(), []AccessCheck{
+		{Resource: "secrets", Verb: "get", Namespace: "default"},
+		{Resource: "secrets", Verb: "delete", Namespace: "default"},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.True(t, results[0].Allowed)
+	assert.False(t, results[1].Allowed)
+}
+
+func TestManager_GetControllerStatus(t *testing.T) {
+	scheme, err := NewScheme()
+	require.NoError(t, err)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "envoy-gateway", Namespace: "envoy-gateway-system"},
+		Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "envoy-gateway", Image: "envoyproxy/gateway:v1.5.0"}},
+		}}},
+		Status: appsv1.DeploymentStatus{ReadyReplicas: 1},
+	}
+	manager := NewManagerWithClient(fake.NewClientBuilder().WithScheme(scheme).WithObjects(deployment).Build(), logr.Discard())
+
+	status := manager.GetControllerStatus(context.Background(), DefaultControllers[0])
+	assert.True(t, status.Present)
+	assert.Equal(t, int32(1), status.ReadyReplicas)
+	assert.Equal(t, "envoyproxy/gateway:v1.5.0", status.Image)
+
+	status = manager.GetControllerStatus(context.Background(), DefaultControllers[1])
+	assert.False(t, status.Present)
+	assert.Empty(t, status.Error)
+}
@@ -0,0 +1,154 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package client
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// impersonationCacheSize bounds the number of cached per-identity managers
+	impersonationCacheSize = 256
+	// impersonationCacheIdleTTL evicts managers of identities that stopped making requests
+	impersonationCacheIdleTTL = 15 * time.Minute
+)
+
+// Identity is the Kubernetes user a Manager acts as
+type Identity struct {
+	UserName string
+	UID      string
+	Groups   []string
+}
+
+// key returns a stable cache key for the identity
+func (i Identity) key() string {
+	groups := append([]string(nil), i.Groups...)
+	sort.Strings(groups)
+	return i.UserName + "\x00" + i.UID + "\x00" + strings.Join(groups, "\x00")
+}
+
+// Impersonate returns a Manager whose clients act as the given identity, so Kubernetes
+// RBAC and audit logging apply to the user rather than the console's service account.
+// Managers are cached per identity and share the REST mapper of this Manager.
+func (m *Manager) Impersonate(identity Identity) (ManagerInterface, error) {
+	if identity.UserName == "" {
+		return nil, fmt.Errorf("impersonation requires a user name")
+	}
+	if m.impersonation == nil {
+		return nil, fmt.Errorf("client manager does not support impersonation")
+	}
+
+	manager, err := m.impersonation.get(identity, func() (*Manager, error) {
+		k8sClient, err := m.impersonatingClient(identity)
+		if err != nil {
+			return nil, err
+		}
+		manager := newManager(k8sClient, m.logger.WithValues("impersonate", identity.UserName))
+		manager.discovery = m.discovery
+		manager.apiVersions = m.apiVersions
+		return manager, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return manager, nil
+}
+
+// impersonatingClient creates a controller-runtime client impersonating the identity
+func (m *Manager) impersonatingClient(identity Identity) (client.Client, error) {
+	if m.impersonation.newClient != nil {
+		return m.impersonation.newClient(identity)
+	}
+	if m.restConfig == nil {
+		return nil, fmt.Errorf("client manager has no REST config to impersonate with")
+	}
+
+	config := rest.CopyConfig(m.restConfig)
+	config.Impersonate = rest.ImpersonationConfig{
+		UserName: identity.UserName,
+		UID:      identity.UID,
+		Groups:   identity.Groups,
+	}
+
+	k8sClient, err := client.New(config, client.Options{
+		Scheme: m.scheme,
+		Mapper: m.client.RESTMapper(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create impersonating client for %s: %w", identity.UserName, err)
+	}
+	return k8sClient, nil
+}
+
+// impersonationCache holds the impersonating managers keyed by identity
+type impersonationCache struct {
+	mu      sync.Mutex
+	entries map[string]*impersonationEntry
+	now     func() time.Time
+
+	// newClient overrides how impersonating clients are built, used with fake clients
+	newClient func(Identity) (client.Client, error)
+}
+
+type impersonationEntry struct {
+	manager  *Manager
+	lastUsed time.Time
+}
+
+func newImpersonationCache() *impersonationCache {
+	return &impersonationCache{
+		entries: map[string]*impersonationEntry{},
+		now:     time.Now,
+	}
+}
+
+// get returns the cached manager for the identity or creates it
+func (c *impersonationCache) get(identity Identity, create func() (*Manager, error)) (*Manager, error) {
+	key := identity.key()
+	now := c.now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, ok := c.entries[key]; ok {
+		entry.lastUsed = now
+		return entry.manager, nil
+	}
+
+	manager, err := create()
+	if err != nil {
+		return nil, err
+	}
+
+	c.evict(now)
+	c.entries[key] = &impersonationEntry{manager: manager, lastUsed: now}
+	return manager, nil
+}
+
+// evict drops idle entries and, if the cache is still full, the least recently used one
+func (c *impersonationCache) evict(now time.Time) {
+	var oldestKey string
+	var oldest time.Time
+	for key, entry := range c.entries {
+		if now.Sub(entry.lastUsed) > impersonationCacheIdleTTL {
+			delete(c.entries, key)
+			continue
+		}
+		if oldestKey == "" || entry.lastUsed.Before(oldest) {
+			oldestKey, oldest = key, entry.lastUsed
+		}
+	}
+	if len(c.entries) >= impersonationCacheSize {
+		delete(c.entries, oldestKey)
+	}
+}
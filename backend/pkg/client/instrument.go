@@ -0,0 +1,132 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package client
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// tracer starts the spans of the Kubernetes requests of every Manager
+var tracer = otel.Tracer("github.com/envoyproxy/ai-gateway/console/backend/pkg/client")
+
+var (
+	kubernetesRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "console_kubernetes_requests_total",
+		Help: "Kubernetes API requests made by the console by resource kind, verb and result, the reason of the error or success.",
+	}, []string{"kind", "verb", "result"})
+
+	kubernetesRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "console_kubernetes_request_duration_seconds",
+		Help:    "Latency of Kubernetes API requests made by the console by resource kind and verb.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"kind", "verb"})
+)
+
+// MetricsCollectors returns the collectors of the Kubernetes requests made by every Manager
+func MetricsCollectors() []prometheus.Collector {
+	return []prometheus.Collector{kubernetesRequests, kubernetesRequestDuration}
+}
+
+// instrumentedClient records the metrics and traces the requests of a controller-runtime client.
+// Subresource requests are not recorded.
+type instrumentedClient struct {
+	client.Client
+}
+
+func instrument(k8sClient client.Client) client.Client {
+	if _, ok := k8sClient.(instrumentedClient); ok {
+		return k8sClient
+	}
+	return instrumentedClient{Client: k8sClient}
+}
+
+// observe runs a request of the verb on the object in a span and records its metrics
+func (c instrumentedClient) observe(ctx context.Context, verb string, obj runtime.Object, namespace, name string, call func(ctx context.Context) error) error {
+	kind := "Unknown"
+	if gvk, err := apiutil.GVKForObject(obj, c.Scheme()); err == nil {
+		kind = strings.TrimSuffix(gvk.Kind, "List")
+	}
+
+	ctx, span := tracer.Start(ctx, verb+" "+kind, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("k8s.resource.kind", kind),
+		attribute.String("k8s.namespace.name", namespace),
+		attribute.String("k8s.resource.name", name),
+	))
+	defer span.End()
+
+	start := time.Now()
+	err := call(ctx)
+	kubernetesRequestDuration.WithLabelValues(kind, verb).Observe(time.Since(start).Seconds())
+
+	result := "Success"
+	if err != nil {
+		result = string(errors.ReasonForError(err))
+		if result == "" {
+			result = "Error"
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, result)
+	}
+	span.SetAttributes(attribute.String("k8s.result", result))
+	kubernetesRequests.WithLabelValues(kind, verb, result).Inc()
+	return err
+}
+
+func (c instrumentedClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return c.observe(ctx, "get", obj, key.Namespace, key.Name, func(ctx context.Context) error {
+		return c.Client.Get(ctx, key, obj, opts...)
+	})
+}
+
+func (c instrumentedClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	namespace := (&client.ListOptions{}).ApplyOptions(opts).Namespace
+	return c.observe(ctx, "list", list, namespace, "", func(ctx context.Context) error {
+		return c.Client.List(ctx, list, opts...)
+	})
+}
+
+func (c instrumentedClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	return c.observe(ctx, "create", obj, obj.GetNamespace(), obj.GetName(), func(ctx context.Context) error {
+		return c.Client.Create(ctx, obj, opts...)
+	})
+}
+
+func (c instrumentedClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	return c.observe(ctx, "update", obj, obj.GetNamespace(), obj.GetName(), func(ctx context.Context) error {
+		return c.Client.Update(ctx, obj, opts...)
+	})
+}
+
+func (c instrumentedClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return c.observe(ctx, "patch", obj, obj.GetNamespace(), obj.GetName(), func(ctx context.Context) error {
+		return c.Client.Patch(ctx, obj, patch, opts...)
+	})
+}
+
+func (c instrumentedClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	return c.observe(ctx, "delete", obj, obj.GetNamespace(), obj.GetName(), func(ctx context.Context) error {
+		return c.Client.Delete(ctx, obj, opts...)
+	})
+}
+
+func (c instrumentedClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	namespace := (&client.DeleteAllOfOptions{}).ApplyOptions(opts).Namespace
+	return c.observe(ctx, "deletecollection", obj, namespace, "", func(ctx context.Context) error {
+		return c.Client.DeleteAllOf(ctx, obj, opts...)
+	})
+}
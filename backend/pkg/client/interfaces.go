@@ -9,6 +9,7 @@ import (
 	"context"
 
 	aigv1a1 "github.com/envoyproxy/ai-gateway/api/v1alpha1"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/llm"
 	gwapiv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -19,7 +20,7 @@ import (
 type BackendClientInterface interface {
 	Create(ctx context.Context, backend *gwapiv1a1.Backend) error
 	Get(ctx context.Context, namespace, name string) (*gwapiv1a1.Backend, error)
-	List(ctx context.Context, namespace string) (*gwapiv1a1.BackendList, error)
+	List(ctx context.Context, namespace string, opts ...client.ListOption) (*gwapiv1a1.BackendList, error)
 	Update(ctx context.Context, backend *gwapiv1a1.Backend) error
 	Delete(ctx context.Context, namespace, name string) error
 }
@@ -28,16 +29,25 @@ type BackendClientInterface interface {
 type SecretClientInterface interface {
 	Create(ctx context.Context, secret *corev1.Secret) error
 	Get(ctx context.Context, namespace, name string) (*corev1.Secret, error)
-	List(ctx context.Context, namespace string) (*corev1.SecretList, error)
+	List(ctx context.Context, namespace string, opts ...client.ListOption) (*corev1.SecretList, error)
 	Update(ctx context.Context, secret *corev1.Secret) error
 	Delete(ctx context.Context, namespace, name string) error
 }
 
+// ConfigMapClientInterface defines the interface for ConfigMap operations
+type ConfigMapClientInterface interface {
+	Create(ctx context.Context, configMap *corev1.ConfigMap) error
+	Get(ctx context.Context, namespace, name string) (*corev1.ConfigMap, error)
+	List(ctx context.Context, namespace string, opts ...client.ListOption) (*corev1.ConfigMapList, error)
+	Update(ctx context.Context, configMap *corev1.ConfigMap) error
+	Delete(ctx context.Context, namespace, name string) error
+}
+
 // AIServiceBackendClientInterface defines the interface for AIServiceBackend operations
 type AIServiceBackendClientInterface interface {
 	Create(ctx context.Context, backend *aigv1a1.AIServiceBackend) error
 	Get(ctx context.Context, namespace, name string) (*aigv1a1.AIServiceBackend, error)
-	List(ctx context.Context, namespace string) (*aigv1a1.AIServiceBackendList, error)
+	List(ctx context.Context, namespace string, opts ...client.ListOption) (*aigv1a1.AIServiceBackendList, error)
 	Update(ctx context.Context, backend *aigv1a1.AIServiceBackend) error
 	Delete(ctx context.Context, namespace, name string) error
 }
@@ -46,7 +56,7 @@ type AIServiceBackendClientInterface interface {
 type BackendSecurityPolicyClientInterface interface {
 	Create(ctx context.Context, policy *aigv1a1.BackendSecurityPolicy) error
 	Get(ctx context.Context, namespace, name string) (*aigv1a1.BackendSecurityPolicy, error)
-	List(ctx context.Context, namespace string) (*aigv1a1.BackendSecurityPolicyList, error)
+	List(ctx context.Context, namespace string, opts ...client.ListOption) (*aigv1a1.BackendSecurityPolicyList, error)
 	Update(ctx context.Context, policy *aigv1a1.BackendSecurityPolicy) error
 	Delete(ctx context.Context, namespace, name string) error
 }
@@ -55,11 +65,29 @@ type BackendSecurityPolicyClientInterface interface {
 type BackendTLSPolicyClientInterface interface {
 	Create(ctx context.Context, policy *gwapiv1a3.BackendTLSPolicy) error
 	Get(ctx context.Context, namespace, name string) (*gwapiv1a3.BackendTLSPolicy, error)
-	List(ctx context.Context, namespace string) (*gwapiv1a3.BackendTLSPolicyList, error)
+	List(ctx context.Context, namespace string, opts ...client.ListOption) (*gwapiv1a3.BackendTLSPolicyList, error)
 	Update(ctx context.Context, policy *gwapiv1a3.BackendTLSPolicy) error
 	Delete(ctx context.Context, namespace, name string) error
 }
 
+// AIGatewayRouteClientInterface defines the interface for AIGatewayRoute operations
+type AIGatewayRouteClientInterface interface {
+	Create(ctx context.Context, route *aigv1a1.AIGatewayRoute) error
+	Get(ctx context.Context, namespace, name string) (*aigv1a1.AIGatewayRoute, error)
+	List(ctx context.Context, namespace string, opts ...client.ListOption) (*aigv1a1.AIGatewayRouteList, error)
+	Update(ctx context.Context, route *aigv1a1.AIGatewayRoute) error
+	Delete(ctx context.Context, namespace, name string) error
+}
+
+// BackendTrafficPolicyClientInterface defines the interface for BackendTrafficPolicy operations
+type BackendTrafficPolicyClientInterface interface {
+	Create(ctx context.Context, policy *gwapiv1a1.BackendTrafficPolicy) error
+	Get(ctx context.Context, namespace, name string) (*gwapiv1a1.BackendTrafficPolicy, error)
+	List(ctx context.Context, namespace string, opts ...client.ListOption) (*gwapiv1a1.BackendTrafficPolicyList, error)
+	Update(ctx context.Context, policy *gwapiv1a1.BackendTrafficPolicy) error
+	Delete(ctx context.Context, namespace, name string) error
+}
+
 // ManagerInterface defines the interface for the client manager
 type ManagerInterface interface {
 	// Client returns the underlying Kubernetes client
@@ -68,21 +96,51 @@ type ManagerInterface interface {
 	// HealthCheck performs a basic health check against the Kubernetes API
 	HealthCheck(ctx context.Context) error
 
+	// ServerVersion returns the version of the Kubernetes API server
+	ServerVersion(ctx context.Context) (string, error)
+
+	// DiscoverKinds returns the AI Gateway, Envoy Gateway and Gateway API kinds served by the cluster
+	DiscoverKinds(ctx context.Context) (ServedKinds, error)
+
+	// APIVersions returns the API versions the clients read and write
+	APIVersions() llm.APIVersions
+
+	// SetAPIVersions selects the API versions, e.g. from the served versions found by DiscoverKinds
+	SetAPIVersions(versions llm.APIVersions) error
+
+	// CheckAccess reviews whether the client may perform each check
+	CheckAccess(ctx context.Context, checks []AccessCheck) ([]AccessCheck, error)
+
+	// GetControllerStatus reads the Deployment of a controller
+	GetControllerStatus(ctx context.Context, controller Controller) ControllerStatus
+
+	// ListNamespaces returns the names of all namespaces visible to the client
+	ListNamespaces(ctx context.Context) ([]string, error)
+
+	// Impersonate returns a manager whose clients act as the given identity
+	Impersonate(identity Identity) (ManagerInterface, error)
+
 	// LoadEnvoyGatewayResources loads all Envoy Gateway resources for a given provider
 	LoadEnvoyGatewayResources(ctx context.Context, namespace, name string) ([]interface{}, error)
 
 	// Resource clients
 	GetBackendClient() BackendClientInterface
 	GetSecretClient() SecretClientInterface
+	GetConfigMapClient() ConfigMapClientInterface
 	GetAIServiceBackendClient() AIServiceBackendClientInterface
 	GetBackendSecurityPolicyClient() BackendSecurityPolicyClientInterface
 	GetBackendTLSPolicyClient() BackendTLSPolicyClientInterface
+	GetAIGatewayRouteClient() AIGatewayRouteClientInterface
+	GetBackendTrafficPolicyClient() BackendTrafficPolicyClientInterface
 }
 
 // Ensure our implementations satisfy the interfaces
 var _ BackendClientInterface = &BackendClient{}
 var _ SecretClientInterface = &SecretClient{}
+var _ ConfigMapClientInterface = &ConfigMapClient{}
 var _ AIServiceBackendClientInterface = &AIServiceBackendClient{}
 var _ BackendSecurityPolicyClientInterface = &BackendSecurityPolicyClient{}
 var _ BackendTLSPolicyClientInterface = &BackendTLSPolicyClient{}
+var _ AIGatewayRouteClientInterface = &AIGatewayRouteClient{}
+var _ BackendTrafficPolicyClientInterface = &BackendTrafficPolicyClient{}
 var _ ManagerInterface = &Manager{}
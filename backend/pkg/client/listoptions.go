@@ -0,0 +1,38 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package client
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PageOptions returns the list options for fetching a single page of a List call.
+// A zero limit disables paging, the continue token comes from a previous page.
+func PageOptions(limit int64, continueToken string) []client.ListOption {
+	var opts []client.ListOption
+	if limit > 0 {
+		opts = append(opts, client.Limit(limit))
+	}
+	if continueToken != "" {
+		opts = append(opts, client.Continue(continueToken))
+	}
+	return opts
+}
+
+// LabelSelectorOptions parses a Kubernetes label selector into list options
+func LabelSelectorOptions(selector string) ([]client.ListOption, error) {
+	if selector == "" {
+		return nil, nil
+	}
+	parsed, err := labels.Parse(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label selector %q: %w", selector, err)
+	}
+	return []client.ListOption{client.MatchingLabelsSelector{Selector: parsed}}, nil
+}
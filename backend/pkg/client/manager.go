@@ -8,13 +8,18 @@ package client
 import (
 	"context"
 	"fmt"
+	"time"
 
 	aigv1a1 "github.com/envoyproxy/ai-gateway/api/v1alpha1"
 	gwapiv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
 	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -28,42 +33,97 @@ type Manager struct {
 	client client.Client
 	logger logr.Logger
 
+	// discovery reports the API versions served by the cluster
+	discovery discovery.DiscoveryInterface
+	// apiVersions are the versions the clients read and write, shared with the impersonating managers
+	apiVersions *servedAPIVersions
+
+	// restConfig and scheme are used to derive impersonating clients
+	restConfig    *rest.Config
+	scheme        *runtime.Scheme
+	impersonation *impersonationCache
+
 	// Individual typed clients for each resource type
 	Backend               *BackendClient
 	BackendTLSPolicy      *BackendTLSPolicyClient
 	Secret                *SecretClient
+	ConfigMap             *ConfigMapClient
 	BackendSecurityPolicy *BackendSecurityPolicyClient
 	AIServiceBackend      *AIServiceBackendClient
+	AIGatewayRoute        *AIGatewayRouteClient
+	BackendTrafficPolicy  *BackendTrafficPolicyClient
 }
 
 // Config holds configuration for the Kubernetes client manager
 type Config struct {
-	// Kubeconfig path, if empty uses in-cluster config
+	// Kubeconfig path, if empty the standard lookup is used: $KUBECONFIG, the
+	// in-cluster config, then ~/.kube/config
 	Kubeconfig string
+	// Context of the kubeconfig to use, if empty the current context
+	Context string
+	// InCluster forces the in-cluster config of the pod service account
+	InCluster bool
+	// Timeout of requests to the Kubernetes API server, zero means no timeout
+	Timeout time.Duration
 	// Logger for the client manager
 	Logger logr.Logger
 }
 
 // NewManager creates a new Kubernetes client manager with all resource services
 func NewManager(cfg Config) (*Manager, error) {
-	// Get Kubernetes config
-	var restConfig *rest.Config
-	var err error
+	restConfig, err := restConfigFor(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Kubernetes config: %w", err)
+	}
+	restConfig.Timeout = cfg.Timeout
 
-	if cfg.Kubeconfig != "" {
-		restConfig, err = clientcmd.BuildConfigFromFlags("", cfg.Kubeconfig)
-	} else {
-		restConfig, err = config.GetConfig()
+	scheme, err := NewScheme()
+	if err != nil {
+		return nil, err
 	}
+
+	// Create the controller-runtime client
+	k8sClient, err := client.New(restConfig, client.Options{Scheme: scheme})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get Kubernetes config: %w", err)
+		return nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
 	}
 
-	// Create a new scheme and add all required types
+	logger := cfg.Logger
+	if logger.GetSink() == nil {
+		// Use a no-op logger if none provided
+		logger = logr.Discard()
+	}
+
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create discovery client: %w", err)
+	}
+
+	manager := newManager(k8sClient, logger)
+	manager.discovery = discoveryClient
+	manager.restConfig = restConfig
+	manager.scheme = scheme
+	manager.impersonation = newImpersonationCache()
+
+	return manager, nil
+}
+
+// NewScheme returns a scheme with every Kubernetes, Gateway API, Envoy Gateway and
+// AI Gateway type the console reads or writes
+func NewScheme() (*runtime.Scheme, error) {
 	scheme := runtime.NewScheme()
 	if err := corev1.AddToScheme(scheme); err != nil {
 		return nil, fmt.Errorf("failed to add core/v1 to scheme: %w", err)
 	}
+	if err := appsv1.AddToScheme(scheme); err != nil {
+		return nil, fmt.Errorf("failed to add apps/v1 to scheme: %w", err)
+	}
+	if err := authenticationv1.AddToScheme(scheme); err != nil {
+		return nil, fmt.Errorf("failed to add authentication/v1 to scheme: %w", err)
+	}
+	if err := authorizationv1.AddToScheme(scheme); err != nil {
+		return nil, fmt.Errorf("failed to add authorization/v1 to scheme: %w", err)
+	}
 	if err := gwapiv1.Install(scheme); err != nil {
 		return nil, fmt.Errorf("failed to add gateway-api/v1 to scheme: %w", err)
 	}
@@ -76,31 +136,53 @@ func NewManager(cfg Config) (*Manager, error) {
 	if err := aigv1a1.AddToScheme(scheme); err != nil {
 		return nil, fmt.Errorf("failed to add ai-gateway/v1alpha1 to scheme: %w", err)
 	}
+	return scheme, nil
+}
 
-	// Create the controller-runtime client
-	k8sClient, err := client.New(restConfig, client.Options{Scheme: scheme})
-	if err != nil {
-		return nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
+// restConfigFor loads the REST config selected by the configuration
+func restConfigFor(cfg Config) (*rest.Config, error) {
+	switch {
+	case cfg.InCluster:
+		return rest.InClusterConfig()
+	case cfg.Kubeconfig != "" || cfg.Context != "":
+		rules := clientcmd.NewDefaultClientConfigLoadingRules()
+		rules.ExplicitPath = cfg.Kubeconfig
+		overrides := &clientcmd.ConfigOverrides{CurrentContext: cfg.Context}
+		return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, overrides).ClientConfig()
+	default:
+		return config.GetConfig()
 	}
+}
 
-	logger := cfg.Logger
+// NewManagerWithClient creates a client manager on top of an existing controller-runtime
+// client, such as a fake client built with NewScheme. The manager cannot impersonate users.
+func NewManagerWithClient(k8sClient client.Client, logger logr.Logger) *Manager {
 	if logger.GetSink() == nil {
-		// Use a no-op logger if none provided
 		logger = logr.Discard()
 	}
+	return newManager(k8sClient, logger)
+}
 
-	// Initialize all clients
+// newManager initializes all typed clients on top of a controller-runtime client,
+// recording the metrics of its requests
+func newManager(k8sClient client.Client, logger logr.Logger) *Manager {
+	k8sClient = instrument(k8sClient)
 	manager := &Manager{
 		client:                k8sClient,
+		apiVersions:           &servedAPIVersions{},
 		logger:                logger,
 		Backend:               NewBackendClient(k8sClient, logger),
 		BackendTLSPolicy:      NewBackendTLSPolicyClient(k8sClient, logger),
 		Secret:                NewSecretClient(k8sClient, logger),
+		ConfigMap:             NewConfigMapClient(k8sClient, logger),
 		BackendSecurityPolicy: NewBackendSecurityPolicyClient(k8sClient, logger),
 		AIServiceBackend:      NewAIServiceBackendClient(k8sClient, logger),
+		AIGatewayRoute:        NewAIGatewayRouteClient(k8sClient, logger),
+		BackendTrafficPolicy:  NewBackendTrafficPolicyClient(k8sClient, logger),
 	}
-
-	return manager, nil
+	// The BackendTLSPolicy client follows the versions set on the manager
+	manager.BackendTLSPolicy.version = func() string { return manager.APIVersions().BackendTLSPolicy }
+	return manager
 }
 
 // Client returns the underlying Kubernetes client
@@ -118,6 +200,20 @@ func (m *Manager) HealthCheck(ctx context.Context) error {
 	return nil
 }
 
+// ListNamespaces returns the names of all namespaces visible to the client
+func (m *Manager) ListNamespaces(ctx context.Context) ([]string, error) {
+	var namespaces corev1.NamespaceList
+	if err := m.client.List(ctx, &namespaces); err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+
+	names := make([]string, 0, len(namespaces.Items))
+	for _, ns := range namespaces.Items {
+		names = append(names, ns.Name)
+	}
+	return names, nil
+}
+
 // LoadEnvoyGatewayResources loads all Envoy Gateway resources for a given provider
 // This is a convenience method that matches the test case requirements
 func (m *Manager) LoadEnvoyGatewayResources(ctx context.Context, namespace, name string) ([]interface{}, error) {
@@ -171,6 +267,11 @@ func (m *Manager) GetSecretClient() SecretClientInterface {
 	return m.Secret
 }
 
+// GetConfigMapClient returns the ConfigMap client
+func (m *Manager) GetConfigMapClient() ConfigMapClientInterface {
+	return m.ConfigMap
+}
+
 // GetAIServiceBackendClient returns the AIServiceBackend client
 func (m *Manager) GetAIServiceBackendClient() AIServiceBackendClientInterface {
 	return m.AIServiceBackend
@@ -185,3 +286,13 @@ func (m *Manager) GetBackendSecurityPolicyClient() BackendSecurityPolicyClientIn
 func (m *Manager) GetBackendTLSPolicyClient() BackendTLSPolicyClientInterface {
 	return m.BackendTLSPolicy
 }
+
+// GetAIGatewayRouteClient returns the AIGatewayRoute client
+func (m *Manager) GetAIGatewayRouteClient() AIGatewayRouteClientInterface {
+	return m.AIGatewayRoute
+}
+
+// GetBackendTrafficPolicyClient returns the BackendTrafficPolicy client
+func (m *Manager) GetBackendTrafficPolicyClient() BackendTrafficPolicyClientInterface {
+	return m.BackendTrafficPolicy
+}
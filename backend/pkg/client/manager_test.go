@@ -7,12 +7,16 @@ package client
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	aigv1a1 "github.com/envoyproxy/ai-gateway/api/v1alpha1"
@@ -117,3 +121,144 @@ func TestManager_HealthCheck(t *testing.T) {
 	err := manager.HealthCheck(ctx)
 	assert.NoError(t, err)
 }
+
+func TestParseNamespaces(t *testing.T) {
+	assert.Empty(t, ParseNamespaces())
+	assert.Equal(t, []string{"default"}, ParseNamespaces("default"))
+	assert.Equal(t, []string{"team-a", "team-b"}, ParseNamespaces("team-b, team-a", "team-a"))
+	assert.Equal(t, []string{AllNamespaces}, ParseNamespaces("team-a", "*"))
+}
+
+func TestManager_ListAllNamespaces(t *testing.T) {
+	// Create a test scheme with all required types
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, aigv1a1.AddToScheme(scheme))
+
+	backendA := &aigv1a1.AIServiceBackend{}
+	backendA.Namespace, backendA.Name = "team-a", "openai"
+	backendB := &aigv1a1.AIServiceBackend{}
+	backendB.Namespace, backendB.Name = "team-b", "bedrock"
+	nsA := &corev1.Namespace{}
+	nsA.Name = "team-a"
+	nsB := &corev1.Namespace{}
+	nsB.Name = "team-b"
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(backendA, backendB, nsA, nsB).
+		Build()
+
+	manager := &Manager{
+		client:           fakeClient,
+		logger:           logr.Discard(),
+		AIServiceBackend: NewAIServiceBackendClient(fakeClient, logr.Discard()),
+	}
+
+	ctx := context.Background()
+
+	list, err := manager.AIServiceBackend.List(ctx, "team-a")
+	require.NoError(t, err)
+	assert.Len(t, list.Items, 1)
+
+	list, err = manager.AIServiceBackend.List(ctx, AllNamespaces)
+	require.NoError(t, err)
+	assert.Len(t, list.Items, 2)
+
+	namespaces, err := manager.ListNamespaces(ctx)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"team-a", "team-b"}, namespaces)
+}
+
+func TestManager_Impersonate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	var created []Identity
+	manager := newManager(fake.NewClientBuilder().WithScheme(scheme).Build(), logr.Discard())
+	manager.impersonation = newImpersonationCache()
+	manager.impersonation.newClient = func(identity Identity) (client.Client, error) {
+		created = append(created, identity)
+		return fake.NewClientBuilder().WithScheme(scheme).Build(), nil
+	}
+
+	alice, err := manager.Impersonate(Identity{UserName: "alice", Groups: []string{"b", "a"}})
+	require.NoError(t, err)
+	again, err := manager.Impersonate(Identity{UserName: "alice", Groups: []string{"a", "b"}})
+	require.NoError(t, err)
+	assert.Same(t, alice, again, "identities with the same groups share a manager")
+
+	bob, err := manager.Impersonate(Identity{UserName: "bob"})
+	require.NoError(t, err)
+	assert.NotSame(t, alice, bob)
+	assert.Len(t, created, 2)
+
+	_, err = manager.Impersonate(Identity{})
+	assert.Error(t, err)
+}
+
+func TestManager_NewManagerWithClient(t *testing.T) {
+	scheme, err := NewScheme()
+	require.NoError(t, err)
+
+	backend := &aigv1a1.AIServiceBackend{}
+	backend.Namespace, backend.Name = "default", "openai"
+	manager := NewManagerWithClient(fake.NewClientBuilder().WithScheme(scheme).WithObjects(backend).Build(), logr.Logger{})
+
+	got, err := manager.GetAIServiceBackendClient().Get(context.Background(), "default", "openai")
+	require.NoError(t, err)
+	assert.Equal(t, "openai", got.Name)
+
+	_, err = manager.Impersonate(Identity{UserName: "alice"})
+	assert.Error(t, err, "fake clients cannot impersonate")
+}
+
+func TestManager_RestConfigForContext(t *testing.T) {
+	kubeconfig := filepath.Join(t.TempDir(), "kubeconfig")
+	require.NoError(t, os.WriteFile(kubeconfig, []byte(`
+apiVersion: v1
+kind: Config
+current-context: dev
+clusters:
+  - name: dev
+    cluster: {server: "https://dev.example.com"}
+  - name: prod
+    cluster: {server: "https://prod.example.com"}
+contexts:
+  - name: dev
+    context: {cluster: dev, user: admin}
+  - name: prod
+    context: {cluster: prod, user: admin}
+users:
+  - name: admin
+    user: {token: test}
+`), 0o600))
+
+	restConfig, err := restConfigFor(Config{Kubeconfig: kubeconfig})
+	require.NoError(t, err)
+	assert.Equal(t, "https://dev.example.com", restConfig.Host)
+
+	restConfig, err = restConfigFor(Config{Kubeconfig: kubeconfig, Context: "prod"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://prod.example.com", restConfig.Host)
+
+	_, err = restConfigFor(Config{Kubeconfig: kubeconfig, Context: "missing"})
+	assert.Error(t, err)
+}
+
+func TestManager_RecordsKubernetesMetrics(t *testing.T) {
+	scheme, err := NewScheme()
+	require.NoError(t, err)
+	manager := NewManagerWithClient(fake.NewClientBuilder().WithScheme(scheme).Build(), logr.Discard())
+
+	notFound := testutil.ToFloat64(kubernetesRequests.WithLabelValues("Backend", "get", "NotFound"))
+	listed := testutil.ToFloat64(kubernetesRequests.WithLabelValues("Backend", "list", "Success"))
+
+	_, err = manager.GetBackendClient().Get(context.Background(), "default", "missing")
+	require.Error(t, err)
+	_, err = manager.GetBackendClient().List(context.Background(), "default")
+	require.NoError(t, err)
+
+	assert.Equal(t, notFound+1, testutil.ToFloat64(kubernetesRequests.WithLabelValues("Backend", "get", "NotFound")))
+	assert.Equal(t, listed+1, testutil.ToFloat64(kubernetesRequests.WithLabelValues("Backend", "list", "Success")))
+}
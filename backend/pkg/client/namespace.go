@@ -0,0 +1,54 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package client
+
+import (
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// AllNamespaces selects resources across every namespace in the cluster
+const AllNamespaces = "*"
+
+// IsAllNamespaces reports whether the namespace selector means cluster-wide
+func IsAllNamespaces(namespace string) bool {
+	return namespace == AllNamespaces
+}
+
+// ParseNamespaces splits comma separated namespace selectors into a sorted,
+// de-duplicated list. If any selector is AllNamespaces only AllNamespaces is returned.
+func ParseNamespaces(values ...string) []string {
+	seen := make(map[string]struct{})
+	for _, value := range values {
+		for _, ns := range strings.Split(value, ",") {
+			ns = strings.TrimSpace(ns)
+			if ns == "" {
+				continue
+			}
+			if IsAllNamespaces(ns) {
+				return []string{AllNamespaces}
+			}
+			seen[ns] = struct{}{}
+		}
+	}
+
+	namespaces := make([]string, 0, len(seen))
+	for ns := range seen {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
+// namespaceListOptions returns the list options restricting a List call to a namespace
+func namespaceListOptions(namespace string) []client.ListOption {
+	if IsAllNamespaces(namespace) {
+		return nil
+	}
+	return []client.ListOption{client.InNamespace(namespace)}
+}
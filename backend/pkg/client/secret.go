@@ -45,10 +45,10 @@ func (c *SecretClient) Get(ctx context.Context, namespace, name string) (*corev1
 	return &secret, nil
 }
 
-// List retrieves all Secret resources in a namespace
-func (c *SecretClient) List(ctx context.Context, namespace string) (*corev1.SecretList, error) {
+// List retrieves all Secret resources in a namespace, or in all namespaces for AllNamespaces
+func (c *SecretClient) List(ctx context.Context, namespace string, opts ...client.ListOption) (*corev1.SecretList, error) {
 	var list corev1.SecretList
-	if err := c.client.List(ctx, &list, client.InNamespace(namespace)); err != nil {
+	if err := c.client.List(ctx, &list, append(namespaceListOptions(namespace), opts...)...); err != nil {
 		return nil, fmt.Errorf("failed to list Secrets: %w", err)
 	}
 	return &list, nil
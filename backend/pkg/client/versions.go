@@ -0,0 +1,53 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package client
+
+import (
+	"sync/atomic"
+
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/llm"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// servedAPIVersions holds the API versions selected for the cluster, shared by a
+// manager and its impersonating managers
+type servedAPIVersions = atomic.Pointer[llm.APIVersions]
+
+// APIVersions selects the versions of BackendTLSPolicy and of the AI Gateway API the
+// console writes among the served versions
+func (s ServedKinds) APIVersions() (llm.APIVersions, error) {
+	return llm.SelectAPIVersions(
+		s.Versions(schema.GroupKind{Group: GroupGatewayAPI, Kind: llm.KindBackendTLSPolicy}),
+		s.Versions(schema.GroupKind{Group: GroupAIGateway, Kind: llm.KindAIServiceBackend}),
+	)
+}
+
+// APIVersions returns the API versions the clients read and write, the defaults until
+// SetAPIVersions is called
+func (m *Manager) APIVersions() llm.APIVersions {
+	if m.apiVersions != nil {
+		if versions := m.apiVersions.Load(); versions != nil {
+			return *versions
+		}
+	}
+	return llm.DefaultAPIVersions
+}
+
+// SetAPIVersions selects the API versions of the clients of the manager and of its
+// impersonating managers, e.g. from the versions found by DiscoverKinds
+func (m *Manager) SetAPIVersions(versions llm.APIVersions) error {
+	if err := versions.Validate(); err != nil {
+		return err
+	}
+	if previous := m.APIVersions(); previous != versions {
+		m.logger.Info("Selected API versions", "backendTLSPolicy", versions.BackendTLSPolicy, "aiGateway", versions.AIGateway)
+	}
+	if m.apiVersions == nil {
+		m.apiVersions = &servedAPIVersions{}
+	}
+	m.apiVersions.Store(&versions)
+	return nil
+}
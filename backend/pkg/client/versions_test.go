@@ -0,0 +1,199 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/llm"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gwapiv1a3 "sigs.k8s.io/gateway-api/apis/v1alpha3"
+)
+
+func TestServedKinds_APIVersions(t *testing.T) {
+	tlsPolicy := schema.GroupKind{Group: GroupGatewayAPI, Kind: llm.KindBackendTLSPolicy}
+	aisb := schema.GroupKind{Group: GroupAIGateway, Kind: llm.KindAIServiceBackend}
+
+	tests := []struct {
+		name    string
+		kinds   ServedKinds
+		want    llm.APIVersions
+		wantErr bool
+	}{
+		{
+			name:  "v1 is preferred when served",
+			kinds: ServedKinds{tlsPolicy: {"v1alpha3", "v1"}, aisb: {"v1alpha1"}},
+			want:  llm.APIVersions{BackendTLSPolicy: "v1", AIGateway: "v1alpha1"},
+		},
+		{
+			name:  "v1alpha3 on older Gateway API releases",
+			kinds: ServedKinds{tlsPolicy: {"v1alpha3"}, aisb: {"v1alpha1"}},
+			want:  llm.APIVersions{BackendTLSPolicy: "v1alpha3", AIGateway: "v1alpha1"},
+		},
+		{
+			name:  "missing kinds keep the defaults",
+			kinds: ServedKinds{},
+			want:  llm.DefaultAPIVersions,
+		},
+		{
+			name:    "unsupported AI Gateway API",
+			kinds:   ServedKinds{tlsPolicy: {"v1"}, aisb: {"v1beta1"}},
+			wantErr: true,
+		},
+		{
+			name:    "unsupported BackendTLSPolicy",
+			kinds:   ServedKinds{tlsPolicy: {"v1alpha2"}, aisb: {"v1alpha1"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.kinds.APIVersions()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestManager_BackendTLSPolicyV1(t *testing.T) {
+	scheme, err := NewScheme()
+	require.NoError(t, err)
+	v1 := schema.GroupVersionKind{Group: GroupGatewayAPI, Version: "v1", Kind: llm.KindBackendTLSPolicy}
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(v1, meta.RESTScopeNamespace)
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(mapper).Build()
+
+	manager := NewManagerWithClient(k8sClient, logr.Discard())
+	assert.Equal(t, llm.DefaultAPIVersions, manager.APIVersions())
+	assert.Error(t, manager.SetAPIVersions(llm.APIVersions{BackendTLSPolicy: "v1alpha2", AIGateway: "v1alpha1"}))
+	require.NoError(t, manager.SetAPIVersions(llm.APIVersions{BackendTLSPolicy: "v1", AIGateway: "v1alpha1"}))
+	assert.Equal(t, "v1", manager.BackendTLSPolicy.Version())
+
+	ctx := context.Background()
+	policies := manager.GetBackendTLSPolicyClient()
+	policy := &gwapiv1a3.BackendTLSPolicy{}
+	policy.Namespace, policy.Name = "default", "openai"
+	policy.Spec.Validation.Hostname = "api.openai.com"
+	require.NoError(t, policies.Create(ctx, policy))
+	assert.NotEmpty(t, policy.ResourceVersion, "the created policy is read back")
+
+	// The policy is stored as v1
+	stored := &unstructured.Unstructured{}
+	stored.SetGroupVersionKind(v1)
+	require.NoError(t, k8sClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "openai"}, stored))
+	hostname, _, _ := unstructured.NestedString(stored.Object, "spec", "validation", "hostname")
+	assert.Equal(t, "api.openai.com", hostname)
+
+	got, err := policies.Get(ctx, "default", "openai")
+	require.NoError(t, err)
+	assert.Equal(t, "api.openai.com", string(got.Spec.Validation.Hostname))
+
+	got.Spec.Validation.Hostname = "eu.api.openai.com"
+	require.NoError(t, policies.Update(ctx, got))
+
+	list, err := policies.List(ctx, "default")
+	require.NoError(t, err)
+	require.Len(t, list.Items, 1)
+	assert.Equal(t, "eu.api.openai.com", string(list.Items[0].Spec.Validation.Hostname))
+
+	require.NoError(t, policies.Delete(ctx, "default", "openai"))
+	_, err = policies.Get(ctx, "default", "openai")
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestManager_BackendTLSPolicyV1Only(t *testing.T) {
+	scheme, err := NewScheme()
+	require.NoError(t, err)
+	v1 := schema.GroupVersionKind{Group: GroupGatewayAPI, Version: "v1", Kind: llm.KindBackendTLSPolicy}
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(v1, meta.RESTScopeNamespace)
+
+	// newPolicy returns a v1 policy written by another tool, with every spec field of v1alpha3
+	newPolicy := func(name string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"targetRefs": []interface{}{map[string]interface{}{"group": "gateway.envoyproxy.io", "kind": "Backend", "name": name}},
+				"validation": map[string]interface{}{
+					"hostname":          "api.openai.com",
+					"caCertificateRefs": []interface{}{map[string]interface{}{"group": "", "kind": "ConfigMap", "name": "ca"}},
+					"subjectAltNames":   []interface{}{map[string]interface{}{"type": "Hostname", "hostname": "*.openai.com"}},
+				},
+				"options": map[string]interface{}{"example.com/mode": "strict"},
+			},
+		}}
+		obj.SetGroupVersionKind(v1)
+		obj.SetNamespace("default")
+		obj.SetName(name)
+		return obj
+	}
+	// A field the v1alpha3 type does not have, as a later v1 schema may add
+	unknown := newPolicy("unknown")
+	require.NoError(t, unstructured.SetNestedField(unknown.Object, "Strict", "spec", "validation", "mode"))
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(mapper).WithObjects(newPolicy("openai")).Build()
+	manager := NewManagerWithClient(k8sClient, logr.Discard())
+	require.NoError(t, manager.SetAPIVersions(llm.APIVersions{BackendTLSPolicy: "v1", AIGateway: "v1alpha1"}))
+	policies := manager.GetBackendTLSPolicyClient()
+	ctx := context.Background()
+
+	got, err := policies.Get(ctx, "default", "openai")
+	require.NoError(t, err)
+	assert.Equal(t, "api.openai.com", string(got.Spec.Validation.Hostname))
+	require.Len(t, got.Spec.TargetRefs, 1)
+	assert.Equal(t, "openai", string(got.Spec.TargetRefs[0].Name))
+	require.Len(t, got.Spec.Validation.CACertificateRefs, 1)
+	assert.Equal(t, "ca", string(got.Spec.Validation.CACertificateRefs[0].Name))
+	require.Len(t, got.Spec.Validation.SubjectAltNames, 1)
+	assert.Equal(t, "*.openai.com", string(got.Spec.Validation.SubjectAltNames[0].Hostname))
+	assert.Equal(t, "strict", string(got.Spec.Options["example.com/mode"]))
+
+	// Writing back keeps every field
+	require.NoError(t, policies.Update(ctx, got))
+	stored := &unstructured.Unstructured{}
+	stored.SetGroupVersionKind(v1)
+	require.NoError(t, k8sClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "openai"}, stored))
+	assert.Equal(t, newPolicy("openai").Object["spec"], stored.Object["spec"])
+
+	// Fields v1alpha3 cannot represent fail the read instead of being dropped
+	require.NoError(t, k8sClient.Create(ctx, unknown))
+	_, err = policies.Get(ctx, "default", "unknown")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "mode")
+	_, err = policies.List(ctx, "default")
+	assert.ErrorContains(t, err, "default/unknown")
+}
+
+func TestManager_ImpersonationSharesAPIVersions(t *testing.T) {
+	scheme, err := NewScheme()
+	require.NoError(t, err)
+	manager := newManager(fake.NewClientBuilder().WithScheme(scheme).Build(), logr.Discard())
+	manager.impersonation = newImpersonationCache()
+	manager.impersonation.newClient = func(Identity) (client.Client, error) {
+		return fake.NewClientBuilder().WithScheme(scheme).Build(), nil
+	}
+
+	alice, err := manager.Impersonate(Identity{UserName: "alice"})
+	require.NoError(t, err)
+	assert.Equal(t, "v1alpha3", alice.GetBackendTLSPolicyClient().(*BackendTLSPolicyClient).Version())
+
+	// Versions selected after the impersonating manager was created apply to it
+	require.NoError(t, manager.SetAPIVersions(llm.APIVersions{BackendTLSPolicy: "v1", AIGateway: "v1alpha1"}))
+	assert.Equal(t, "v1", alice.APIVersions().BackendTLSPolicy)
+	assert.Equal(t, "v1", alice.GetBackendTLSPolicyClient().(*BackendTLSPolicyClient).Version())
+}
@@ -1,5 +1,7 @@
 package llm
 
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 // LLMProvider represents a logical configuration to interact with LLM APIs via Envoy AI Gateway.
 type LLMProvider struct {
 	Name      string `json:"name"`
@@ -12,8 +14,21 @@ type LLMProvider struct {
 
 	Backend Backend       `json:"backend"`
 	TLS     TLSValidation `json:"tls"`
+
+	// Traffic configures timeouts, retries and limits towards the backend, omitted when unset
+	Traffic *TrafficPolicy `json:"traffic,omitempty"`
+
+	// Models maps logical model names to the names the provider expects
+	Models []ModelMapping `json:"models,omitempty"`
+
+	// Read-only fields reconstructed from the AIServiceBackend
+	CreatedAt *metav1.Time `json:"createdAt,omitempty"`
+	Status    string       `json:"status,omitempty"` // Accepted, NotAccepted, Broken; empty until reconciled
 }
 
+// StatusBroken is the status of a provider whose resources cannot be loaded or translated
+const StatusBroken = "Broken"
+
 // AuthType represents the type of authentication used.
 type AuthType string
 
@@ -105,6 +120,10 @@ func (l *LLMProvider) MaskSecret() *LLMProvider {
 		Auth:      l.Auth.MaskSecret(),
 		Backend:   l.Backend,
 		TLS:       l.TLS,
+		Traffic:   l.Traffic,
+		Models:    l.Models,
+		CreatedAt: l.CreatedAt,
+		Status:    l.Status,
 	}
 
 	return masked
@@ -209,3 +228,68 @@ func (a *AzureAuth) MaskSecret() *AzureAuth {
 
 	return masked
 }
+
+// RestoreSecrets returns a copy of the AuthConfig where every masked value is replaced
+// by the matching value of the current configuration. It reverses MaskSecret for
+// updates sent back by clients that only ever saw masked providers.
+func (a AuthConfig) RestoreSecrets(current AuthConfig) AuthConfig {
+	restored := a
+	restored.APIKey = restoreSecret(a.APIKey, current.APIKey)
+
+	if a.AWS != nil {
+		aws := *a.AWS
+		if current.AWS != nil {
+			aws.AccessKeyID = restoreSecret(aws.AccessKeyID, current.AWS.AccessKeyID)
+			aws.SecretAccessKey = restoreSecret(aws.SecretAccessKey, current.AWS.SecretAccessKey)
+		}
+		restored.AWS = &aws
+	}
+
+	if a.GCP != nil {
+		gcp := *a.GCP
+		if current.GCP != nil {
+			gcp.OIDCClientSecret = restoreSecret(gcp.OIDCClientSecret, current.GCP.OIDCClientSecret)
+			gcp.PrivateKey = restoreSecret(gcp.PrivateKey, current.GCP.PrivateKey)
+		}
+		restored.GCP = &gcp
+	}
+
+	if a.Azure != nil {
+		azure := *a.Azure
+		if current.Azure != nil {
+			azure.APIKey = restoreSecret(azure.APIKey, current.Azure.APIKey)
+		}
+		restored.Azure = &azure
+	}
+
+	return restored
+}
+
+// HasMaskedSecrets reports whether any credential still holds MaskedSecretValue
+func (a AuthConfig) HasMaskedSecrets() bool {
+	values := []string{a.APIKey}
+	if a.AWS != nil {
+		values = append(values, a.AWS.AccessKeyID, a.AWS.SecretAccessKey)
+	}
+	if a.GCP != nil {
+		values = append(values, a.GCP.OIDCClientSecret, a.GCP.PrivateKey)
+	}
+	if a.Azure != nil {
+		values = append(values, a.Azure.APIKey)
+	}
+	for _, value := range values {
+		if value == MaskedSecretValue {
+			return true
+		}
+	}
+	return false
+}
+
+// restoreSecret keeps the masked value when there is nothing to restore so that
+// HasMaskedSecrets can report the missing credential
+func restoreSecret(value, current string) string {
+	if value == MaskedSecretValue && current != "" {
+		return current
+	}
+	return value
+}
@@ -0,0 +1,255 @@
+package llm
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+
+	aigatewayv1alpha1 "github.com/envoyproxy/ai-gateway/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AnnotationModels stores the model mappings of a provider on its AIServiceBackend
+const AnnotationModels = "console.aigateway.envoyproxy.io/models"
+
+// ModelMapping is an entry of the model catalog of a provider. It maps the model name clients
+// request to the name the provider expects, e.g. gpt-4o to an Azure deployment or a Bedrock
+// model ID, and carries its price.
+type ModelMapping struct {
+	Name     string `json:"name"`               // logical model matched by routes through x-ai-eg-model
+	Upstream string `json:"upstream,omitempty"` // model name sent to the provider, Name when empty
+
+	Pricing *ModelPricing `json:"pricing,omitempty"`
+}
+
+// ModelPricing is the price of a model in USD per million tokens
+type ModelPricing struct {
+	Input  float64 `json:"input"`
+	Output float64 `json:"output"`
+}
+
+// Cost returns the price in USD of the tokens
+func (p *ModelPricing) Cost(inputTokens, outputTokens float64) float64 {
+	return (inputTokens*p.Input + outputTokens*p.Output) / 1e6
+}
+
+// ModelPrice returns the pricing of a model from a provider catalog, matching the logical
+// name first and the upstream name otherwise
+func ModelPrice(models []ModelMapping, name string) *ModelPricing {
+	for _, model := range models {
+		if model.Name == name && model.Pricing != nil {
+			return model.Pricing
+		}
+	}
+	for _, model := range models {
+		if model.Upstream == name && model.Pricing != nil {
+			return model.Pricing
+		}
+	}
+	return nil
+}
+
+// validateModels checks that every logical model is listed once with non-negative prices
+func validateModels(models []ModelMapping) error {
+	seen := map[string]bool{}
+	for _, model := range models {
+		if model.Name == "" {
+			return fmt.Errorf("model mappings require a name")
+		}
+		if seen[model.Name] {
+			return fmt.Errorf("model %s is mapped more than once", model.Name)
+		}
+		seen[model.Name] = true
+		if model.Pricing != nil && (model.Pricing.Input < 0 || model.Pricing.Output < 0) {
+			return fmt.Errorf("prices of model %s must not be negative", model.Name)
+		}
+	}
+	return nil
+}
+
+// setModelAnnotations stores the model mappings of the provider on the object
+func (l *LLMProvider) setModelAnnotations(meta *metav1.ObjectMeta) error {
+	if err := validateModels(l.Models); err != nil {
+		return err
+	}
+
+	if len(l.Models) > 0 {
+		data, err := json.Marshal(l.Models)
+		if err != nil {
+			return fmt.Errorf("failed to encode model mappings: %w", err)
+		}
+		meta.Annotations = map[string]string{AnnotationModels: string(data)}
+	}
+	return nil
+}
+
+// CopyModelAnnotations replaces the model annotation of dst with that of src, keeping the
+// other annotations of dst
+func CopyModelAnnotations(dst *metav1.ObjectMeta, src metav1.ObjectMeta) {
+	value, ok := src.Annotations[AnnotationModels]
+	switch {
+	case ok:
+		if dst.Annotations == nil {
+			dst.Annotations = map[string]string{}
+		}
+		dst.Annotations[AnnotationModels] = value
+	default:
+		delete(dst.Annotations, AnnotationModels)
+	}
+}
+
+// ModelMappings reads the model mappings stored on an AIServiceBackend
+func ModelMappings(aisb *aigatewayv1alpha1.AIServiceBackend) ([]ModelMapping, error) {
+	value, ok := aisb.Annotations[AnnotationModels]
+	if !ok {
+		return nil, nil
+	}
+	var models []ModelMapping
+	if err := json.Unmarshal([]byte(value), &models); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation on AIServiceBackend %s/%s: %w", AnnotationModels, aisb.Namespace, aisb.Name, err)
+	}
+	return models, nil
+}
+
+// upstreamModel returns the upstream name of a logical model, if it is renamed
+func upstreamModel(models []ModelMapping, name string) (string, bool) {
+	for _, model := range models {
+		if model.Name == name && model.Upstream != "" {
+			return model.Upstream, true
+		}
+	}
+	return "", false
+}
+
+// RuleModel returns the single model a route rule matches through the x-ai-eg-model header.
+// Rules matching several models or none have no model, as one override cannot serve them all.
+func RuleModel(rule aigatewayv1alpha1.AIGatewayRouteRule) (string, bool) {
+	model := ""
+	for _, match := range rule.Matches {
+		for _, header := range match.Headers {
+			if string(header.Name) != aigatewayv1alpha1.AIModelHeaderKey {
+				continue
+			}
+			if model != "" && model != header.Value {
+				return "", false
+			}
+			model = header.Value
+		}
+	}
+	return model, model != ""
+}
+
+// ApplyModelOverrides sets the modelNameOverride of the backendRefs to the provider in every
+// rule of the route matching a mapped model. Overrides of models dropped from previous are
+// cleared when they still hold the previous upstream name, so overrides set by hand survive.
+// It reports whether the route changed.
+func ApplyModelOverrides(route *aigatewayv1alpha1.AIGatewayRoute, provider string, models, previous []ModelMapping) bool {
+	changed := false
+	for i := range route.Spec.Rules {
+		rule := &route.Spec.Rules[i]
+		model, ok := RuleModel(*rule)
+		if !ok {
+			continue
+		}
+		for j := range rule.BackendRefs {
+			ref := &rule.BackendRefs[j]
+			if ref.Name != provider || (ref.Kind != nil && *ref.Kind != KindAIServiceBackend) {
+				continue
+			}
+			override := ref.ModelNameOverride
+			if upstream, ok := upstreamModel(models, model); ok {
+				override = upstream
+			} else if upstream, ok := upstreamModel(previous, model); ok && ref.ModelNameOverride == upstream {
+				override = ""
+			}
+			if override != ref.ModelNameOverride {
+				ref.ModelNameOverride = override
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+const (
+	// ModelSourceProvider marks upstream names taken from the model mappings of the provider
+	ModelSourceProvider = "provider"
+	// ModelSourceRoute marks upstream names set on the route only
+	ModelSourceRoute = "route"
+)
+
+// ModelRoute is a row of the model table: the upstream name a provider receives for a
+// logical model and the routes sending it there
+type ModelRoute struct {
+	Namespace string   `json:"namespace"`
+	Model     string   `json:"model"`
+	Provider  string   `json:"provider"`
+	Upstream  string   `json:"upstream"`
+	Source    string   `json:"source,omitempty"` // provider, route or empty when the name is passed as is
+	Routes    []string `json:"routes,omitempty"`
+}
+
+// ModelTable joins the model mappings of the providers of a namespace, keyed by provider
+// name, with the rules of its routes. Mappings no route uses yet are listed without routes.
+func ModelTable(namespace string, providers map[string][]ModelMapping, routes []aigatewayv1alpha1.AIGatewayRoute) []ModelRoute {
+	var rows []ModelRoute
+	index := map[[3]string]int{}
+	add := func(row ModelRoute, route string) {
+		key := [3]string{row.Model, row.Provider, row.Upstream}
+		i, ok := index[key]
+		if !ok {
+			i = len(rows)
+			index[key] = i
+			rows = append(rows, row)
+		}
+		if route != "" && !slices.Contains(rows[i].Routes, route) {
+			rows[i].Routes = append(rows[i].Routes, route)
+		}
+	}
+
+	for provider, models := range providers {
+		for _, model := range models {
+			row := ModelRoute{Namespace: namespace, Model: model.Name, Provider: provider, Upstream: model.Name}
+			if model.Upstream != "" {
+				row.Upstream, row.Source = model.Upstream, ModelSourceProvider
+			}
+			add(row, "")
+		}
+	}
+	for _, route := range routes {
+		for _, rule := range route.Spec.Rules {
+			model, ok := RuleModel(rule)
+			if !ok {
+				continue
+			}
+			for _, ref := range rule.BackendRefs {
+				if ref.Kind != nil && *ref.Kind != KindAIServiceBackend {
+					continue
+				}
+				row := ModelRoute{Namespace: namespace, Model: model, Provider: ref.Name, Upstream: model}
+				if ref.ModelNameOverride != "" {
+					row.Upstream, row.Source = ref.ModelNameOverride, ModelSourceRoute
+					if upstream, ok := upstreamModel(providers[ref.Name], model); ok && upstream == ref.ModelNameOverride {
+						row.Source = ModelSourceProvider
+					}
+				}
+				add(row, route.Name)
+			}
+		}
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Model != rows[j].Model {
+			return rows[i].Model < rows[j].Model
+		}
+		if rows[i].Provider != rows[j].Provider {
+			return rows[i].Provider < rows[j].Provider
+		}
+		return rows[i].Upstream < rows[j].Upstream
+	})
+	for i := range rows {
+		sort.Strings(rows[i].Routes)
+	}
+	return rows
+}
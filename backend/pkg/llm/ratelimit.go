@@ -0,0 +1,299 @@
+package llm
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	aigatewayv1alpha1 "github.com/envoyproxy/ai-gateway/api/v1alpha1"
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+const (
+	KindBackendTrafficPolicy = "BackendTrafficPolicy"
+	KindHTTPRoute            = "HTTPRoute"
+
+	GroupGatewayNetworking = "gateway.networking.k8s.io"
+
+	// LabelRateLimitPolicy marks the BackendTrafficPolicies managed as rate limit policies
+	LabelRateLimitPolicy = "console.aigateway.envoyproxy.io/rate-limit-policy"
+
+	TokenTypeInput  = "input"
+	TokenTypeOutput = "output"
+	TokenTypeTotal  = "total"
+
+	RateLimitStatusAccepted    = "Accepted"
+	RateLimitStatusNotAccepted = "NotAccepted"
+)
+
+// RateLimitPolicy limits the tokens consumed through an AIGatewayRoute.
+// It is stored as a BackendTrafficPolicy targeting the HTTPRoute generated for the route,
+// whose token counts come from the request costs of the AIGatewayRoute.
+type RateLimitPolicy struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+
+	Route string          `json:"route"` // name of the AIGatewayRoute in the same namespace
+	Rules []RateLimitRule `json:"rules"`
+
+	// Read-only fields reconstructed from the BackendTrafficPolicy
+	CreatedAt *metav1.Time `json:"createdAt,omitempty"`
+	Status    string       `json:"status,omitempty"` // Accepted, NotAccepted; empty until reconciled
+	Message   string       `json:"message,omitempty"`
+}
+
+// RateLimitRule is a budget of tokens per time unit
+type RateLimitRule struct {
+	TokenType string `json:"tokenType"` // input, output or total
+	Limit     uint   `json:"limit"`
+	Unit      string `json:"unit"` // Minute, Hour, Day or Month
+
+	// UserHeader gives every distinct value of the header its own budget, e.g. x-user-id
+	UserHeader string `json:"userHeader,omitempty"`
+	// Model only counts requests for this model
+	Model string `json:"model,omitempty"`
+	// Provider only counts requests routed to this LLM provider of the route
+	Provider string `json:"provider,omitempty"`
+}
+
+var rateLimitUnits = []egv1a1.RateLimitUnit{
+	egv1a1.RateLimitUnitMinute, egv1a1.RateLimitUnitHour, egv1a1.RateLimitUnitDay, egv1a1.RateLimitUnitMonth,
+}
+
+var requestCostTypes = map[string]aigatewayv1alpha1.LLMRequestCostType{
+	TokenTypeInput:  aigatewayv1alpha1.LLMRequestCostTypeInputToken,
+	TokenTypeOutput: aigatewayv1alpha1.LLMRequestCostTypeOutputToken,
+	TokenTypeTotal:  aigatewayv1alpha1.LLMRequestCostTypeTotalToken,
+}
+
+// Validate checks that the policy can be translated
+func (p *RateLimitPolicy) Validate() error {
+	if p.Name == "" {
+		return fmt.Errorf("rate limit policy name is required")
+	}
+	if p.Route == "" {
+		return fmt.Errorf("rate limit policy %s requires a route", p.Name)
+	}
+	if len(p.Rules) == 0 {
+		return fmt.Errorf("rate limit policy %s requires at least one rule", p.Name)
+	}
+	for i, rule := range p.Rules {
+		if _, ok := requestCostTypes[rule.TokenType]; !ok {
+			return fmt.Errorf("rule %d: invalid token type %q: must be %s, %s or %s", i, rule.TokenType, TokenTypeInput, TokenTypeOutput, TokenTypeTotal)
+		}
+		if rule.Limit == 0 {
+			return fmt.Errorf("rule %d: limit must be greater than zero", i)
+		}
+		if !slices.Contains(rateLimitUnits, egv1a1.RateLimitUnit(rule.Unit)) {
+			return fmt.Errorf("rule %d: invalid unit %q: must be Minute, Hour, Day or Month", i, rule.Unit)
+		}
+		if strings.ContainsAny(rule.Provider, "'\\") {
+			return fmt.Errorf("rule %d: invalid provider name %q", i, rule.Provider)
+		}
+	}
+	return nil
+}
+
+// costMetadataKey is the key of the request cost counted by the rule.
+// Costs scoped to a provider carry its name after a dot, which names of the default costs lack.
+func (r RateLimitRule) costMetadataKey() string {
+	key := "llm_" + r.TokenType + "_token"
+	if r.Provider != "" {
+		key += "." + r.Provider
+	}
+	return key
+}
+
+// RequestCosts returns the AIGatewayRoute request costs the policy rules are counted from
+func (p *RateLimitPolicy) RequestCosts() []aigatewayv1alpha1.LLMRequestCost {
+	var costs []aigatewayv1alpha1.LLMRequestCost
+	for _, rule := range p.Rules {
+		key := rule.costMetadataKey()
+		if slices.ContainsFunc(costs, func(c aigatewayv1alpha1.LLMRequestCost) bool { return c.MetadataKey == key }) {
+			continue
+		}
+
+		cost := aigatewayv1alpha1.LLMRequestCost{MetadataKey: key, Type: requestCostTypes[rule.TokenType]}
+		if rule.Provider != "" {
+			// Tokens of other providers cost nothing against this budget
+			cel := fmt.Sprintf("backend == '%s.%s' ? %s_tokens : 0", rule.Provider, p.Namespace, rule.TokenType)
+			cost = aigatewayv1alpha1.LLMRequestCost{MetadataKey: key, Type: aigatewayv1alpha1.LLMRequestCostTypeCEL, CEL: &cel}
+		}
+		costs = append(costs, cost)
+	}
+	slices.SortFunc(costs, func(a, b aigatewayv1alpha1.LLMRequestCost) int { return strings.Compare(a.MetadataKey, b.MetadataKey) })
+	return costs
+}
+
+// MergeRequestCosts adds the costs missing from the existing request costs of a route.
+// It reports whether anything was added and fails when an existing cost counts something else.
+func MergeRequestCosts(existing, costs []aigatewayv1alpha1.LLMRequestCost) ([]aigatewayv1alpha1.LLMRequestCost, bool, error) {
+	merged := slices.Clone(existing)
+	changed := false
+	for _, cost := range costs {
+		i := slices.IndexFunc(merged, func(c aigatewayv1alpha1.LLMRequestCost) bool { return c.MetadataKey == cost.MetadataKey })
+		if i < 0 {
+			merged = append(merged, cost)
+			changed = true
+			continue
+		}
+		if merged[i].Type != cost.Type || (cost.CEL != nil && (merged[i].CEL == nil || *merged[i].CEL != *cost.CEL)) {
+			return nil, false, fmt.Errorf("request cost %s already exists with a different definition", cost.MetadataKey)
+		}
+	}
+	return merged, changed, nil
+}
+
+// ToBackendTrafficPolicy translates the policy into a global rate limit on the HTTPRoute of its AIGatewayRoute
+func (p *RateLimitPolicy) ToBackendTrafficPolicy() (*egv1a1.BackendTrafficPolicy, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	rules := make([]egv1a1.RateLimitRule, 0, len(p.Rules))
+	for _, rule := range p.Rules {
+		var headers []egv1a1.HeaderMatch
+		if rule.UserHeader != "" {
+			headers = append(headers, egv1a1.HeaderMatch{
+				Type: strPtr(egv1a1.HeaderMatchDistinct),
+				Name: rule.UserHeader,
+			})
+		}
+		if rule.Model != "" {
+			headers = append(headers, egv1a1.HeaderMatch{
+				Type:  strPtr(egv1a1.HeaderMatchExact),
+				Name:  aigatewayv1alpha1.AIModelHeaderKey,
+				Value: strPtr(rule.Model),
+			})
+		}
+
+		var selectors []egv1a1.RateLimitSelectCondition
+		if len(headers) > 0 {
+			selectors = []egv1a1.RateLimitSelectCondition{{Headers: headers}}
+		}
+
+		// Requests are only checked against the budget, tokens are deducted from the response
+		zero := uint64(0)
+		rules = append(rules, egv1a1.RateLimitRule{
+			ClientSelectors: selectors,
+			Limit: egv1a1.RateLimitValue{
+				Requests: rule.Limit,
+				Unit:     egv1a1.RateLimitUnit(rule.Unit),
+			},
+			Cost: &egv1a1.RateLimitCost{
+				Request: &egv1a1.RateLimitCostSpecifier{
+					From:   egv1a1.RateLimitCostFromNumber,
+					Number: &zero,
+				},
+				Response: &egv1a1.RateLimitCostSpecifier{
+					From: egv1a1.RateLimitCostFromMetadata,
+					Metadata: &egv1a1.RateLimitCostMetadata{
+						Namespace: aigatewayv1alpha1.AIGatewayFilterMetadataNamespace,
+						Key:       rule.costMetadataKey(),
+					},
+				},
+			},
+		})
+	}
+
+	return &egv1a1.BackendTrafficPolicy{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       KindBackendTrafficPolicy,
+			APIVersion: APIVersionGatewayV1Alpha1,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      p.Name,
+			Namespace: p.Namespace,
+			Labels:    map[string]string{LabelRateLimitPolicy: p.Route},
+		},
+		Spec: egv1a1.BackendTrafficPolicySpec{
+			PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+				TargetRefs: []gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{{
+					LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+						Group: GroupGatewayNetworking,
+						Kind:  KindHTTPRoute,
+						Name:  gwapiv1a2.ObjectName(p.Route),
+					},
+				}},
+			},
+			RateLimit: &egv1a1.RateLimitSpec{
+				Type:   egv1a1.GlobalRateLimitType,
+				Global: &egv1a1.GlobalRateLimit{Rules: rules},
+			},
+		},
+	}, nil
+}
+
+// ToRateLimitPolicy reconstructs a RateLimitPolicy from its BackendTrafficPolicy
+func ToRateLimitPolicy(policy *egv1a1.BackendTrafficPolicy) (*RateLimitPolicy, error) {
+	route, ok := policy.Labels[LabelRateLimitPolicy]
+	if !ok {
+		return nil, fmt.Errorf("BackendTrafficPolicy %s/%s is not a rate limit policy", policy.Namespace, policy.Name)
+	}
+	if policy.Spec.RateLimit == nil || policy.Spec.RateLimit.Global == nil {
+		return nil, fmt.Errorf("BackendTrafficPolicy %s/%s has no global rate limit", policy.Namespace, policy.Name)
+	}
+
+	result := &RateLimitPolicy{
+		Name:      policy.Name,
+		Namespace: policy.Namespace,
+		Route:     route,
+		Rules:     make([]RateLimitRule, 0, len(policy.Spec.RateLimit.Global.Rules)),
+	}
+	if !policy.CreationTimestamp.IsZero() {
+		createdAt := policy.CreationTimestamp
+		result.CreatedAt = &createdAt
+	}
+	result.Status, result.Message = rateLimitStatus(policy.Status.Ancestors)
+
+	for i, rule := range policy.Spec.RateLimit.Global.Rules {
+		if rule.Cost == nil || rule.Cost.Response == nil || rule.Cost.Response.Metadata == nil {
+			return nil, fmt.Errorf("rule %d of BackendTrafficPolicy %s/%s is not a token limit", i, policy.Namespace, policy.Name)
+		}
+
+		key, provider, _ := strings.Cut(rule.Cost.Response.Metadata.Key, ".")
+		tokenType := strings.TrimSuffix(strings.TrimPrefix(key, "llm_"), "_token")
+		if _, ok := requestCostTypes[tokenType]; !ok {
+			return nil, fmt.Errorf("rule %d of BackendTrafficPolicy %s/%s counts unknown cost %q", i, policy.Namespace, policy.Name, rule.Cost.Response.Metadata.Key)
+		}
+
+		parsed := RateLimitRule{
+			TokenType: tokenType,
+			Limit:     rule.Limit.Requests,
+			Unit:      string(rule.Limit.Unit),
+			Provider:  provider,
+		}
+		for _, selector := range rule.ClientSelectors {
+			for _, header := range selector.Headers {
+				switch {
+				case header.Type != nil && *header.Type == egv1a1.HeaderMatchDistinct:
+					parsed.UserHeader = header.Name
+				case header.Name == aigatewayv1alpha1.AIModelHeaderKey && header.Value != nil:
+					parsed.Model = *header.Value
+				}
+			}
+		}
+		result.Rules = append(result.Rules, parsed)
+	}
+
+	return result, nil
+}
+
+// rateLimitStatus summarizes the Accepted conditions reported by the gateway controllers
+func rateLimitStatus(ancestors []gwapiv1a2.PolicyAncestorStatus) (string, string) {
+	status := ""
+	for _, ancestor := range ancestors {
+		for _, condition := range ancestor.Conditions {
+			if condition.Type != string(gwapiv1a2.PolicyConditionAccepted) {
+				continue
+			}
+			if condition.Status != metav1.ConditionTrue {
+				return RateLimitStatusNotAccepted, condition.Message
+			}
+			status = RateLimitStatusAccepted
+		}
+	}
+	return status, ""
+}
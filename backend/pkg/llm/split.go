@@ -0,0 +1,160 @@
+package llm
+
+import (
+	"fmt"
+	"slices"
+
+	aigatewayv1alpha1 "github.com/envoyproxy/ai-gateway/api/v1alpha1"
+)
+
+// TrafficSplit is the weighted distribution of the requests matched by an AIGatewayRoute rule
+// over LLM providers
+type TrafficSplit struct {
+	Namespace string `json:"namespace"`
+	Route     string `json:"route"`
+	Rule      int    `json:"rule"` // index of the rule in the route
+
+	Backends []WeightedBackend `json:"backends"`
+
+	// Read-only models matched by the rule through the x-ai-eg-model header
+	Models []string `json:"models,omitempty"`
+}
+
+// WeightedBackend is a provider receiving weight/sum(weights) of the traffic
+type WeightedBackend struct {
+	Provider string `json:"provider"`
+	Weight   int32  `json:"weight"`
+}
+
+// schemaTranslations lists the backend schemas AI Gateway translates each route schema to
+var schemaTranslations = map[aigatewayv1alpha1.APISchema][]aigatewayv1alpha1.APISchema{
+	aigatewayv1alpha1.APISchemaOpenAI: {
+		aigatewayv1alpha1.APISchemaOpenAI,
+		aigatewayv1alpha1.APISchemaAWSBedrock,
+		aigatewayv1alpha1.APISchemaAzureOpenAI,
+		aigatewayv1alpha1.APISchemaGCPVertexAI,
+		aigatewayv1alpha1.APISchemaGCPAnthropic,
+	},
+}
+
+// CompatibleSchema reports whether requests received with the route schema can be sent to a
+// backend speaking the backend schema
+func CompatibleSchema(route, backend aigatewayv1alpha1.APISchema) bool {
+	if route == backend {
+		return true
+	}
+	return slices.Contains(schemaTranslations[route], backend)
+}
+
+// RouteSchema returns the input schema of the route, OpenAI when unset
+func RouteSchema(route *aigatewayv1alpha1.AIGatewayRoute) aigatewayv1alpha1.APISchema {
+	if route.Spec.APISchema == nil || route.Spec.APISchema.Name == "" {
+		return aigatewayv1alpha1.APISchemaOpenAI
+	}
+	return route.Spec.APISchema.Name
+}
+
+// Validate checks that the split sends traffic somewhere and names every provider once
+func (t *TrafficSplit) Validate() error {
+	if len(t.Backends) == 0 {
+		return fmt.Errorf("traffic split requires at least one backend")
+	}
+	total := int32(0)
+	seen := map[string]bool{}
+	for _, backend := range t.Backends {
+		if backend.Provider == "" {
+			return fmt.Errorf("backend provider is required")
+		}
+		if seen[backend.Provider] {
+			return fmt.Errorf("provider %s is listed more than once", backend.Provider)
+		}
+		seen[backend.Provider] = true
+		if backend.Weight < 0 {
+			return fmt.Errorf("weight of provider %s must not be negative", backend.Provider)
+		}
+		total += backend.Weight
+	}
+	if total == 0 {
+		return fmt.Errorf("at least one backend requires a positive weight")
+	}
+	return nil
+}
+
+// ToTrafficSplit reads the weighted backends of a route rule
+func ToTrafficSplit(route *aigatewayv1alpha1.AIGatewayRoute, rule int) (*TrafficSplit, error) {
+	if rule < 0 || rule >= len(route.Spec.Rules) {
+		return nil, fmt.Errorf("AIGatewayRoute %s/%s has no rule %d", route.Namespace, route.Name, rule)
+	}
+
+	split := &TrafficSplit{
+		Namespace: route.Namespace,
+		Route:     route.Name,
+		Rule:      rule,
+		Backends:  make([]WeightedBackend, 0, len(route.Spec.Rules[rule].BackendRefs)),
+	}
+	for _, ref := range route.Spec.Rules[rule].BackendRefs {
+		if ref.Kind != nil && *ref.Kind != KindAIServiceBackend {
+			return nil, fmt.Errorf("rule %d of AIGatewayRoute %s/%s routes to a %s, which cannot be split", rule, route.Namespace, route.Name, *ref.Kind)
+		}
+		// An unset weight is 1 as in Gateway API
+		weight := int32(1)
+		if ref.Weight != nil {
+			weight = *ref.Weight
+		}
+		split.Backends = append(split.Backends, WeightedBackend{Provider: ref.Name, Weight: weight})
+	}
+	for _, match := range route.Spec.Rules[rule].Matches {
+		for _, header := range match.Headers {
+			if string(header.Name) == aigatewayv1alpha1.AIModelHeaderKey {
+				split.Models = append(split.Models, header.Value)
+			}
+		}
+	}
+	return split, nil
+}
+
+// ApplyTo replaces the backendRefs of the route rule with the split. Settings of providers
+// already referenced by the rule, such as their model name override, are kept.
+func (t *TrafficSplit) ApplyTo(route *aigatewayv1alpha1.AIGatewayRoute) error {
+	if err := t.Validate(); err != nil {
+		return err
+	}
+	if t.Rule < 0 || t.Rule >= len(route.Spec.Rules) {
+		return fmt.Errorf("AIGatewayRoute %s/%s has no rule %d", route.Namespace, route.Name, t.Rule)
+	}
+
+	rule := &route.Spec.Rules[t.Rule]
+	refs := make([]aigatewayv1alpha1.AIGatewayRouteRuleBackendRef, 0, len(t.Backends))
+	for _, backend := range t.Backends {
+		ref := aigatewayv1alpha1.AIGatewayRouteRuleBackendRef{Name: backend.Provider}
+		if i := slices.IndexFunc(rule.BackendRefs, func(r aigatewayv1alpha1.AIGatewayRouteRuleBackendRef) bool {
+			return r.Name == backend.Provider
+		}); i >= 0 {
+			ref = rule.BackendRefs[i]
+		}
+		weight := backend.Weight
+		ref.Weight = &weight
+		refs = append(refs, ref)
+	}
+	rule.BackendRefs = refs
+	return nil
+}
+
+// Shift returns a copy of the split where the combined weight of from and to is divided so
+// that to receives percent of it, adding to when missing. Other backends keep their weight.
+func (t *TrafficSplit) Shift(from, to string, percent int32) *TrafficSplit {
+	shifted := *t
+	shifted.Backends = slices.Clone(t.Backends)
+	if !slices.ContainsFunc(shifted.Backends, func(b WeightedBackend) bool { return b.Provider == to }) {
+		shifted.Backends = append(shifted.Backends, WeightedBackend{Provider: to})
+	}
+	for i := range shifted.Backends {
+		switch shifted.Backends[i].Provider {
+		case from:
+			shifted.Backends[i].Weight = 100 - percent
+		case to:
+			shifted.Backends[i].Weight = percent
+		}
+	}
+	return &shifted
+}
@@ -0,0 +1,259 @@
+package llm
+
+import (
+	"fmt"
+	"regexp"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// TrafficPolicy configures how requests to the provider's Backend are timed out, retried and limited
+type TrafficPolicy struct {
+	RequestTimeout string `json:"requestTimeout,omitempty"` // e.g. "60s"
+	ConnectTimeout string `json:"connectTimeout,omitempty"`
+
+	Retry          *RetryPolicy          `json:"retry,omitempty"`
+	CircuitBreaker *CircuitBreakerPolicy `json:"circuitBreaker,omitempty"`
+	Connection     *ConnectionLimits     `json:"connection,omitempty"`
+}
+
+// RetryPolicy retries failed requests with an exponential backoff
+type RetryPolicy struct {
+	NumRetries int32 `json:"numRetries"`
+	// StatusCodes are retried along with connection failures, defaults to DefaultRetryStatusCodes
+	StatusCodes   []int  `json:"statusCodes,omitempty"`
+	PerTryTimeout string `json:"perTryTimeout,omitempty"`
+	BaseInterval  string `json:"baseInterval,omitempty"` // e.g. "100ms"
+	MaxInterval   string `json:"maxInterval,omitempty"`
+}
+
+// CircuitBreakerPolicy rejects requests beyond these thresholds instead of queueing them
+type CircuitBreakerPolicy struct {
+	MaxPendingRequests  *int64 `json:"maxPendingRequests,omitempty"`
+	MaxParallelRequests *int64 `json:"maxParallelRequests,omitempty"`
+	MaxParallelRetries  *int64 `json:"maxParallelRetries,omitempty"`
+}
+
+// ConnectionLimits bound the connections opened to the provider
+type ConnectionLimits struct {
+	MaxConnections           *int64 `json:"maxConnections,omitempty"`
+	MaxRequestsPerConnection *int64 `json:"maxRequestsPerConnection,omitempty"`
+	BufferLimit              string `json:"bufferLimit,omitempty"` // e.g. "32Ki"
+}
+
+// DefaultRetryStatusCodes are the rate limit and server errors LLM APIs answer with when overloaded
+var DefaultRetryStatusCodes = []int{429, 500, 502, 503, 504}
+
+// gatewayDurationPattern is the duration format accepted by Gateway API
+var gatewayDurationPattern = regexp.MustCompile(`^([0-9]{1,5}(h|m|s|ms)){1,4}$`)
+
+// Validate checks the durations, quantities and thresholds of the traffic policy
+func (t *TrafficPolicy) Validate() error {
+	durations := map[string]string{"requestTimeout": t.RequestTimeout, "connectTimeout": t.ConnectTimeout}
+	if t.Retry != nil {
+		if t.Retry.NumRetries < 0 {
+			return fmt.Errorf("retry.numRetries must not be negative")
+		}
+		for _, code := range t.Retry.StatusCodes {
+			if code < 100 || code > 599 {
+				return fmt.Errorf("retry.statusCodes: invalid HTTP status %d", code)
+			}
+		}
+		durations["retry.perTryTimeout"] = t.Retry.PerTryTimeout
+		durations["retry.baseInterval"] = t.Retry.BaseInterval
+		durations["retry.maxInterval"] = t.Retry.MaxInterval
+	}
+	for field, value := range durations {
+		if value != "" && !gatewayDurationPattern.MatchString(value) {
+			return fmt.Errorf("%s: invalid duration %q, e.g. 30s or 1m30s", field, value)
+		}
+	}
+
+	var thresholds []*int64
+	if t.CircuitBreaker != nil {
+		thresholds = append(thresholds, t.CircuitBreaker.MaxPendingRequests, t.CircuitBreaker.MaxParallelRequests, t.CircuitBreaker.MaxParallelRetries)
+	}
+	if t.Connection != nil {
+		thresholds = append(thresholds, t.Connection.MaxConnections, t.Connection.MaxRequestsPerConnection)
+		if t.Connection.BufferLimit != "" {
+			if _, err := resource.ParseQuantity(t.Connection.BufferLimit); err != nil {
+				return fmt.Errorf("connection.bufferLimit: invalid quantity %q", t.Connection.BufferLimit)
+			}
+		}
+	}
+	for _, threshold := range thresholds {
+		if threshold != nil && *threshold < 0 {
+			return fmt.Errorf("circuit breaker and connection limits must not be negative")
+		}
+	}
+	return nil
+}
+
+// toBackendTrafficPolicy translates the traffic settings of the provider into a
+// BackendTrafficPolicy targeting its Backend
+func (l *LLMProvider) toBackendTrafficPolicy() (*egv1a1.BackendTrafficPolicy, error) {
+	t := l.Traffic
+	if err := t.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid traffic policy: %w", err)
+	}
+
+	settings := egv1a1.ClusterSettings{}
+	if t.RequestTimeout != "" || t.ConnectTimeout != "" {
+		settings.Timeout = &egv1a1.Timeout{}
+		if t.RequestTimeout != "" {
+			settings.Timeout.HTTP = &egv1a1.HTTPTimeout{RequestTimeout: durationPtr(t.RequestTimeout)}
+		}
+		if t.ConnectTimeout != "" {
+			settings.Timeout.TCP = &egv1a1.TCPTimeout{ConnectTimeout: durationPtr(t.ConnectTimeout)}
+		}
+	}
+
+	if t.Retry != nil {
+		codes := t.Retry.StatusCodes
+		if len(codes) == 0 {
+			codes = DefaultRetryStatusCodes
+		}
+		statuses := make([]egv1a1.HTTPStatus, 0, len(codes))
+		for _, code := range codes {
+			statuses = append(statuses, egv1a1.HTTPStatus(code))
+		}
+
+		numRetries := t.Retry.NumRetries
+		settings.Retry = &egv1a1.Retry{
+			NumRetries: &numRetries,
+			RetryOn: &egv1a1.RetryOn{
+				Triggers:        []egv1a1.TriggerEnum{egv1a1.ConnectFailure, egv1a1.RetriableStatusCodes},
+				HTTPStatusCodes: statuses,
+			},
+		}
+		if t.Retry.PerTryTimeout != "" || t.Retry.BaseInterval != "" || t.Retry.MaxInterval != "" {
+			settings.Retry.PerRetry = &egv1a1.PerRetryPolicy{Timeout: durationPtr(t.Retry.PerTryTimeout)}
+			if t.Retry.BaseInterval != "" || t.Retry.MaxInterval != "" {
+				settings.Retry.PerRetry.BackOff = &egv1a1.BackOffPolicy{
+					BaseInterval: durationPtr(t.Retry.BaseInterval),
+					MaxInterval:  durationPtr(t.Retry.MaxInterval),
+				}
+			}
+		}
+	}
+
+	if t.CircuitBreaker != nil || (t.Connection != nil && (t.Connection.MaxConnections != nil || t.Connection.MaxRequestsPerConnection != nil)) {
+		settings.CircuitBreaker = &egv1a1.CircuitBreaker{}
+		if t.CircuitBreaker != nil {
+			settings.CircuitBreaker.MaxPendingRequests = t.CircuitBreaker.MaxPendingRequests
+			settings.CircuitBreaker.MaxParallelRequests = t.CircuitBreaker.MaxParallelRequests
+			settings.CircuitBreaker.MaxParallelRetries = t.CircuitBreaker.MaxParallelRetries
+		}
+		if t.Connection != nil {
+			settings.CircuitBreaker.MaxConnections = t.Connection.MaxConnections
+			settings.CircuitBreaker.MaxRequestsPerConnection = t.Connection.MaxRequestsPerConnection
+		}
+	}
+
+	if t.Connection != nil && t.Connection.BufferLimit != "" {
+		limit := resource.MustParse(t.Connection.BufferLimit)
+		settings.Connection = &egv1a1.BackendConnection{BufferLimit: &limit}
+	}
+
+	return &egv1a1.BackendTrafficPolicy{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       KindBackendTrafficPolicy,
+			APIVersion: APIVersionGatewayV1Alpha1,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      l.Name,
+			Namespace: l.Namespace,
+		},
+		Spec: egv1a1.BackendTrafficPolicySpec{
+			PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+				TargetRefs: []gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{{
+					LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+						Group: GroupGatewayEnvoyProxy,
+						Kind:  KindBackend,
+						Name:  gwapiv1a2.ObjectName(l.Name),
+					},
+				}},
+			},
+			ClusterSettings: settings,
+		},
+	}, nil
+}
+
+// toTrafficPolicy reconstructs the traffic settings of a provider from its BackendTrafficPolicy
+func toTrafficPolicy(policy *egv1a1.BackendTrafficPolicy) *TrafficPolicy {
+	settings := policy.Spec.ClusterSettings
+	t := &TrafficPolicy{}
+
+	if settings.Timeout != nil {
+		if settings.Timeout.HTTP != nil && settings.Timeout.HTTP.RequestTimeout != nil {
+			t.RequestTimeout = string(*settings.Timeout.HTTP.RequestTimeout)
+		}
+		if settings.Timeout.TCP != nil && settings.Timeout.TCP.ConnectTimeout != nil {
+			t.ConnectTimeout = string(*settings.Timeout.TCP.ConnectTimeout)
+		}
+	}
+
+	if retry := settings.Retry; retry != nil {
+		t.Retry = &RetryPolicy{}
+		if retry.NumRetries != nil {
+			t.Retry.NumRetries = *retry.NumRetries
+		}
+		if retry.RetryOn != nil {
+			for _, code := range retry.RetryOn.HTTPStatusCodes {
+				t.Retry.StatusCodes = append(t.Retry.StatusCodes, int(code))
+			}
+		}
+		if retry.PerRetry != nil {
+			t.Retry.PerTryTimeout = durationValue(retry.PerRetry.Timeout)
+			if retry.PerRetry.BackOff != nil {
+				t.Retry.BaseInterval = durationValue(retry.PerRetry.BackOff.BaseInterval)
+				t.Retry.MaxInterval = durationValue(retry.PerRetry.BackOff.MaxInterval)
+			}
+		}
+	}
+
+	if cb := settings.CircuitBreaker; cb != nil {
+		if cb.MaxPendingRequests != nil || cb.MaxParallelRequests != nil || cb.MaxParallelRetries != nil {
+			t.CircuitBreaker = &CircuitBreakerPolicy{
+				MaxPendingRequests:  cb.MaxPendingRequests,
+				MaxParallelRequests: cb.MaxParallelRequests,
+				MaxParallelRetries:  cb.MaxParallelRetries,
+			}
+		}
+		if cb.MaxConnections != nil || cb.MaxRequestsPerConnection != nil {
+			t.Connection = &ConnectionLimits{
+				MaxConnections:           cb.MaxConnections,
+				MaxRequestsPerConnection: cb.MaxRequestsPerConnection,
+			}
+		}
+	}
+
+	if settings.Connection != nil && settings.Connection.BufferLimit != nil {
+		if t.Connection == nil {
+			t.Connection = &ConnectionLimits{}
+		}
+		t.Connection.BufferLimit = settings.Connection.BufferLimit.String()
+	}
+
+	return t
+}
+
+// durationPtr returns nil for an empty duration
+func durationPtr(value string) *gwapiv1.Duration {
+	if value == "" {
+		return nil
+	}
+	d := gwapiv1.Duration(value)
+	return &d
+}
+
+func durationValue(d *gwapiv1.Duration) string {
+	if d == nil {
+		return ""
+	}
+	return string(*d)
+}
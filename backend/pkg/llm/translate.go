@@ -21,10 +21,11 @@ const (
 	KindAIServiceBackend      = "AIServiceBackend"
 	KindSecret                = "Secret"
 
-	APIVersionGatewayV1Alpha1   = "gateway.envoyproxy.io/v1alpha1"
-	APIVersionGatewayV1Alpha3   = "gateway.envoyproxy.io/v1alpha3"
-	APIVersionAIGatewayV1Alpha1 = "aigateway.envoyproxy.io/v1alpha1"
-	APIVersionV1                = "v1"
+	APIVersionGatewayV1Alpha1    = "gateway.envoyproxy.io/v1alpha1"
+	APIVersionGatewayAPIV1Alpha3 = "gateway.networking.k8s.io/v1alpha3"
+	APIVersionGatewayAPIV1       = "gateway.networking.k8s.io/v1"
+	APIVersionAIGatewayV1Alpha1  = "aigateway.envoyproxy.io/v1alpha1"
+	APIVersionV1                 = "v1"
 
 	GroupGatewayEnvoyProxy   = "gateway.envoyproxy.io"
 	GroupAIGatewayEnvoyProxy = "aigateway.envoyproxy.io"
@@ -39,7 +40,16 @@ const (
 	AuthTypeGCP    = "gcp"
 )
 
+// ToEnvoyGatewayResources translates the provider to the resources of the default API versions
 func (l *LLMProvider) ToEnvoyGatewayResources() ([]any, error) {
+	return l.ToEnvoyGatewayResourcesFor(DefaultAPIVersions)
+}
+
+// ToEnvoyGatewayResourcesFor translates the provider to the resources of the given API versions
+func (l *LLMProvider) ToEnvoyGatewayResourcesFor(versions APIVersions) ([]any, error) {
+	if err := versions.Validate(); err != nil {
+		return nil, err
+	}
 	var resources []any
 	backend := &gatewayv1alpha1.Backend{
 		TypeMeta: metav1.TypeMeta{
@@ -63,38 +73,48 @@ func (l *LLMProvider) ToEnvoyGatewayResources() ([]any, error) {
 	}
 	resources = append(resources, backend)
 
-	tls := &gwapiv1a3.BackendTLSPolicy{
-		TypeMeta: metav1.TypeMeta{
-			Kind:       KindBackendTLSPolicy,
-			APIVersion: APIVersionGatewayV1Alpha3,
-		},
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      l.Name,
-			Namespace: l.Namespace,
-		},
-		Spec: gwapiv1a3.BackendTLSPolicySpec{
-			TargetRefs: []gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{{
-				LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
-					Group: GroupGatewayEnvoyProxy,
-					Kind:  KindBackend,
-					Name:  gwapiv1a2.ObjectName(l.Name),
+	// A BackendTLSPolicy requires a hostname, providers without one talk plain HTTP
+	if l.TLS.Hostname != "" {
+		tls := &gwapiv1a3.BackendTLSPolicy{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       KindBackendTLSPolicy,
+				APIVersion: versions.BackendTLSPolicyAPIVersion(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      l.Name,
+				Namespace: l.Namespace,
+			},
+			Spec: gwapiv1a3.BackendTLSPolicySpec{
+				TargetRefs: []gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{{
+					LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+						Group: GroupGatewayEnvoyProxy,
+						Kind:  KindBackend,
+						Name:  gwapiv1a2.ObjectName(l.Name),
+					},
+				}},
+				Validation: gwapiv1a3.BackendTLSPolicyValidation{
+					Hostname: gwapiv1.PreciseHostname(l.TLS.Hostname),
 				},
-			}},
-			Validation: gwapiv1a3.BackendTLSPolicyValidation{
-				Hostname: gwapiv1.PreciseHostname(l.TLS.Hostname),
-				WellKnownCACertificates: func() *gwapiv1a3.WellKnownCACertificatesType {
-					val := gwapiv1a3.WellKnownCACertificatesType(l.TLS.WellKnownCACertificates)
-					return &val
-				}(),
 			},
-		},
+		}
+		if l.TLS.WellKnownCACertificates != "" {
+			tls.Spec.Validation.WellKnownCACertificates = strPtr(gwapiv1a3.WellKnownCACertificatesType(l.TLS.WellKnownCACertificates))
+		}
+		resources = append(resources, tls)
+	}
+
+	if l.Traffic != nil {
+		traffic, err := l.toBackendTrafficPolicy()
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, traffic)
 	}
-	resources = append(resources, tls)
 
 	bsp := &aigatewayv1alpha1.BackendSecurityPolicy{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       KindBackendSecurityPolicy,
-			APIVersion: APIVersionAIGatewayV1Alpha1,
+			APIVersion: versions.AIGatewayAPIVersion(),
 		},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      l.Name,
@@ -384,7 +404,7 @@ func (l *LLMProvider) ToEnvoyGatewayResources() ([]any, error) {
 	aisb := &aigatewayv1alpha1.AIServiceBackend{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       KindAIServiceBackend,
-			APIVersion: APIVersionAIGatewayV1Alpha1,
+			APIVersion: versions.AIGatewayAPIVersion(),
 		},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      l.Name,
@@ -392,8 +412,7 @@ func (l *LLMProvider) ToEnvoyGatewayResources() ([]any, error) {
 		},
 		Spec: aigatewayv1alpha1.AIServiceBackendSpec{
 			APISchema: aigatewayv1alpha1.VersionedAPISchema{
-				Name:    aigatewayv1alpha1.APISchema(l.Schema),
-				Version: &l.Version,
+				Name: aigatewayv1alpha1.APISchema(l.Schema),
 			},
 			BackendRef: gwapiv1.BackendObjectReference{
 				Group:     strPtr(gwapiv1.Group(GroupGatewayEnvoyProxy)),
@@ -405,11 +424,31 @@ func (l *LLMProvider) ToEnvoyGatewayResources() ([]any, error) {
 			// Note: Removed deprecated BackendSecurityPolicyRef - using targetRefs in BackendSecurityPolicy instead
 		},
 	}
+	if l.Version != "" {
+		aisb.Spec.APISchema.Version = strPtr(l.Version)
+	}
+	if err := l.setModelAnnotations(&aisb.ObjectMeta); err != nil {
+		return nil, err
+	}
 	resources = append(resources, aisb)
 
 	return resources, nil
 }
 
+// providerStatus returns the type of the most recent reconciliation condition
+func providerStatus(conditions []metav1.Condition) string {
+	var latest *metav1.Condition
+	for i := range conditions {
+		if latest == nil || conditions[i].LastTransitionTime.After(latest.LastTransitionTime.Time) {
+			latest = &conditions[i]
+		}
+	}
+	if latest == nil {
+		return ""
+	}
+	return latest.Type
+}
+
 func strPtr[T ~string](val T) *T { return &val }
 
 func portPtr(val int32) *gwapiv1.PortNumber {
@@ -417,11 +456,43 @@ func portPtr(val int32) *gwapiv1.PortNumber {
 	return &p
 }
 
+// RecoverLLMProvider returns what can be read of a provider whose resources do not
+// translate: the metadata, schema and models of the AIServiceBackend and the endpoint
+// of the Backend if it is among the resources. The status is StatusBroken.
+func RecoverLLMProvider(aisb *aigatewayv1alpha1.AIServiceBackend, resources []interface{}) *LLMProvider {
+	provider := &LLMProvider{
+		Name:      aisb.Name,
+		Namespace: aisb.Namespace,
+		Schema:    string(aisb.Spec.APISchema.Name),
+		Status:    StatusBroken,
+	}
+	if aisb.Spec.APISchema.Version != nil {
+		provider.Version = *aisb.Spec.APISchema.Version
+	}
+	if !aisb.CreationTimestamp.IsZero() {
+		createdAt := aisb.CreationTimestamp
+		provider.CreatedAt = &createdAt
+	}
+	// Unreadable mappings are left out, they may be the reason the provider is broken
+	provider.Models, _ = ModelMappings(aisb)
+
+	for _, res := range resources {
+		if backend, ok := res.(*gatewayv1alpha1.Backend); ok && len(backend.Spec.Endpoints) > 0 && backend.Spec.Endpoints[0].FQDN != nil {
+			provider.Backend = Backend{
+				Host: backend.Spec.Endpoints[0].FQDN.Hostname,
+				Port: backend.Spec.Endpoints[0].FQDN.Port,
+			}
+		}
+	}
+	return provider
+}
+
 // FromEnvoyGatewayResources reconstructs an LLMProvider object from a set of Envoy Gateway resources
 func ToLLMProvider(resources []interface{}) (*LLMProvider, error) {
 	var (
 		backend   *gatewayv1alpha1.Backend
 		tlsPolicy *gwapiv1a3.BackendTLSPolicy
+		traffic   *gatewayv1alpha1.BackendTrafficPolicy
 		bsp       *aigatewayv1alpha1.BackendSecurityPolicy
 		aisb      *aigatewayv1alpha1.AIServiceBackend
 		secret    *corev1.Secret
@@ -432,8 +503,10 @@ func ToLLMProvider(resources []interface{}) (*LLMProvider, error) {
 		switch r := res.(type) {
 		case *gatewayv1alpha1.Backend:
 			backend = r
-		case *gwapiv1a3.BackendTLSPolicy:
+		case *gwapiv1a3.BackendTLSPolicy: // v1alpha3 and v1 policies share the Go type
 			tlsPolicy = r
+		case *gatewayv1alpha1.BackendTrafficPolicy:
+			traffic = r
 		case *aigatewayv1alpha1.BackendSecurityPolicy:
 			bsp = r
 		case *aigatewayv1alpha1.AIServiceBackend:
@@ -455,6 +528,12 @@ func ToLLMProvider(resources []interface{}) (*LLMProvider, error) {
 		Namespace: aisb.Namespace,
 	}
 
+	if !aisb.CreationTimestamp.IsZero() {
+		createdAt := aisb.CreationTimestamp
+		provider.CreatedAt = &createdAt
+	}
+	provider.Status = providerStatus(aisb.Status.Conditions)
+
 	// Set schema and version
 	if aisb.Spec.APISchema.Name != "" {
 		provider.Schema = string(aisb.Spec.APISchema.Name)
@@ -472,7 +551,7 @@ func ToLLMProvider(resources []interface{}) (*LLMProvider, error) {
 	}
 
 	// Set TLS info
-	if tlsPolicy != nil && tlsPolicy.Spec.Validation.Hostname != "" {
+	if tlsPolicy != nil {
 		provider.TLS = TLSValidation{
 			Hostname: string(tlsPolicy.Spec.Validation.Hostname),
 		}
@@ -481,6 +560,16 @@ func ToLLMProvider(resources []interface{}) (*LLMProvider, error) {
 		}
 	}
 
+	if traffic != nil {
+		provider.Traffic = toTrafficPolicy(traffic)
+	}
+
+	models, err := ModelMappings(aisb)
+	if err != nil {
+		return nil, err
+	}
+	provider.Models = models
+
 	// Set auth info based on BSP type
 	switch bsp.Spec.Type {
 	case aigatewayv1alpha1.BackendSecurityPolicyTypeAPIKey:
@@ -553,14 +642,13 @@ func ToLLMProvider(resources []interface{}) (*LLMProvider, error) {
 				provider.Auth.GCP.OIDCClientID = *bsp.Spec.GCPCredentials.WorkloadIdentityFederationConfig.OIDCExchangeToken.OIDC.ClientID
 			}
 
-			// Get client secret from Secret
+			// Get client secret from Secret. The legacy PrivateKey is only a fallback on
+			// input and is not filled in, so the provider reads back as it was written.
 			if secret != nil {
 				if clientSecret, ok := secret.StringData[KeyClientSecret]; ok {
 					provider.Auth.GCP.OIDCClientSecret = clientSecret
-					provider.Auth.GCP.PrivateKey = clientSecret
 				} else if clientSecret, ok := secret.Data[KeyClientSecret]; ok {
 					provider.Auth.GCP.OIDCClientSecret = string(clientSecret)
-					provider.Auth.GCP.PrivateKey = string(clientSecret)
 				}
 			}
 		}
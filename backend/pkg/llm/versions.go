@@ -0,0 +1,83 @@
+package llm
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	GroupGatewayAPI = "gateway.networking.k8s.io"
+
+	VersionV1       = "v1"
+	VersionV1Alpha1 = "v1alpha1"
+	VersionV1Alpha3 = "v1alpha3"
+)
+
+// BackendTLSPolicyVersions are the Gateway API versions of BackendTLSPolicy the console
+// writes, most preferred first. v1 is written through the v1alpha3 Go type, whose spec it matches.
+var BackendTLSPolicyVersions = []string{VersionV1, VersionV1Alpha3}
+
+// AIGatewayVersions are the AI Gateway API versions the console writes. The AI Gateway module
+// the console builds against only has v1alpha1, other versions are not supported.
+var AIGatewayVersions = []string{VersionV1Alpha1}
+
+// APIVersions selects the versions of the resources a provider translates to
+type APIVersions struct {
+	// BackendTLSPolicy is the gateway.networking.k8s.io version of the BackendTLSPolicy
+	BackendTLSPolicy string `json:"backendTLSPolicy"`
+	// AIGateway is the aigateway.envoyproxy.io version of the AIServiceBackend and BackendSecurityPolicy
+	AIGateway string `json:"aiGateway"`
+}
+
+// DefaultAPIVersions are used until the versions served by the cluster are known
+var DefaultAPIVersions = APIVersions{BackendTLSPolicy: VersionV1Alpha3, AIGateway: VersionV1Alpha1}
+
+// Validate checks that every version is one the console writes
+func (v APIVersions) Validate() error {
+	if !slices.Contains(BackendTLSPolicyVersions, v.BackendTLSPolicy) {
+		return fmt.Errorf("unsupported BackendTLSPolicy version %q: must be one of %s", v.BackendTLSPolicy, strings.Join(BackendTLSPolicyVersions, ", "))
+	}
+	if !slices.Contains(AIGatewayVersions, v.AIGateway) {
+		return fmt.Errorf("unsupported AI Gateway API version %q: must be one of %s", v.AIGateway, strings.Join(AIGatewayVersions, ", "))
+	}
+	return nil
+}
+
+// BackendTLSPolicyAPIVersion returns the apiVersion of the BackendTLSPolicy
+func (v APIVersions) BackendTLSPolicyAPIVersion() string {
+	return GroupGatewayAPI + "/" + v.BackendTLSPolicy
+}
+
+// AIGatewayAPIVersion returns the apiVersion of the AI Gateway resources
+func (v APIVersions) AIGatewayAPIVersion() string {
+	return GroupAIGatewayEnvoyProxy + "/" + v.AIGateway
+}
+
+// SelectAPIVersions picks the most preferred supported version of each API among the
+// versions served by the cluster. A kind served in no version keeps its default, the
+// missing CRD is reported elsewhere.
+func SelectAPIVersions(backendTLSPolicy, aiGateway []string) (APIVersions, error) {
+	versions := DefaultAPIVersions
+	var err error
+	if versions.BackendTLSPolicy, err = selectVersion("BackendTLSPolicy", BackendTLSPolicyVersions, backendTLSPolicy, versions.BackendTLSPolicy); err != nil {
+		return DefaultAPIVersions, err
+	}
+	if versions.AIGateway, err = selectVersion("AI Gateway API", AIGatewayVersions, aiGateway, versions.AIGateway); err != nil {
+		return DefaultAPIVersions, err
+	}
+	return versions, nil
+}
+
+// selectVersion returns the first supported version that is served
+func selectVersion(api string, supported, served []string, fallback string) (string, error) {
+	if len(served) == 0 {
+		return fallback, nil
+	}
+	for _, version := range supported {
+		if slices.Contains(served, version) {
+			return version, nil
+		}
+	}
+	return "", fmt.Errorf("%s versions %s are not supported: the console writes %s", api, strings.Join(served, ", "), strings.Join(supported, ", "))
+}
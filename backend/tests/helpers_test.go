@@ -0,0 +1,139 @@
+package tests
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	aigatewayv1alpha1 "github.com/envoyproxy/ai-gateway/api/v1alpha1"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/llm"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files in testdata with the actual output")
+
+func ptr[T any](v T) *T { return &v }
+
+// newOpenAIProvider returns a valid OpenAI provider the tests modify
+func newOpenAIProvider() *llm.LLMProvider {
+	return &llm.LLMProvider{
+		Name:      "openai",
+		Namespace: "default",
+		Schema:    "OpenAI",
+		Version:   "v1",
+		Auth:      llm.AuthConfig{Type: llm.AuthTypeAPIKey, APIKey: "sk-test"},
+		Backend:   llm.Backend{Host: "api.openai.com", Port: 443},
+		TLS:       llm.TLSValidation{Hostname: "api.openai.com", WellKnownCACertificates: "System"},
+	}
+}
+
+// newRoute returns an AIGatewayRoute in the default namespace with one rule per model,
+// each sending traffic to the backends
+func newRoute(name string, backends []string, models ...string) *aigatewayv1alpha1.AIGatewayRoute {
+	route := &aigatewayv1alpha1.AIGatewayRoute{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+	for _, model := range models {
+		rule := aigatewayv1alpha1.AIGatewayRouteRule{
+			Matches: []aigatewayv1alpha1.AIGatewayRouteRuleMatch{{
+				Headers: []gwapiv1.HTTPHeaderMatch{{Name: aigatewayv1alpha1.AIModelHeaderKey, Value: model}},
+			}},
+		}
+		for _, backend := range backends {
+			rule.BackendRefs = append(rule.BackendRefs, aigatewayv1alpha1.AIGatewayRouteRuleBackendRef{Name: backend})
+		}
+		route.Spec.Rules = append(route.Spec.Rules, rule)
+	}
+	return route
+}
+
+// assertInvalid applies every mutation to a new value and expects check to fail
+func assertInvalid[T any](t *testing.T, newValue func() T, check func(T) error, mutations map[string]func(T)) {
+	t.Helper()
+	for name, mutate := range mutations {
+		t.Run(name, func(t *testing.T) {
+			value := newValue()
+			mutate(value)
+			if err := check(value); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+// assertGolden compares the JSON of actual with the golden file, ignoring null fields.
+// go test -update rewrites the golden file instead.
+func assertGolden(t *testing.T, goldenFile string, actual any) {
+	t.Helper()
+	marshaled, err := json.MarshalIndent(actual, "", "  ")
+	if err != nil {
+		t.Fatalf("failed to marshal actual output: %v", err)
+	}
+	if *updateGolden {
+		if err := os.MkdirAll(filepath.Dir(goldenFile), 0o755); err != nil {
+			t.Fatalf("failed to create golden directory: %v", err)
+		}
+		if err := os.WriteFile(goldenFile, append(marshaled, '\n'), 0o644); err != nil {
+			t.Fatalf("failed to write golden json: %v", err)
+		}
+		return
+	}
+
+	goldenData, err := os.ReadFile(goldenFile)
+	if err != nil {
+		t.Fatalf("failed to read golden json: %v", err)
+	}
+	actualNorm, expectedNorm := normalizeJSON(t, marshaled), normalizeJSON(t, goldenData)
+	if actualNorm != expectedNorm {
+		t.Errorf("JSON output does not match golden file %s\nExpected:\n%s\nActual:\n%s", goldenFile, expectedNorm, actualNorm)
+	}
+}
+
+// normalizeJSON re-indents the JSON without its null and empty fields
+func normalizeJSON(t *testing.T, data []byte) string {
+	t.Helper()
+	var obj interface{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		t.Fatalf("failed to unmarshal json: %v", err)
+	}
+	normalized, _ := json.MarshalIndent(removeNulls(obj), "", "  ")
+	return string(normalized)
+}
+
+func removeNulls(v interface{}) interface{} {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		m := map[string]interface{}{}
+		for k, val := range vv {
+			cleaned := removeNulls(val)
+			if cleaned != nil {
+				m[k] = cleaned
+			}
+		}
+		if len(m) == 0 {
+			return nil
+		}
+		return m
+	case []interface{}:
+		arr := make([]interface{}, 0, len(vv))
+		for _, item := range vv {
+			cleaned := removeNulls(item)
+			if cleaned != nil {
+				arr = append(arr, cleaned)
+			}
+		}
+		return arr
+	default:
+		if vv == nil {
+			return nil
+		}
+		return vv
+	}
+}
+
+// translate translates the provider, for assertInvalid
+func translate(provider *llm.LLMProvider) error {
+	_, err := provider.ToEnvoyGatewayResources()
+	return err
+}
@@ -0,0 +1,317 @@
+//go:build integration
+
+// Package integration runs the console against a real API server started by envtest, with
+// the Envoy Gateway and AI Gateway CRDs of the module versions in go.mod installed, so the
+// generated resources are checked by the real CRD schemas and CEL rules.
+//
+// The suite needs the envtest binaries, e.g.
+//
+//	KUBEBUILDER_ASSETS=$(setup-envtest use -p path) go test -tags integration ./tests/integration/...
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/auth"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/authz"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/router"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/server"
+	"github.com/envoyproxy/ai-gateway/console/backend/internal/service"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/client"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/llm"
+	"github.com/go-logr/logr"
+	"k8s.io/client-go/discovery"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+)
+
+const token = "integration-token"
+
+// consoleURL is the base URL of the console server running against envtest, empty when the
+// envtest binaries are missing
+var consoleURL string
+
+func TestMain(m *testing.M) {
+	if os.Getenv("KUBEBUILDER_ASSETS") == "" {
+		// Every test reports the skip, so a run without envtest is not mistaken for a pass
+		os.Exit(m.Run())
+	}
+	os.Exit(run(m))
+}
+
+// requireCluster skips the test when the suite runs without the envtest binaries
+func requireCluster(t *testing.T) {
+	t.Helper()
+	if consoleURL == "" {
+		t.Skip("KUBEBUILDER_ASSETS is not set, run with KUBEBUILDER_ASSETS=$(setup-envtest use -p path)")
+	}
+}
+
+func run(m *testing.M) int {
+	crds, err := crdPaths()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to locate CRDs: %v\n", err)
+		return 1
+	}
+
+	env := &envtest.Environment{
+		CRDDirectoryPaths:     crds,
+		ErrorIfCRDPathMissing: true,
+	}
+	cfg, err := env.Start()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to start envtest: %v\n", err)
+		return 1
+	}
+	defer env.Stop()
+
+	scheme, err := client.NewScheme()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to build scheme: %v\n", err)
+		return 1
+	}
+	k8sClient, err := ctrlclient.New(cfg, ctrlclient.Options{Scheme: scheme})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to create client: %v\n", err)
+		return 1
+	}
+
+	tokens, err := os.CreateTemp("", "static-tokens-*.csv")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to create token file: %v\n", err)
+		return 1
+	}
+	defer os.Remove(tokens.Name())
+	fmt.Fprintf(tokens, "%s,integration,integration,\"system:masters\"\n", token)
+	tokens.Close()
+
+	// The test user is an admin everywhere, authorization is not disabled
+	policy, err := os.CreateTemp("", "roles-*.yaml")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to create role policy: %v\n", err)
+		return 1
+	}
+	defer os.Remove(policy.Name())
+	fmt.Fprint(policy, "bindings:\n  - role: admin\n    users: [integration]\n    namespaces: [\"*\"]\n")
+	policy.Close()
+
+	manager := client.NewManagerWithClient(k8sClient, logr.Discard())
+	manager.SetDiscovery(discovery.NewDiscoveryClientForConfigOrDie(cfg))
+	srv, err := server.NewServerWithManager(server.Config{
+		Auth:  auth.Config{Modes: []string{auth.MethodStatic}, StaticTokensFile: tokens.Name()},
+		Authz: authz.Config{PolicyFile: policy.Name()},
+	}, manager)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to create server: %v\n", err)
+		return 1
+	}
+	defer srv.Close()
+	httpServer := httptest.NewServer(router.NewRouter(srv))
+	defer httpServer.Close()
+	consoleURL = httpServer.URL
+	if err := waitReady(30 * time.Second); err != nil {
+		fmt.Fprintf(os.Stderr, "console did not become ready: %v\n", err)
+		return 1
+	}
+
+	return m.Run()
+}
+
+// waitReady polls /readyz until the background checks of the console pass
+func waitReady(timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		resp, err := http.Get(consoleURL + "/readyz")
+		if err == nil {
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil
+			}
+			err = fmt.Errorf("status %d: %s", resp.StatusCode, body)
+		}
+		if time.Now().After(deadline) {
+			return err
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// crdPaths returns the CRD directories of the Envoy Gateway and AI Gateway modules in go.mod.
+// The Envoy Gateway chart also ships the Gateway API CRDs, including BackendTLSPolicy.
+func crdPaths() ([]string, error) {
+	envoyGateway, err := moduleDir("github.com/envoyproxy/gateway")
+	if err != nil {
+		return nil, err
+	}
+	aiGateway, err := moduleDir("github.com/envoyproxy/ai-gateway")
+	if err != nil {
+		return nil, err
+	}
+	return []string{
+		filepath.Join(envoyGateway, "charts", "gateway-helm", "crds", "gatewayapi-crds.yaml"),
+		filepath.Join(envoyGateway, "charts", "gateway-helm", "crds", "generated"),
+		filepath.Join(aiGateway, "manifests", "charts", "ai-gateway-helm", "crds"),
+	}, nil
+}
+
+// moduleDir returns the directory of a module in the module cache
+func moduleDir(module string) (string, error) {
+	out, err := exec.Command("go", "list", "-m", "-f", "{{.Dir}}", module).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate module %s: %w", module, err)
+	}
+	dir := strings.TrimSpace(string(out))
+	if dir == "" {
+		return "", fmt.Errorf("module %s is not downloaded, run go mod download", module)
+	}
+	return dir, nil
+}
+
+// do sends an authenticated request to the console and decodes the JSON response into out
+func do(t *testing.T, method, path string, body any, header http.Header, out any) *http.Response {
+	t.Helper()
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("failed to marshal request: %v", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, consoleURL+path, reader)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s failed: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read response of %s %s: %v", method, path, err)
+	}
+	if out != nil && resp.StatusCode < 300 {
+		if err := json.Unmarshal(data, out); err != nil {
+			t.Fatalf("failed to decode response of %s %s: %v\n%s", method, path, err, data)
+		}
+	}
+	if resp.StatusCode >= 300 {
+		t.Logf("%s %s: %s %s", method, path, resp.Status, data)
+	}
+	return resp
+}
+
+func expectStatus(t *testing.T, resp *http.Response, status int) {
+	t.Helper()
+	if resp.StatusCode != status {
+		t.Fatalf("%s %s: expected status %d, got %s", resp.Request.Method, resp.Request.URL.Path, status, resp.Status)
+	}
+}
+
+func TestProviderFixtures(t *testing.T) {
+	requireCluster(t)
+
+	fixtures, err := filepath.Glob("../testdata/llm_provider/*.json")
+	if err != nil || len(fixtures) == 0 {
+		t.Fatalf("failed to find provider fixtures: %v", err)
+	}
+
+	for _, fixture := range fixtures {
+		t.Run(filepath.Base(fixture), func(t *testing.T) {
+			data, err := os.ReadFile(fixture)
+			if err != nil {
+				t.Fatalf("failed to read fixture: %v", err)
+			}
+			var provider llm.LLMProvider
+			if err := json.Unmarshal(data, &provider); err != nil {
+				t.Fatalf("failed to unmarshal fixture: %v", err)
+			}
+			query := "?namespace=" + url.QueryEscape(provider.Namespace)
+			path := "/api/v1/llm/providers/" + url.PathEscape(provider.Name) + query
+
+			var created llm.LLMProvider
+			expectStatus(t, do(t, http.MethodPost, "/api/v1/llm/providers", &provider, nil, &created), http.StatusCreated)
+			if created.Name != provider.Name {
+				t.Fatalf("expected created provider %s, got %s", provider.Name, created.Name)
+			}
+
+			var got llm.LLMProvider
+			resp := do(t, http.MethodGet, path, nil, nil, &got)
+			expectStatus(t, resp, http.StatusOK)
+			if got.Backend != provider.Backend {
+				t.Fatalf("expected backend %+v, got %+v", provider.Backend, got.Backend)
+			}
+			if got.TLS != provider.TLS {
+				t.Fatalf("expected TLS %+v, got %+v", provider.TLS, got.TLS)
+			}
+			etag := resp.Header.Get(server.HeaderETag)
+
+			var list service.ProviderList
+			expectStatus(t, do(t, http.MethodGet, "/api/v1/llm/providers"+query, nil, nil, &list), http.StatusOK)
+			found := false
+			for _, item := range list.Items {
+				found = found || item.Name == provider.Name
+			}
+			if !found {
+				t.Fatalf("provider %s is missing from the list", provider.Name)
+			}
+
+			header := http.Header{server.HeaderIfMatch: []string{etag}}
+			expectStatus(t, do(t, http.MethodDelete, path, nil, header, nil), http.StatusOK)
+			expectStatus(t, do(t, http.MethodGet, path, nil, nil, nil), http.StatusNotFound)
+		})
+	}
+}
+
+func TestReadinessAndDiagnostics(t *testing.T) {
+	requireCluster(t)
+
+	var health server.HealthResponse
+	expectStatus(t, do(t, http.MethodGet, "/readyz", nil, nil, &health), http.StatusOK)
+	for _, check := range health.Checks {
+		if !check.OK {
+			t.Fatalf("check %s failed: %s", check.Name, check.Message)
+		}
+	}
+
+	var diagnostics server.Diagnostics
+	expectStatus(t, do(t, http.MethodGet, "/api/v1/diagnostics", nil, nil, &diagnostics), http.StatusOK)
+	if !diagnostics.Ready {
+		t.Fatalf("expected the diagnostics to report ready: %+v", diagnostics.Checks)
+	}
+	for _, crd := range diagnostics.CRDs {
+		if crd.Required && len(crd.Versions) == 0 {
+			t.Fatalf("required CRD %s.%s is reported as missing", crd.Kind, crd.Group)
+		}
+	}
+	// envtest runs without controllers and its admin user may do anything
+	if len(diagnostics.MissingPermissions) > 0 {
+		t.Fatalf("unexpected missing permissions: %+v", diagnostics.MissingPermissions)
+	}
+	for _, controller := range diagnostics.Controllers {
+		if controller.Present {
+			t.Fatalf("unexpected controller %s", controller.Name)
+		}
+	}
+}
@@ -171,3 +171,49 @@ func TestNilMaskSecret(t *testing.T) {
 		t.Error("Expected nil when masking nil Azure auth")
 	}
 }
+
+func TestAuthConfigRestoreSecrets(t *testing.T) {
+	current := llm.AuthConfig{
+		Type:   "apiKey",
+		APIKey: "sk-current",
+		AWS:    &llm.AWSAuth{Region: "us-east-1", AccessKeyID: "AKIACURRENT", SecretAccessKey: "current-secret"},
+		GCP:    &llm.GCPAuth{ProjectID: "my-project", OIDCClientSecret: "current-client-secret", PrivateKey: "current-private-key"},
+		Azure:  &llm.AzureAuth{ClientID: "client-id-123", APIKey: "current-azure-key"},
+	}
+
+	// Masked values come back from the current configuration
+	restored := current.MaskSecret().RestoreSecrets(current)
+	if restored.APIKey != current.APIKey {
+		t.Errorf("Expected API key %s, got %s", current.APIKey, restored.APIKey)
+	}
+	if *restored.AWS != *current.AWS {
+		t.Errorf("Expected AWS auth %+v, got %+v", *current.AWS, *restored.AWS)
+	}
+	if *restored.GCP != *current.GCP {
+		t.Errorf("Expected GCP auth %+v, got %+v", *current.GCP, *restored.GCP)
+	}
+	if *restored.Azure != *current.Azure {
+		t.Errorf("Expected Azure auth %+v, got %+v", *current.Azure, *restored.Azure)
+	}
+	if restored.HasMaskedSecrets() {
+		t.Error("Expected no masked secrets after restoring")
+	}
+
+	// New values are kept
+	update := llm.AuthConfig{Type: "apiKey", APIKey: "sk-new"}
+	if restored := update.RestoreSecrets(current); restored.APIKey != "sk-new" {
+		t.Errorf("Expected API key sk-new, got %s", restored.APIKey)
+	}
+
+	// Masked values without a current value stay masked
+	update = llm.AuthConfig{Type: "AWSCredentials", AWS: &llm.AWSAuth{Region: "us-east-1", AccessKeyID: llm.MaskedSecretValue, SecretAccessKey: llm.MaskedSecretValue}}
+	restored = update.RestoreSecrets(llm.AuthConfig{Type: "apiKey", APIKey: "sk-current"})
+	if !restored.HasMaskedSecrets() {
+		t.Error("Expected masked secrets to remain without current credentials")
+	}
+
+	// The update is not modified
+	if update.AWS.AccessKeyID != llm.MaskedSecretValue {
+		t.Error("Original auth was modified during restoring")
+	}
+}
@@ -0,0 +1,120 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	aigatewayv1alpha1 "github.com/envoyproxy/ai-gateway/api/v1alpha1"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/llm"
+)
+
+// newModelRoute returns a route sending every model to azure and openai
+func newModelRoute(name string, models ...string) *aigatewayv1alpha1.AIGatewayRoute {
+	return newRoute(name, []string{"azure", "openai"}, models...)
+}
+
+func TestModelMappingsRoundTrip(t *testing.T) {
+	provider := newOpenAIProvider()
+	provider.Models = []llm.ModelMapping{{Name: "gpt-4o", Upstream: "gpt-4o-2024-08-06"}}
+
+	resources, err := provider.ToEnvoyGatewayResources()
+	if err != nil {
+		t.Fatalf("ToEnvoyGatewayResources failed: %v", err)
+	}
+	for _, resource := range resources {
+		if aisb, ok := resource.(*aigatewayv1alpha1.AIServiceBackend); ok {
+			if aisb.Annotations[llm.AnnotationModels] == "" {
+				t.Fatalf("expected a model annotation, got %v", aisb.Annotations)
+			}
+		}
+	}
+
+	restored, err := llm.ToLLMProvider(resources)
+	if err != nil {
+		t.Fatalf("ToLLMProvider failed: %v", err)
+	}
+	if !reflect.DeepEqual(restored.Models, provider.Models) {
+		t.Fatalf("expected models %+v, got %+v", provider.Models, restored.Models)
+	}
+}
+
+func TestModelMappingsValidate(t *testing.T) {
+	assertInvalid(t, newOpenAIProvider, translate, map[string]func(*llm.LLMProvider){
+		"empty name": func(p *llm.LLMProvider) { p.Models = []llm.ModelMapping{{Upstream: "gpt-4o"}} },
+		"negative price": func(p *llm.LLMProvider) {
+			p.Models = []llm.ModelMapping{{Name: "gpt-4o", Pricing: &llm.ModelPricing{Input: -1}}}
+		},
+		"duplicate model": func(p *llm.LLMProvider) {
+			p.Models = []llm.ModelMapping{{Name: "gpt-4o", Upstream: "a"}, {Name: "gpt-4o", Upstream: "b"}}
+		},
+	})
+}
+
+func TestApplyModelOverrides(t *testing.T) {
+	route := newModelRoute("chat", "gpt-4o", "gpt-4o-mini")
+	route.Spec.Rules[1].BackendRefs[0].ModelNameOverride = "manual"
+
+	models := []llm.ModelMapping{{Name: "gpt-4o", Upstream: "prod-gpt-4o"}}
+	if !llm.ApplyModelOverrides(route, "azure", models, nil) {
+		t.Fatalf("expected the route to change")
+	}
+	if got := route.Spec.Rules[0].BackendRefs[0].ModelNameOverride; got != "prod-gpt-4o" {
+		t.Fatalf("expected override prod-gpt-4o, got %q", got)
+	}
+	if got := route.Spec.Rules[0].BackendRefs[1].ModelNameOverride; got != "" {
+		t.Fatalf("expected other providers to keep no override, got %q", got)
+	}
+	if llm.ApplyModelOverrides(route, "azure", models, nil) {
+		t.Fatalf("expected reapplying the same mappings not to change the route")
+	}
+
+	// Removing the mapping clears the override it set but not the one set by hand
+	previous := append(models, llm.ModelMapping{Name: "gpt-4o-mini", Upstream: "other"})
+	llm.ApplyModelOverrides(route, "azure", nil, previous)
+	if got := route.Spec.Rules[0].BackendRefs[0].ModelNameOverride; got != "" {
+		t.Fatalf("expected the override to be cleared, got %q", got)
+	}
+	if got := route.Spec.Rules[1].BackendRefs[0].ModelNameOverride; got != "manual" {
+		t.Fatalf("expected the manual override to be kept, got %q", got)
+	}
+}
+
+func TestModelTable(t *testing.T) {
+	chat := newModelRoute("chat", "gpt-4o")
+	chat.Spec.Rules[0].BackendRefs[0].ModelNameOverride = "prod-gpt-4o"
+	chat.Spec.Rules[0].BackendRefs[1].ModelNameOverride = "gpt-4o-2024-08-06"
+	providers := map[string][]llm.ModelMapping{
+		"azure": {{Name: "gpt-4o", Upstream: "prod-gpt-4o"}, {Name: "o3", Upstream: "prod-o3"}},
+	}
+
+	rows := llm.ModelTable("default", providers, []aigatewayv1alpha1.AIGatewayRoute{*chat, *newModelRoute("batch", "gpt-4o")})
+	want := []llm.ModelRoute{
+		{Namespace: "default", Model: "gpt-4o", Provider: "azure", Upstream: "gpt-4o", Routes: []string{"batch"}},
+		{Namespace: "default", Model: "gpt-4o", Provider: "azure", Upstream: "prod-gpt-4o", Source: llm.ModelSourceProvider, Routes: []string{"chat"}},
+		{Namespace: "default", Model: "gpt-4o", Provider: "openai", Upstream: "gpt-4o", Routes: []string{"batch"}},
+		{Namespace: "default", Model: "gpt-4o", Provider: "openai", Upstream: "gpt-4o-2024-08-06", Source: llm.ModelSourceRoute, Routes: []string{"chat"}},
+		{Namespace: "default", Model: "o3", Provider: "azure", Upstream: "prod-o3", Source: llm.ModelSourceProvider},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Fatalf("unexpected model table:\n got %+v\nwant %+v", rows, want)
+	}
+}
+
+func TestModelPrice(t *testing.T) {
+	models := []llm.ModelMapping{
+		{Name: "gpt-4o", Upstream: "prod-gpt-4o", Pricing: &llm.ModelPricing{Input: 2.5, Output: 10}},
+		{Name: "o3"},
+	}
+	if price := llm.ModelPrice(models, "prod-gpt-4o"); price == nil || price.Cost(1e6, 5e5) != 7.5 {
+		t.Fatalf("expected the price of gpt-4o by its upstream name, got %+v", price)
+	}
+	if price := llm.ModelPrice(models, "o3"); price != nil {
+		t.Fatalf("expected no price for o3, got %+v", price)
+	}
+
+	// Catalog entries without an upstream name do not override the model
+	route := newModelRoute("chat", "o3")
+	if llm.ApplyModelOverrides(route, "azure", models, nil) {
+		t.Fatalf("expected no override for a model that is not renamed")
+	}
+}
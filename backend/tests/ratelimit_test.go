@@ -0,0 +1,109 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	aigatewayv1alpha1 "github.com/envoyproxy/ai-gateway/api/v1alpha1"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/llm"
+	gatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+func newRateLimitPolicy() *llm.RateLimitPolicy {
+	return &llm.RateLimitPolicy{
+		Name:      "team-budget",
+		Namespace: "default",
+		Route:     "chat",
+		Rules: []llm.RateLimitRule{
+			{TokenType: llm.TokenTypeTotal, Limit: 10000, Unit: "Minute", UserHeader: "x-user-id"},
+			{TokenType: llm.TokenTypeInput, Limit: 500000, Unit: "Day", UserHeader: "x-user-id", Model: "gpt-4o"},
+			{TokenType: llm.TokenTypeOutput, Limit: 200000, Unit: "Day", Provider: "openai"},
+		},
+	}
+}
+
+func TestRateLimitPolicyRoundTrip(t *testing.T) {
+	policy := newRateLimitPolicy()
+
+	btp, err := policy.ToBackendTrafficPolicy()
+	if err != nil {
+		t.Fatalf("ToBackendTrafficPolicy failed: %v", err)
+	}
+
+	assertGolden(t, "testdata/ratelimit/team_budget.json", btp)
+
+	restored, err := llm.ToRateLimitPolicy(btp)
+	if err != nil {
+		t.Fatalf("ToRateLimitPolicy failed: %v", err)
+	}
+	if !reflect.DeepEqual(restored, policy) {
+		t.Fatalf("round trip mismatch:\n got %+v\nwant %+v", restored, policy)
+	}
+}
+
+func TestRateLimitPolicyRequestCosts(t *testing.T) {
+	costs := newRateLimitPolicy().RequestCosts()
+
+	keys := make([]string, 0, len(costs))
+	for _, cost := range costs {
+		keys = append(keys, cost.MetadataKey)
+	}
+	if want := []string{"llm_input_token", "llm_output_token.openai", "llm_total_token"}; !reflect.DeepEqual(keys, want) {
+		t.Fatalf("unexpected request cost keys %v, want %v", keys, want)
+	}
+	if costs[1].Type != aigatewayv1alpha1.LLMRequestCostTypeCEL || *costs[1].CEL != "backend == 'openai.default' ? output_tokens : 0" {
+		t.Fatalf("unexpected provider scoped cost %+v", costs[1])
+	}
+
+	merged, changed, err := llm.MergeRequestCosts(costs[:1], costs)
+	if err != nil || !changed || len(merged) != 3 {
+		t.Fatalf("expected the missing costs to be added, got %v %v %v", merged, changed, err)
+	}
+	if _, changed, _ := llm.MergeRequestCosts(merged, costs); changed {
+		t.Fatal("expected no change when every cost exists")
+	}
+
+	conflicting := []aigatewayv1alpha1.LLMRequestCost{{MetadataKey: "llm_input_token", Type: aigatewayv1alpha1.LLMRequestCostTypeOutputToken}}
+	if _, _, err := llm.MergeRequestCosts(conflicting, costs); err == nil {
+		t.Fatal("expected a conflicting cost definition to fail")
+	}
+}
+
+func TestRateLimitPolicyValidate(t *testing.T) {
+	assertInvalid(t, newRateLimitPolicy, (*llm.RateLimitPolicy).Validate, map[string]func(*llm.RateLimitPolicy){
+		"missing route":      func(p *llm.RateLimitPolicy) { p.Route = "" },
+		"no rules":           func(p *llm.RateLimitPolicy) { p.Rules = nil },
+		"unknown token type": func(p *llm.RateLimitPolicy) { p.Rules[0].TokenType = "cached" },
+		"zero limit":         func(p *llm.RateLimitPolicy) { p.Rules[0].Limit = 0 },
+		"unknown unit":       func(p *llm.RateLimitPolicy) { p.Rules[0].Unit = "Week" },
+	})
+}
+
+func TestRateLimitPolicyStatus(t *testing.T) {
+	btp, err := newRateLimitPolicy().ToBackendTrafficPolicy()
+	if err != nil {
+		t.Fatalf("ToBackendTrafficPolicy failed: %v", err)
+	}
+
+	btp.Status.Ancestors = []gwapiv1a2.PolicyAncestorStatus{{
+		Conditions: []metav1.Condition{{
+			Type:    string(gwapiv1a2.PolicyConditionAccepted),
+			Status:  metav1.ConditionFalse,
+			Message: "rate limit service is not enabled",
+		}},
+	}}
+	policy, err := llm.ToRateLimitPolicy(btp)
+	if err != nil {
+		t.Fatalf("ToRateLimitPolicy failed: %v", err)
+	}
+	if policy.Status != llm.RateLimitStatusNotAccepted || policy.Message != "rate limit service is not enabled" {
+		t.Fatalf("unexpected status %q: %q", policy.Status, policy.Message)
+	}
+
+	unmanaged := &gatewayv1alpha1.BackendTrafficPolicy{}
+	if _, err := llm.ToRateLimitPolicy(unmanaged); err == nil {
+		t.Fatal("expected a BackendTrafficPolicy without the rate limit label to be rejected")
+	}
+}
@@ -0,0 +1,226 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	aigatewayv1alpha1 "github.com/envoyproxy/ai-gateway/api/v1alpha1"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/llm"
+	gatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	gwapiv1a3 "sigs.k8s.io/gateway-api/apis/v1alpha3"
+)
+
+var authTypes = []string{llm.AuthTypeAPIKey, llm.AuthTypeAWS, llm.AuthTypeAzure, llm.AuthTypeGCP}
+
+// randomProvider generates a valid provider with the auth type. Optional settings are
+// left out at random, and values use the canonical form ToLLMProvider reads back.
+func randomProvider(r *rand.Rand, authType string) *llm.LLMProvider {
+	word := func(prefix string) string { return fmt.Sprintf("%s-%d", prefix, r.Intn(1000)) }
+	maybe := func(value string) string {
+		if r.Intn(2) == 0 {
+			return ""
+		}
+		return value
+	}
+	int64Ptr := func() *int64 {
+		if r.Intn(2) == 0 {
+			return nil
+		}
+		v := int64(r.Intn(1000) + 1)
+		return &v
+	}
+
+	host := word("api") + ".example.com"
+	provider := &llm.LLMProvider{
+		Name:      word("provider"),
+		Namespace: word("team"),
+		Schema:    []string{"OpenAI", "AWSBedrock", "AzureOpenAI", "GCPVertexAI"}[r.Intn(4)],
+		Version:   maybe([]string{"v1", "2025-01-01-preview"}[r.Intn(2)]),
+		Backend:   llm.Backend{Host: host, Port: int32(r.Intn(65535) + 1)},
+	}
+	if r.Intn(3) > 0 {
+		provider.TLS = llm.TLSValidation{Hostname: host, WellKnownCACertificates: maybe("System")}
+	}
+
+	switch authType {
+	case llm.AuthTypeAPIKey:
+		provider.Auth = llm.AuthConfig{Type: authType, APIKey: word("sk")}
+	case llm.AuthTypeAWS:
+		provider.Auth = llm.AuthConfig{Type: authType, AWS: &llm.AWSAuth{
+			Region:          word("region"),
+			AccessKeyID:     word("AKIA"),
+			SecretAccessKey: word("secret"),
+		}}
+	case llm.AuthTypeAzure:
+		provider.Auth = llm.AuthConfig{Type: authType, Azure: &llm.AzureAuth{
+			ClientID: word("client"),
+			TenantID: word("tenant"),
+			APIKey:   word("key"),
+		}}
+	case llm.AuthTypeGCP:
+		provider.Auth = llm.AuthConfig{Type: authType, GCP: &llm.GCPAuth{
+			ProjectID:                    word("project"),
+			Location:                     word("region"),
+			WorkloadIdentityPoolName:     word("pool"),
+			WorkloadIdentityProviderName: word("provider"),
+			ServiceAccountName:           word("sa"),
+			OIDCIssuer:                   "https://" + word("issuer") + ".example.com",
+			OIDCClientID:                 word("client"),
+			OIDCClientSecret:             word("secret"),
+		}}
+	}
+
+	if r.Intn(2) == 0 {
+		traffic := &llm.TrafficPolicy{RequestTimeout: maybe("60s"), ConnectTimeout: maybe("10s")}
+		if r.Intn(2) == 0 {
+			// Status codes are explicit, none means DefaultRetryStatusCodes
+			traffic.Retry = &llm.RetryPolicy{
+				NumRetries:    int32(r.Intn(5)),
+				StatusCodes:   []int{429, 500 + r.Intn(5)},
+				PerTryTimeout: maybe("30s"),
+			}
+			if r.Intn(2) == 0 {
+				traffic.Retry.BaseInterval, traffic.Retry.MaxInterval = "100ms", maybe("1s")
+			}
+		}
+		if cb := (&llm.CircuitBreakerPolicy{MaxPendingRequests: int64Ptr(), MaxParallelRequests: int64Ptr(), MaxParallelRetries: int64Ptr()}); *cb != (llm.CircuitBreakerPolicy{}) {
+			traffic.CircuitBreaker = cb
+		}
+		if limits := (&llm.ConnectionLimits{MaxConnections: int64Ptr(), MaxRequestsPerConnection: int64Ptr(), BufferLimit: maybe("32Ki")}); *limits != (llm.ConnectionLimits{}) {
+			traffic.Connection = limits
+		}
+		provider.Traffic = traffic
+	}
+
+	for i := r.Intn(3); i > 0; i-- {
+		model := llm.ModelMapping{Name: fmt.Sprintf("model-%d", i), Upstream: maybe(word("upstream"))}
+		if r.Intn(2) == 0 {
+			model.Pricing = &llm.ModelPricing{Input: float64(r.Intn(100)) / 4, Output: float64(r.Intn(100)) / 4}
+		}
+		provider.Models = append(provider.Models, model)
+	}
+	return provider
+}
+
+// storeResources emulates storing the resources in Kubernetes: every resource goes
+// through JSON and secrets only keep their data
+func storeResources(t *testing.T, resources []any) []any {
+	t.Helper()
+	stored := make([]any, 0, len(resources))
+	for _, resource := range resources {
+		data, err := json.Marshal(resource)
+		if err != nil {
+			t.Fatalf("failed to marshal %T: %v", resource, err)
+		}
+		var out any
+		switch resource.(type) {
+		case *gatewayv1alpha1.Backend:
+			out = &gatewayv1alpha1.Backend{}
+		case *gwapiv1a3.BackendTLSPolicy:
+			out = &gwapiv1a3.BackendTLSPolicy{}
+		case *gatewayv1alpha1.BackendTrafficPolicy:
+			out = &gatewayv1alpha1.BackendTrafficPolicy{}
+		case *aigatewayv1alpha1.BackendSecurityPolicy:
+			out = &aigatewayv1alpha1.BackendSecurityPolicy{}
+		case *aigatewayv1alpha1.AIServiceBackend:
+			out = &aigatewayv1alpha1.AIServiceBackend{}
+		case *corev1.Secret:
+			out = &corev1.Secret{}
+		default:
+			t.Fatalf("unexpected resource type %T", resource)
+		}
+		if err := json.Unmarshal(data, out); err != nil {
+			t.Fatalf("failed to unmarshal %T: %v", resource, err)
+		}
+		if secret, ok := out.(*corev1.Secret); ok {
+			secret.Data = map[string][]byte{}
+			for key, value := range secret.StringData {
+				secret.Data[key] = []byte(value)
+			}
+			secret.StringData = nil
+		}
+		stored = append(stored, out)
+	}
+	return stored
+}
+
+// checkRoundTrip translates the provider to resources and back and fails if anything is lost
+func checkRoundTrip(t *testing.T, provider *llm.LLMProvider) {
+	t.Helper()
+	resources, err := provider.ToEnvoyGatewayResources()
+	if err != nil {
+		t.Fatalf("ToEnvoyGatewayResources failed: %v", err)
+	}
+
+	for _, stored := range [][]any{resources, storeResources(t, resources)} {
+		actual, err := llm.ToLLMProvider(stored)
+		if err != nil {
+			t.Fatalf("ToLLMProvider failed: %v", err)
+		}
+		if !reflect.DeepEqual(provider, actual) {
+			expectedJSON, _ := json.MarshalIndent(provider, "", "  ")
+			actualJSON, _ := json.MarshalIndent(actual, "", "  ")
+			t.Fatalf("provider changed in the round trip\nExpected:\n%s\nActual:\n%s", expectedJSON, actualJSON)
+		}
+	}
+}
+
+func TestLLMProviderRoundTrip(t *testing.T) {
+	for _, authType := range authTypes {
+		t.Run(authType, func(t *testing.T) {
+			for seed := int64(0); seed < 200; seed++ {
+				provider := randomProvider(rand.New(rand.NewSource(seed)), authType)
+				t.Run(fmt.Sprint(seed), func(t *testing.T) { checkRoundTrip(t, provider) })
+			}
+		})
+	}
+}
+
+func TestLLMProviderRoundTripLegacyGCPFields(t *testing.T) {
+	// Legacy GCP fields are read back in their current form
+	provider := randomProvider(rand.New(rand.NewSource(1)), llm.AuthTypeGCP)
+	legacy := *provider
+	gcp := *provider.Auth.GCP
+	gcp.PrivateKey, gcp.OIDCClientSecret = gcp.OIDCClientSecret, ""
+	gcp.ClientEmail, gcp.WorkloadIdentityPoolName = gcp.WorkloadIdentityPoolName, ""
+	legacy.Auth.GCP = &gcp
+
+	resources, err := legacy.ToEnvoyGatewayResources()
+	if err != nil {
+		t.Fatalf("ToEnvoyGatewayResources failed: %v", err)
+	}
+	actual, err := llm.ToLLMProvider(storeResources(t, resources))
+	if err != nil {
+		t.Fatalf("ToLLMProvider failed: %v", err)
+	}
+	if !reflect.DeepEqual(provider, actual) {
+		t.Fatalf("expected %+v, got %+v", provider.Auth.GCP, actual.Auth.GCP)
+	}
+}
+
+func TestLLMProviderWithoutTLSHasNoPolicy(t *testing.T) {
+	provider := randomProvider(rand.New(rand.NewSource(1)), llm.AuthTypeAPIKey)
+	provider.TLS = llm.TLSValidation{}
+	resources, err := provider.ToEnvoyGatewayResources()
+	if err != nil {
+		t.Fatalf("ToEnvoyGatewayResources failed: %v", err)
+	}
+	for _, resource := range resources {
+		if _, ok := resource.(*gwapiv1a3.BackendTLSPolicy); ok {
+			t.Fatalf("expected no BackendTLSPolicy without a TLS hostname")
+		}
+	}
+}
+
+func FuzzLLMProviderRoundTrip(f *testing.F) {
+	for seed := int64(0); seed < 8; seed++ {
+		f.Add(seed, uint8(seed))
+	}
+	f.Fuzz(func(t *testing.T, seed int64, authType uint8) {
+		checkRoundTrip(t, randomProvider(rand.New(rand.NewSource(seed)), authTypes[int(authType)%len(authTypes)]))
+	})
+}
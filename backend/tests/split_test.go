@@ -0,0 +1,82 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	aigatewayv1alpha1 "github.com/envoyproxy/ai-gateway/api/v1alpha1"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/llm"
+)
+
+// newSplitRoute returns a route sending 90% of the gpt-4o traffic to openai
+func newSplitRoute() *aigatewayv1alpha1.AIGatewayRoute {
+	route := newRoute("chat", []string{"openai", "azure"}, "gpt-4o")
+	openai := &route.Spec.Rules[0].BackendRefs[0]
+	openai.Weight, openai.ModelNameOverride = ptr[int32](90), "gpt-4o-2024-08-06"
+	return route
+}
+
+func TestToTrafficSplit(t *testing.T) {
+	split, err := llm.ToTrafficSplit(newSplitRoute(), 0)
+	if err != nil {
+		t.Fatalf("ToTrafficSplit failed: %v", err)
+	}
+	want := []llm.WeightedBackend{{Provider: "openai", Weight: 90}, {Provider: "azure", Weight: 1}}
+	if !reflect.DeepEqual(split.Backends, want) {
+		t.Fatalf("expected backends %+v, got %+v", want, split.Backends)
+	}
+	if !reflect.DeepEqual(split.Models, []string{"gpt-4o"}) {
+		t.Fatalf("expected models [gpt-4o], got %v", split.Models)
+	}
+	if _, err := llm.ToTrafficSplit(newSplitRoute(), 1); err == nil {
+		t.Fatalf("expected an error for a missing rule")
+	}
+}
+
+func TestTrafficSplitApplyTo(t *testing.T) {
+	route := newSplitRoute()
+	split := &llm.TrafficSplit{Backends: []llm.WeightedBackend{{Provider: "openai", Weight: 20}, {Provider: "bedrock", Weight: 80}}}
+	if err := split.ApplyTo(route); err != nil {
+		t.Fatalf("ApplyTo failed: %v", err)
+	}
+
+	// openai keeps its model name override, bedrock replaces azure
+	assertGolden(t, "testdata/split/chat.json", route)
+}
+
+func TestTrafficSplitShift(t *testing.T) {
+	split := &llm.TrafficSplit{Backends: []llm.WeightedBackend{{Provider: "openai", Weight: 100}, {Provider: "fallback", Weight: 5}}}
+
+	shifted := split.Shift("openai", "azure", 25)
+	want := []llm.WeightedBackend{{Provider: "openai", Weight: 75}, {Provider: "fallback", Weight: 5}, {Provider: "azure", Weight: 25}}
+	if !reflect.DeepEqual(shifted.Backends, want) {
+		t.Fatalf("expected backends %+v, got %+v", want, shifted.Backends)
+	}
+	if split.Backends[0].Weight != 100 || len(split.Backends) != 2 {
+		t.Fatalf("Shift modified the original split: %+v", split.Backends)
+	}
+}
+
+func TestTrafficSplitValidate(t *testing.T) {
+	newSplit := func() *llm.TrafficSplit {
+		return &llm.TrafficSplit{Backends: []llm.WeightedBackend{{Provider: "openai", Weight: 1}}}
+	}
+	assertInvalid(t, newSplit, (*llm.TrafficSplit).Validate, map[string]func(*llm.TrafficSplit){
+		"empty":     func(s *llm.TrafficSplit) { s.Backends = nil },
+		"duplicate": func(s *llm.TrafficSplit) { s.Backends = append(s.Backends, s.Backends[0]) },
+		"negative":  func(s *llm.TrafficSplit) { s.Backends[0].Weight = -1 },
+		"zero": func(s *llm.TrafficSplit) {
+			s.Backends = []llm.WeightedBackend{{Provider: "openai"}, {Provider: "azure"}}
+		},
+		"unnamed": func(s *llm.TrafficSplit) { s.Backends[0].Provider = "" },
+	})
+}
+
+func TestCompatibleSchema(t *testing.T) {
+	if !llm.CompatibleSchema(aigatewayv1alpha1.APISchemaOpenAI, aigatewayv1alpha1.APISchemaAWSBedrock) {
+		t.Fatalf("expected OpenAI routes to reach AWSBedrock backends")
+	}
+	if llm.CompatibleSchema(aigatewayv1alpha1.APISchemaAWSBedrock, aigatewayv1alpha1.APISchemaOpenAI) {
+		t.Fatalf("expected AWSBedrock routes not to reach OpenAI backends")
+	}
+}
@@ -0,0 +1,102 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/llm"
+	gatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+)
+
+// translateTraffic returns the resources of the OpenAI provider with the traffic settings
+func translateTraffic(traffic *llm.TrafficPolicy) ([]interface{}, error) {
+	provider := newOpenAIProvider()
+	provider.Traffic = traffic
+	return provider.ToEnvoyGatewayResources()
+}
+
+func TestTrafficPolicyRoundTrip(t *testing.T) {
+	traffic := &llm.TrafficPolicy{
+		RequestTimeout: "120s",
+		ConnectTimeout: "5s",
+		Retry: &llm.RetryPolicy{
+			NumRetries:    3,
+			StatusCodes:   []int{429, 503},
+			PerTryTimeout: "30s",
+			BaseInterval:  "250ms",
+			MaxInterval:   "10s",
+		},
+		CircuitBreaker: &llm.CircuitBreakerPolicy{
+			MaxPendingRequests:  ptr[int64](64),
+			MaxParallelRequests: ptr[int64](128),
+		},
+		Connection: &llm.ConnectionLimits{
+			MaxConnections:           ptr[int64](32),
+			MaxRequestsPerConnection: ptr[int64](1000),
+			BufferLimit:              "64Ki",
+		},
+	}
+	resources, err := translateTraffic(traffic)
+	if err != nil {
+		t.Fatalf("ToEnvoyGatewayResources failed: %v", err)
+	}
+
+	var btp *gatewayv1alpha1.BackendTrafficPolicy
+	for _, res := range resources {
+		if r, ok := res.(*gatewayv1alpha1.BackendTrafficPolicy); ok {
+			btp = r
+		}
+	}
+	if btp == nil {
+		t.Fatal("expected a BackendTrafficPolicy")
+	}
+	assertGolden(t, "testdata/traffic/openai.json", btp)
+
+	restored, err := llm.ToLLMProvider(resources)
+	if err != nil {
+		t.Fatalf("ToLLMProvider failed: %v", err)
+	}
+	if !reflect.DeepEqual(restored.Traffic, traffic) {
+		t.Fatalf("traffic round trip mismatch:\n got %+v\nwant %+v", restored.Traffic, traffic)
+	}
+}
+
+func TestTrafficPolicyDefaults(t *testing.T) {
+	resources, err := translateTraffic(&llm.TrafficPolicy{Retry: &llm.RetryPolicy{NumRetries: 2}})
+	if err != nil {
+		t.Fatalf("ToEnvoyGatewayResources failed: %v", err)
+	}
+	restored, err := llm.ToLLMProvider(resources)
+	if err != nil {
+		t.Fatalf("ToLLMProvider failed: %v", err)
+	}
+	if got := restored.Traffic.Retry.StatusCodes; !reflect.DeepEqual(got, llm.DefaultRetryStatusCodes) {
+		t.Fatalf("expected the default retry status codes, got %v", got)
+	}
+
+	resources, err = translateTraffic(nil)
+	if err != nil {
+		t.Fatalf("ToEnvoyGatewayResources failed: %v", err)
+	}
+	for _, res := range resources {
+		if _, ok := res.(*gatewayv1alpha1.BackendTrafficPolicy); ok {
+			t.Fatal("expected no BackendTrafficPolicy without traffic settings")
+		}
+	}
+}
+
+func TestTrafficPolicyValidate(t *testing.T) {
+	assertInvalid(t, newOpenAIProvider, translate, map[string]func(*llm.LLMProvider){
+		"invalid timeout":  func(p *llm.LLMProvider) { p.Traffic = &llm.TrafficPolicy{RequestTimeout: "2 minutes"} },
+		"negative retries": func(p *llm.LLMProvider) { p.Traffic = &llm.TrafficPolicy{Retry: &llm.RetryPolicy{NumRetries: -1}} },
+		"invalid status code": func(p *llm.LLMProvider) {
+			p.Traffic = &llm.TrafficPolicy{Retry: &llm.RetryPolicy{NumRetries: 1, StatusCodes: []int{42}}}
+		},
+		"negative threshold": func(p *llm.LLMProvider) {
+			p.Traffic = &llm.TrafficPolicy{CircuitBreaker: &llm.CircuitBreakerPolicy{MaxPendingRequests: ptr[int64](-1)}}
+		},
+		"invalid buffer limit": func(p *llm.LLMProvider) {
+			p.Traffic = &llm.TrafficPolicy{Connection: &llm.ConnectionLimits{BufferLimit: "lots"}}
+		},
+	})
+}
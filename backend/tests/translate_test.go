@@ -22,7 +22,9 @@ func TestLLMProviderToEnvoyGatewayResources(t *testing.T) {
 		{Name: "OpenAI", Input: "testdata/llm_provider/openai.json", GoldenFile: "testdata/gateway/openai.json"},
 		{Name: "AWS", Input: "testdata/llm_provider/aws.json", GoldenFile: "testdata/gateway/aws.json"},
 		{Name: "Azure", Input: "testdata/llm_provider/azure_openai.json", GoldenFile: "testdata/gateway/azure_openai.json"},
-		{Name: "GCP", Input: "testdata/llm_provider/gcp_vertex.json", GoldenFile: "testdata/gateway/gcp_vertex.json"},
+		{Name: "GCP", Input: "testdata/llm_provider/gcp_vertex_oidc.json", GoldenFile: "testdata/gateway/gcp_vertex.json"},
+		// The legacy privateKey is only a fallback of oidcClientSecret and changes nothing here
+		{Name: "GCPLegacy", Input: "testdata/llm_provider/gcp_vertex.json", GoldenFile: "testdata/gateway/gcp_vertex.json"},
 	}
 
 	for _, tc := range testCases {
@@ -63,67 +65,7 @@ func TestLLMProviderToEnvoyGatewayResources(t *testing.T) {
 				// We shouldn't get errors for any provider now that GCP is fully implemented
 				t.Fatalf("ToEnvoyGatewayResources failed: %v", err)
 			}
-			// Marshal resources to JSON array
-			marshaled, err := json.MarshalIndent(resources, "", "  ")
-			if err != nil {
-				t.Fatalf("failed to marshal resources to json: %v", err)
-			}
-			// Load golden JSON
-			goldenPath, err := filepath.Abs(tc.GoldenFile)
-			if err != nil {
-				t.Fatalf("failed to get abs path: %v", err)
-			}
-			goldenData, err := os.ReadFile(goldenPath)
-			if err != nil {
-				t.Fatalf("failed to read golden json: %v", err)
-			}
-			// Normalize JSON by removing null fields
-			var actualObj interface{}
-			if err := json.Unmarshal(marshaled, &actualObj); err != nil {
-				t.Fatalf("failed to unmarshal actual json: %v", err)
-			}
-			var expectedObj interface{}
-			if err := json.Unmarshal(goldenData, &expectedObj); err != nil {
-				t.Fatalf("failed to unmarshal golden json: %v", err)
-			}
-			var removeNulls func(interface{}) interface{}
-			removeNulls = func(v interface{}) interface{} {
-				switch vv := v.(type) {
-				case map[string]interface{}:
-					m := map[string]interface{}{}
-					for k, val := range vv {
-						cleaned := removeNulls(val)
-						if cleaned != nil {
-							m[k] = cleaned
-						}
-					}
-					if len(m) == 0 {
-						return nil
-					}
-					return m
-				case []interface{}:
-					arr := make([]interface{}, 0, len(vv))
-					for _, item := range vv {
-						cleaned := removeNulls(item)
-						if cleaned != nil {
-							arr = append(arr, cleaned)
-						}
-					}
-					return arr
-				default:
-					if vv == nil {
-						return nil
-					}
-					return vv
-				}
-			}
-			actualClean := removeNulls(actualObj)
-			expectedClean := removeNulls(expectedObj)
-			actualNorm, _ := json.MarshalIndent(actualClean, "", "  ")
-			expectedNorm, _ := json.MarshalIndent(expectedClean, "", "  ")
-			if string(actualNorm) != string(expectedNorm) {
-				t.Errorf("JSON output does not match golden file for %s\nExpected:\n%s\nActual:\n%s", tc.Name, string(expectedNorm), string(actualNorm))
-			}
+			assertGolden(t, tc.GoldenFile, resources)
 		})
 	}
 }
@@ -138,7 +80,8 @@ func TestEnvoyGatewayResourcesToLLMProvider(t *testing.T) {
 		{Name: "OpenAI", InputFile: "testdata/gateway/openai.json", GoldenFile: "testdata/llm_provider/openai.json"},
 		{Name: "AWS", InputFile: "testdata/gateway/aws.json", GoldenFile: "testdata/llm_provider/aws.json"},
 		{Name: "Azure", InputFile: "testdata/gateway/azure_openai.json", GoldenFile: "testdata/llm_provider/azure_openai.json"},
-		{Name: "GCP", InputFile: "testdata/gateway/gcp_vertex.json", GoldenFile: "testdata/llm_provider/gcp_vertex.json"},
+		// The client secret is read back as oidcClientSecret only, whatever the provider was created with
+		{Name: "GCP", InputFile: "testdata/gateway/gcp_vertex.json", GoldenFile: "testdata/llm_provider/gcp_vertex_oidc.json"},
 	}
 
 	for _, tc := range testCases {
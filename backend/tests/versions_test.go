@@ -0,0 +1,93 @@
+package tests
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	aigatewayv1alpha1 "github.com/envoyproxy/ai-gateway/api/v1alpha1"
+	"github.com/envoyproxy/ai-gateway/console/backend/pkg/llm"
+	gwapiv1a3 "sigs.k8s.io/gateway-api/apis/v1alpha3"
+)
+
+func TestLLMProviderToBackendTLSPolicyV1(t *testing.T) {
+	provider := randomProvider(rand.New(rand.NewSource(1)), llm.AuthTypeAPIKey)
+	provider.TLS = llm.TLSValidation{Hostname: "api.openai.com", WellKnownCACertificates: "System"}
+
+	versions := llm.APIVersions{BackendTLSPolicy: llm.VersionV1, AIGateway: llm.VersionV1Alpha1}
+	resources, err := provider.ToEnvoyGatewayResourcesFor(versions)
+	if err != nil {
+		t.Fatalf("ToEnvoyGatewayResourcesFor failed: %v", err)
+	}
+
+	var policies int
+	for _, resource := range resources {
+		switch r := resource.(type) {
+		case *gwapiv1a3.BackendTLSPolicy:
+			policies++
+			if r.APIVersion != llm.APIVersionGatewayAPIV1 {
+				t.Fatalf("expected BackendTLSPolicy %s, got %s", llm.APIVersionGatewayAPIV1, r.APIVersion)
+			}
+		case *aigatewayv1alpha1.AIServiceBackend:
+			if r.APIVersion != llm.APIVersionAIGatewayV1Alpha1 {
+				t.Fatalf("expected AIServiceBackend %s, got %s", llm.APIVersionAIGatewayV1Alpha1, r.APIVersion)
+			}
+		}
+	}
+	if policies != 1 {
+		t.Fatalf("expected one BackendTLSPolicy, got %d", policies)
+	}
+
+	actual, err := llm.ToLLMProvider(storeResources(t, resources))
+	if err != nil {
+		t.Fatalf("ToLLMProvider failed: %v", err)
+	}
+	if !reflect.DeepEqual(provider, actual) {
+		t.Fatalf("expected %+v, got %+v", provider, actual)
+	}
+}
+
+func TestLLMProviderUnsupportedAPIVersions(t *testing.T) {
+	provider := randomProvider(rand.New(rand.NewSource(1)), llm.AuthTypeAPIKey)
+	for _, versions := range []llm.APIVersions{
+		{BackendTLSPolicy: "v1alpha2", AIGateway: llm.VersionV1Alpha1},
+		{BackendTLSPolicy: llm.VersionV1, AIGateway: "v1beta1"},
+		{},
+	} {
+		if _, err := provider.ToEnvoyGatewayResourcesFor(versions); err == nil {
+			t.Fatalf("expected an error for %+v", versions)
+		}
+	}
+}
+
+func TestSelectAPIVersions(t *testing.T) {
+	testCases := []struct {
+		name             string
+		backendTLSPolicy []string
+		aiGateway        []string
+		expected         llm.APIVersions
+		expectErr        bool
+	}{
+		{name: "v1 served", backendTLSPolicy: []string{"v1alpha3", "v1"}, aiGateway: []string{"v1alpha1"}, expected: llm.APIVersions{BackendTLSPolicy: "v1", AIGateway: "v1alpha1"}},
+		{name: "v1alpha3 only", backendTLSPolicy: []string{"v1alpha3"}, aiGateway: []string{"v1alpha1"}, expected: llm.APIVersions{BackendTLSPolicy: "v1alpha3", AIGateway: "v1alpha1"}},
+		{name: "nothing served", expected: llm.DefaultAPIVersions},
+		{name: "unsupported", backendTLSPolicy: []string{"v1alpha2"}, aiGateway: []string{"v1alpha1"}, expectErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := llm.SelectAPIVersions(tc.backendTLSPolicy, tc.aiGateway)
+			if tc.expectErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %+v", actual)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SelectAPIVersions failed: %v", err)
+			}
+			if actual != tc.expected {
+				t.Fatalf("expected %+v, got %+v", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 "use client"
 
-import { IconTrendingDown, IconTrendingUp } from "@tabler/icons-react"
+import { useEffect, useState } from "react"
+import { IconCoin, IconTrendingDown, IconTrendingUp } from "@tabler/icons-react"
 
 import { Badge } from "@/components/ui/badge"
 import {
@@ -11,8 +12,26 @@ import {
   CardHeader,
   CardTitle,
 } from "@/components/ui/card"
+import { UsageService } from "@/services/usage.service"
+import type { UsageReport } from "@/types/usage"
+
+const currencyFormat = new Intl.NumberFormat(undefined, { style: "currency", currency: "USD" })
+const tokenFormat = new Intl.NumberFormat(undefined, { notation: "compact" })
 
 export function SectionCards() {
+  // Spend of the last 24 hours in the namespaces the user can view
+  const [usage, setUsage] = useState<UsageReport | null>(null)
+  const [usageError, setUsageError] = useState<string | null>(null)
+
+  useEffect(() => {
+    UsageService.getUsage({ namespace: "*" })
+      .then(setUsage)
+      .catch(err => setUsageError(err instanceof Error ? err.message : "Usage is not available"))
+  }, [])
+
+  const totalTokens = usage?.items.reduce((sum, item) => sum + item.totalTokens, 0) ?? 0
+  const unpricedModels = new Set(usage?.items.filter(item => item.cost === undefined).map(item => item.model))
+
   return (
     <div className="*:data-[slot=card]:from-primary/5 *:data-[slot=card]:to-card dark:*:data-[slot=card]:bg-card grid grid-cols-1 gap-4 px-4 *:data-[slot=card]:bg-gradient-to-t *:data-[slot=card]:shadow-xs lg:px-6 @xl/main:grid-cols-2 @5xl/main:grid-cols-4">
       <Card className="@container/card">
@@ -81,22 +100,26 @@ export function SectionCards() {
       </Card>
       <Card className="@container/card">
         <CardHeader>
-          <CardDescription>Requests/min</CardDescription>
+          <CardDescription>Spend (24h)</CardDescription>
           <CardTitle className="text-2xl font-semibold tabular-nums @[250px]/card:text-3xl">
-            1,234
+            {usage ? currencyFormat.format(usage.totalCost) : "-"}
           </CardTitle>
           <CardAction>
             <Badge variant="outline">
-              <IconTrendingUp />
-              +8.2%
+              <IconCoin />
+              {tokenFormat.format(totalTokens)} tokens
             </Badge>
           </CardAction>
         </CardHeader>
         <CardFooter className="flex-col items-start gap-1.5 text-sm">
           <div className="line-clamp-1 flex gap-2 font-medium">
-            Steady performance increase <IconTrendingUp className="size-4" />
+            {usageError ?? usage?.scrapeError ?? `${usage?.items.length ?? 0} provider and model usages`}
+          </div>
+          <div className="text-muted-foreground">
+            {unpricedModels.size > 0
+              ? `No price for ${[...unpricedModels].join(", ")}`
+              : "Priced from the provider model catalogs"}
           </div>
-          <div className="text-muted-foreground">Meets traffic projections</div>
         </CardFooter>
       </Card>
     </div>
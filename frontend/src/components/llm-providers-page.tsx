@@ -39,6 +39,7 @@ import {
   SelectValue,
 } from "@/components/ui/select"
 
+import { ModelNamesTable } from "@/components/model-names-table"
 import { LLMProviderService } from "@/services/llm-provider.service"
 import type { LLMProvider, CreateLLMProviderRequest } from "@/types/llm-provider"
 
@@ -149,10 +150,10 @@ export function LLMProvidersPage() {
     }
   }
 
-  const handleDeleteProvider = async (name: string) => {
+  const handleDeleteProvider = async (name: string, namespace: string) => {
     try {
-      await LLMProviderService.deleteProvider(name)
-      setProviders(prev => prev.filter(p => p.name !== name))
+      await LLMProviderService.deleteProvider(name, namespace)
+      setProviders(prev => prev.filter(p => p.name !== name || p.namespace !== namespace))
     } catch (err) {
       setError(err instanceof Error ? err.message : 'Failed to delete provider')
     }
@@ -164,12 +165,15 @@ export function LLMProvidersPage() {
     // Convert provider to edit form format
     const editForm: CreateLLMProviderRequest = {
       name: provider.name,
+      namespace: provider.namespace,
       schema: provider.schema,
       authType: provider.auth.type,
       host: provider.backend.host,
       port: provider.backend.port,
       tlsHostname: provider.tls?.hostname,
       tlsWellKnownCACertificates: provider.tls?.wellKnownCACertificates,
+      traffic: provider.traffic,
+      models: provider.models,
     }
 
     // Add auth-specific fields
@@ -211,9 +215,8 @@ export function LLMProvidersPage() {
     if (!editingProvider) return
     
     try {
-      // For now, delete and recreate the provider (since there's no PUT endpoint)
-      await LLMProviderService.deleteProvider(editingProvider.name)
-      const updated = await LLMProviderService.createProvider(editProvider)
+      // The ETag is read right before the update, masked credentials are kept by the backend
+      const updated = await LLMProviderService.updateProvider(editingProvider.name, editProvider)
       const rawProvider = await LLMProviderService.getProviderRaw(updated.name)
       
       setProviders(prev => prev.filter(p => p.name !== editingProvider.name).concat(rawProvider))
@@ -855,6 +858,7 @@ export function LLMProvidersPage() {
                   <TableHeader>
                     <TableRow>
                       <TableHead>Name</TableHead>
+                      <TableHead>Namespace</TableHead>
                       <TableHead>Host</TableHead>
                       <TableHead>Port</TableHead>
                       <TableHead>Schema</TableHead>
@@ -864,14 +868,15 @@ export function LLMProvidersPage() {
                   <TableBody>
                     {filteredProviders.length === 0 ? (
                       <TableRow>
-                        <TableCell colSpan={5} className="text-center py-8 text-muted-foreground">
+                        <TableCell colSpan={6} className="text-center py-8 text-muted-foreground">
                           No providers found
                         </TableCell>
                       </TableRow>
                     ) : (
                       filteredProviders.map((provider) => (
-                        <TableRow key={provider.name}>
+                        <TableRow key={`${provider.namespace}/${provider.name}`}>
                           <TableCell className="font-medium">{provider.name}</TableCell>
+                          <TableCell>{provider.namespace}</TableCell>
                           <TableCell>{provider.backend.host}</TableCell>
                           <TableCell>{provider.backend.port}</TableCell>
                           <TableCell>
@@ -891,7 +896,7 @@ export function LLMProvidersPage() {
                               <Button
                                 variant="ghost"
                                 size="sm"
-                                onClick={() => handleDeleteProvider(provider.name)}
+                                onClick={() => handleDeleteProvider(provider.name, provider.namespace)}
                                 className="text-red-600 hover:text-red-700"
                               >
                                 <IconTrash className="w-4 h-4" />
@@ -906,6 +911,8 @@ export function LLMProvidersPage() {
               </div>
             </CardContent>
           </Card>
+
+          <ModelNamesTable namespace={providers.length > 0 ? [...new Set(providers.map(p => p.namespace))].join(",") : undefined} />
         </div>
       </div>
     </div>
@@ -0,0 +1,83 @@
+"use client"
+
+import { useState, useEffect } from "react"
+import { IconArrowRight } from "@tabler/icons-react"
+
+import { Badge } from "@/components/ui/badge"
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from "@/components/ui/card"
+import {
+  Table,
+  TableBody,
+  TableCell,
+  TableHead,
+  TableHeader,
+  TableRow,
+} from "@/components/ui/table"
+
+import { LLMProviderService } from "@/services/llm-provider.service"
+import type { ModelRoute } from "@/types/llm-provider"
+
+// ModelNamesTable lists the logical model names clients request and the upstream name each provider receives
+export function ModelNamesTable({ namespace }: { namespace?: string }) {
+  const [models, setModels] = useState<ModelRoute[]>([])
+  const [error, setError] = useState<string | null>(null)
+
+  useEffect(() => {
+    LLMProviderService.listModels(namespace)
+      .then(list => {
+        setModels(list.items)
+        setError(null)
+      })
+      .catch(err => setError(err instanceof Error ? err.message : 'Failed to load models'))
+  }, [namespace])
+
+  return (
+    <Card className="mt-4">
+      <CardHeader>
+        <CardTitle>Model Names</CardTitle>
+        <CardDescription>
+          Model names requested by clients and the names sent to each provider
+        </CardDescription>
+      </CardHeader>
+      <CardContent>
+        {error && <p className="text-sm text-red-600 mb-4">{error}</p>}
+        <div className="rounded-md border">
+          <Table>
+            <TableHeader>
+              <TableRow>
+                <TableHead>Model</TableHead>
+                <TableHead>Provider</TableHead>
+                <TableHead>Upstream Model</TableHead>
+                <TableHead>Routes</TableHead>
+              </TableRow>
+            </TableHeader>
+            <TableBody>
+              {models.length === 0 ? (
+                <TableRow>
+                  <TableCell colSpan={4} className="text-center py-8 text-muted-foreground">
+                    No models are routed yet.
+                  </TableCell>
+                </TableRow>
+              ) : (
+                models.map(model => (
+                  <TableRow key={`${model.namespace}/${model.model}/${model.provider}/${model.upstream}`}>
+                    <TableCell className="font-medium">{model.model}</TableCell>
+                    <TableCell>{model.provider}</TableCell>
+                    <TableCell>
+                      <div className="flex items-center gap-2">
+                        <IconArrowRight className="w-4 h-4 text-muted-foreground" />
+                        <code className="bg-muted px-2 py-1 rounded text-sm">{model.upstream}</code>
+                        {model.source === 'route' && <Badge variant="outline">set on route</Badge>}
+                      </div>
+                    </TableCell>
+                    <TableCell>{model.routes?.join(", ") || "-"}</TableCell>
+                  </TableRow>
+                ))
+              )}
+            </TableBody>
+          </Table>
+        </div>
+      </CardContent>
+    </Card>
+  )
+}
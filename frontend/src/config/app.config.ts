@@ -1,6 +1,7 @@
 interface AppConfig {
   apiBaseUrl: string;
   apiVersion: string;
+  apiToken?: string;
   environment: 'development' | 'production' | 'staging';
 }
 
@@ -23,11 +24,13 @@ class ConfigService {
     // Get environment variables with fallbacks
     const apiBaseUrl = import.meta.env.VITE_API_BASE_URL;
     const apiVersion = import.meta.env.VITE_API_VERSION || 'v1';
+    const apiToken = import.meta.env.VITE_API_TOKEN || undefined;
     const environment = (import.meta.env.VITE_ENVIRONMENT || 'development') as AppConfig['environment'];
 
     return {
       apiBaseUrl,
       apiVersion,
+      apiToken,
       environment,
     };
   }
@@ -40,6 +43,10 @@ class ConfigService {
     return this.config.apiVersion;
   }
 
+  public get apiToken(): string | undefined {
+    return this.config.apiToken;
+  }
+
   public get environment(): AppConfig['environment'] {
     return this.config.environment;
   }
@@ -6,6 +6,24 @@ export interface ApiResponse<T> {
   status: number;
 }
 
+// Problem details body returned by the backend for every API error
+export interface ApiProblem {
+  type: string;
+  title: string;
+  status: number;
+  detail?: string;
+  instance?: string;
+  code: string; // NotFound, AlreadyExists, Invalid, Forbidden, Conflict, Upstream, ...
+  requestId?: string;
+}
+
+export class ApiError extends Error {
+  constructor(public readonly status: number, public readonly problem?: ApiProblem) {
+    super(problem?.detail || `HTTP error! status: ${status}`);
+    this.name = 'ApiError';
+  }
+}
+
 export class ApiService {
   private static baseUrl = config.getFullApiUrl();
 
@@ -13,30 +31,44 @@ export class ApiService {
     endpoint: string,
     options: RequestInit = {}
   ): Promise<T> {
+    return (await this.requestWithHeaders<T>(endpoint, options)).data;
+  }
+
+  // requestWithHeaders also returns the response headers, e.g. the ETag of a provider
+  private static async requestWithHeaders<T>(
+    endpoint: string,
+    options: RequestInit = {}
+  ): Promise<{ data: T; headers: Headers }> {
     const url = `${this.baseUrl}${endpoint}`;
     
     const defaultOptions: RequestInit = {
       headers: {
         'Content-Type': 'application/json',
+        ...(config.apiToken ? { Authorization: `Bearer ${config.apiToken}` } : {}),
         ...options.headers,
       },
     };
 
-    const requestOptions = { ...defaultOptions, ...options };
+    // Headers are merged above, spreading options last would drop the defaults
+    const requestOptions = { ...options, headers: defaultOptions.headers };
 
     try {
       const response = await fetch(url, requestOptions);
       
       if (!response.ok) {
-        throw new Error(`HTTP error! status: ${response.status} - ${response.statusText}`);
+        let problem: ApiProblem | undefined;
+        if (response.headers.get('content-type')?.includes('json')) {
+          problem = await response.json().catch(() => undefined);
+        }
+        throw new ApiError(response.status, problem);
       }
 
       // Handle empty responses (like DELETE requests)
       if (response.status === 204 || response.headers.get('content-length') === '0') {
-        return {} as T;
+        return { data: {} as T, headers: response.headers };
       }
 
-      return await response.json();
+      return { data: await response.json(), headers: response.headers };
     } catch (error) {
       if (config.isDevelopment()) {
         console.error('API Request failed:', {
@@ -53,6 +85,12 @@ export class ApiService {
     return this.request<T>(endpoint, { method: 'GET' });
   }
 
+  // getWithETag returns the body together with the ETag header to send back as If-Match
+  static async getWithETag<T>(endpoint: string): Promise<{ data: T; etag?: string }> {
+    const { data, headers } = await this.requestWithHeaders<T>(endpoint, { method: 'GET' });
+    return { data, etag: headers.get('ETag') ?? undefined };
+  }
+
   static async post<T>(endpoint: string, data?: unknown): Promise<T> {
     return this.request<T>(endpoint, {
       method: 'POST',
@@ -60,15 +98,16 @@ export class ApiService {
     });
   }
 
-  static async put<T>(endpoint: string, data?: unknown): Promise<T> {
+  static async put<T>(endpoint: string, data?: unknown, headers?: HeadersInit): Promise<T> {
     return this.request<T>(endpoint, {
       method: 'PUT',
       body: data ? JSON.stringify(data) : undefined,
+      headers,
     });
   }
 
-  static async delete<T>(endpoint: string): Promise<T> {
-    return this.request<T>(endpoint, { method: 'DELETE' });
+  static async delete<T>(endpoint: string, headers?: HeadersInit): Promise<T> {
+    return this.request<T>(endpoint, { method: 'DELETE', headers });
   }
 
   static async patch<T>(endpoint: string, data?: unknown): Promise<T> {
@@ -1,7 +1,10 @@
 import type { 
   LLMProvider, 
+  LLMProviderList, 
+  LLMProviderListQuery, 
   CreateLLMProviderRequest, 
-  LLMProviderDisplay
+  LLMProviderDisplay,
+  ModelList
 } from '@/types/llm-provider';
 import { createLLMProviderFromForm, toLLMProviderDisplay } from '@/types/llm-provider';
 import { ApiService } from './api.service';
@@ -9,13 +12,27 @@ import { ApiService } from './api.service';
 export class LLMProviderService {
   private static readonly BASE_ENDPOINT = '/llm/providers';
 
-  static async getProviders(): Promise<LLMProviderDisplay[]> {
-    const providers = await ApiService.get<LLMProvider[]>(`${this.BASE_ENDPOINT}`);
+  // namespace accepts a single namespace, a comma separated list or '*' for all namespaces
+  static async getProviders(namespace?: string): Promise<LLMProviderDisplay[]> {
+    const providers = await this.getProvidersRaw(namespace);
     return providers.map(toLLMProviderDisplay);
   }
 
-  static async getProvidersRaw(): Promise<LLMProvider[]> {
-    return ApiService.get<LLMProvider[]>(`${this.BASE_ENDPOINT}`);
+  static async getProvidersRaw(namespace?: string): Promise<LLMProvider[]> {
+    const list = await this.listProviders(namespace);
+    return list.items;
+  }
+
+  static async listProviders(query: string | LLMProviderListQuery = {}): Promise<LLMProviderList> {
+    const params = new URLSearchParams();
+    const options = typeof query === 'string' ? { namespace: query } : query;
+    Object.entries(options).forEach(([key, value]) => {
+      if (value !== undefined && value !== '') {
+        params.set(key, String(value));
+      }
+    });
+    const search = params.toString();
+    return ApiService.get<LLMProviderList>(`${this.BASE_ENDPOINT}${search ? `?${search}` : ''}`);
   }
 
   static async getProviderByName(name: string): Promise<LLMProviderDisplay> {
@@ -33,13 +50,33 @@ export class LLMProviderService {
     return toLLMProviderDisplay(created);
   }
 
-  static async deleteProvider(name: string): Promise<void> {
-    return ApiService.delete<void>(`${this.BASE_ENDPOINT}/${encodeURIComponent(name)}`);
+  // getProviderWithETag returns the provider and the ETag required to update or delete it
+  static async getProviderWithETag(name: string, namespace?: string): Promise<{ provider: LLMProvider; etag?: string }> {
+    const { data, etag } = await ApiService.getWithETag<LLMProvider>(this.providerEndpoint(name, namespace));
+    return { provider: data, etag };
+  }
+
+  // Without an etag the provider is read first, so the delete only applies to its current version
+  static async deleteProvider(name: string, namespace?: string, etag?: string): Promise<void> {
+    const ifMatch = etag ?? (await this.getProviderWithETag(name, namespace)).etag ?? '*';
+    return ApiService.delete<void>(this.providerEndpoint(name, namespace), { 'If-Match': ifMatch });
   }
 
-  static async updateProvider(name: string, providerForm: CreateLLMProviderRequest): Promise<LLMProviderDisplay> {
+  static async updateProvider(name: string, providerForm: CreateLLMProviderRequest, etag?: string): Promise<LLMProviderDisplay> {
     const provider = createLLMProviderFromForm(providerForm);
-    const updated = await ApiService.put<LLMProvider>(`${this.BASE_ENDPOINT}/${encodeURIComponent(name)}`, provider);
+    const ifMatch = etag ?? (await this.getProviderWithETag(name, provider.namespace)).etag ?? '*';
+    const updated = await ApiService.put<LLMProvider>(this.providerEndpoint(name, provider.namespace), provider, { 'If-Match': ifMatch });
     return toLLMProviderDisplay(updated);
   }
+
+  // listModels returns the logical model names and the upstream name each provider receives
+  static async listModels(namespace?: string): Promise<ModelList> {
+    const query = namespace ? `?namespace=${encodeURIComponent(namespace)}` : '';
+    return ApiService.get<ModelList>(`/llm/models${query}`);
+  }
+
+  private static providerEndpoint(name: string, namespace?: string): string {
+    const query = namespace ? `?namespace=${encodeURIComponent(namespace)}` : '';
+    return `${this.BASE_ENDPOINT}/${encodeURIComponent(name)}${query}`;
+  }
 }
@@ -0,0 +1,17 @@
+import type { UsageQuery, UsageReport } from '@/types/usage';
+import { ApiService } from './api.service';
+
+export class UsageService {
+  private static readonly BASE_ENDPOINT = '/usage';
+
+  static async getUsage(query: UsageQuery = {}): Promise<UsageReport> {
+    const params = new URLSearchParams();
+    Object.entries(query).forEach(([key, value]) => {
+      if (value !== undefined && value !== '') {
+        params.set(key, String(value));
+      }
+    });
+    const search = params.toString();
+    return ApiService.get<UsageReport>(`${this.BASE_ENDPOINT}${search ? `?${search}` : ''}`);
+  }
+}
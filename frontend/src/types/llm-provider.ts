@@ -2,11 +2,54 @@
 
 export interface LLMProvider {
   name: string;
+  namespace: string;
   schema: string; // OpenAI, AWSBedrock, AzureOpenAI, GCPVertexAI
   version?: string;
   auth: AuthConfig;
   backend: Backend;
   tls: TLSValidation;
+  traffic?: TrafficPolicy; // timeouts, retries and limits towards the backend
+  models?: ModelMapping[]; // logical model names mapped to the names the provider expects
+  // Read-only fields reported by the backend
+  createdAt?: string;
+  status?: string; // Accepted, NotAccepted, Broken; empty until reconciled
+}
+
+// Response envelope of GET /llm/providers
+export interface LLMProviderList {
+  items: LLMProvider[];
+  total: number;
+  continue?: string; // token for the next page
+  remainingItemCount?: number;
+  namespaceErrors?: NamespaceError[]; // namespaces the console could not read
+  errors?: ProviderError[]; // providers that could not be loaded or translated
+}
+
+// Query parameters accepted by GET /llm/providers
+export interface LLMProviderListQuery {
+  namespace?: string; // single namespace, comma separated list or '*'
+  limit?: number;
+  continue?: string;
+  sort?: 'name' | 'schema' | 'creationTimestamp' | 'status';
+  order?: 'asc' | 'desc';
+  schema?: string;
+  authType?: string;
+  host?: string;
+  labelSelector?: string;
+}
+
+export interface NamespaceError {
+  namespace: string;
+  reason: string;
+  message: string;
+}
+
+// A broken provider, also listed with the Broken status when it matches the filters
+export interface ProviderError {
+  name: string;
+  namespace: string;
+  stage: 'load' | 'translate';
+  message: string;
 }
 
 export interface AuthConfig {
@@ -63,9 +106,66 @@ export interface TLSValidation {
   wellKnownCACertificates: string; // e.g., "System"
 }
 
+export interface TrafficPolicy {
+  requestTimeout?: string; // e.g., "60s"
+  connectTimeout?: string;
+  retry?: RetryPolicy;
+  circuitBreaker?: CircuitBreakerPolicy;
+  connection?: ConnectionLimits;
+}
+
+export interface RetryPolicy {
+  numRetries: number;
+  statusCodes?: number[]; // defaults to 429, 500, 502, 503, 504
+  perTryTimeout?: string;
+  baseInterval?: string;
+  maxInterval?: string;
+}
+
+export interface CircuitBreakerPolicy {
+  maxPendingRequests?: number;
+  maxParallelRequests?: number;
+  maxParallelRetries?: number;
+}
+
+export interface ConnectionLimits {
+  maxConnections?: number;
+  maxRequestsPerConnection?: number;
+  bufferLimit?: string; // e.g., "32Ki"
+}
+
+// Entry of the model catalog of a provider
+export interface ModelMapping {
+  name: string; // logical model requested by clients, e.g. gpt-4o
+  upstream?: string; // model name sent to the provider, e.g. an Azure deployment; name when empty
+  pricing?: ModelPricing;
+}
+
+// USD per million tokens
+export interface ModelPricing {
+  input: number;
+  output: number;
+}
+
+// Row of GET /llm/models: the upstream name a provider receives for a logical model
+export interface ModelRoute {
+  namespace: string;
+  model: string;
+  provider: string;
+  upstream: string;
+  source?: 'provider' | 'route'; // empty when the model name is passed as is
+  routes?: string[];
+}
+
+export interface ModelList {
+  items: ModelRoute[];
+  namespaceErrors?: NamespaceError[];
+}
+
 // Simplified interface for creating new providers (frontend form)
 export interface CreateLLMProviderRequest {
   name: string;
+  namespace?: string;
   schema: string; // OpenAI, AWSBedrock, AzureOpenAI, GCPVertexAI
   version?: string;
   
@@ -110,6 +210,10 @@ export interface CreateLLMProviderRequest {
   // TLS
   tlsHostname?: string;
   tlsWellKnownCACertificates?: string;
+
+  // Traffic settings and model mappings are not edited by the form but kept on update
+  traffic?: TrafficPolicy;
+  models?: ModelMapping[];
 }
 
 // Helper functions to transform between frontend and backend formats
@@ -153,6 +257,7 @@ export function createLLMProviderFromForm(form: CreateLLMProviderRequest): LLMPr
 
   return {
     name: form.name,
+    namespace: form.namespace || 'default',
     schema: form.schema,
     version: form.version,
     auth: authConfig,
@@ -164,12 +269,15 @@ export function createLLMProviderFromForm(form: CreateLLMProviderRequest): LLMPr
       hostname: form.tlsHostname || form.host,
       wellKnownCACertificates: form.tlsWellKnownCACertificates || 'System',
     },
+    traffic: form.traffic,
+    models: form.models,
   };
 }
 
 // Display helpers for the UI
 export interface LLMProviderDisplay {
   name: string;
+  namespace: string;
   type: string; // schema
   model: string; // derived from schema/version
   endpoint: string; // derived from backend
@@ -185,6 +293,7 @@ export function toLLMProviderDisplay(provider: LLMProvider): LLMProviderDisplay
   
   return {
     name: provider.name,
+    namespace: provider.namespace,
     type: provider.schema,
     model,
     endpoint,
@@ -0,0 +1,36 @@
+// Types matching the usage report of GET /usage
+
+import type { NamespaceError } from './llm-provider';
+
+export interface UsageRecord {
+  namespace: string; // empty when the usage could not be attributed to a provider
+  provider: string;
+  model: string;
+  system?: string; // system name the gateway reported instead of the backend, e.g. openai
+  start: string; // start of the time bucket
+  inputTokens: number;
+  outputTokens: number;
+  totalTokens: number;
+  cost?: number; // USD, omitted when the provider has no price for the model
+}
+
+export interface UsageReport {
+  since: string;
+  until: string;
+  bucket: string; // e.g. "1h0m0s"
+  items: UsageRecord[];
+  totalCost: number;
+  currency: string;
+  lastScrape?: string;
+  scrapeError?: string;
+  namespaceErrors?: NamespaceError[];
+}
+
+export interface UsageQuery {
+  namespace?: string; // single namespace, comma separated list or '*'
+  provider?: string;
+  model?: string;
+  since?: string; // RFC 3339
+  until?: string;
+  bucket?: string; // e.g. "1h"
+}